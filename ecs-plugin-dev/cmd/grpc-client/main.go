@@ -2,33 +2,75 @@
 package main
 
 import (
+	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"flag"
 	"fmt"
+	"io"
 	"log"
+	"os"
+	"strings"
 	"time"
 
 	pb "ecs-plugin-dev/proto"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
 )
 
 func main() {
 	var (
-		server     = flag.String("server", "localhost:50051", "gRPC server address")
-		action     = flag.String("action", "deploy", "Action: deploy, status, rollback")
-		deployID   = flag.String("id", "", "Deployment ID")
-		cluster    = flag.String("cluster", "", "ECS Cluster ARN")
-		service    = flag.String("service", "", "ECS Service Name")
-		taskDef    = flag.String("taskdef", "", "Task Definition JSON file")
-		strategy   = flag.String("strategy", "quicksync", "Deployment strategy")
-		configJSON = flag.String("config", "{}", "Config JSON")
+		server       = flag.String("server", "localhost:50051", "gRPC server address")
+		action       = flag.String("action", "deploy", "Action: deploy, status, watch, rollback, cancel, retry, pause, resume, approve, reject, approval-status, pending-approvals, list, summary, analytics, manifest, api-calls, force-release, health, list-strategies, deploy-batch, batch-status, diff, drift, reconcile-drift, metrics")
+		deployID     = flag.String("id", "", "Deployment ID")
+		cluster      = flag.String("cluster", "", "ECS Cluster ARN")
+		service      = flag.String("service", "", "ECS Service Name")
+		taskDef      = flag.String("taskdef", "", "Task Definition JSON file (rollback: target revision ARN or family:revision; drift, reconcile-drift: expected revision)")
+		strategy     = flag.String("strategy", "quicksync", "Deployment strategy")
+		configJSON   = flag.String("config", "{}", "Config JSON")
+		dependsOn    = flag.String("depends-on", "", "Comma-separated deployment IDs that must succeed first")
+		services     = flag.String("services", "", "Comma-separated services to deploy together (deploy-batch)")
+		allOrNothing = flag.Bool("all-or-nothing", false, "Roll back the whole batch if any service fails (deploy-batch)")
+		approver     = flag.String("approver", "", "Approver name (approve/reject) or operator (force-release, reconcile-drift)")
+		reason       = flag.String("reason", "", "Reason (approve/reject/force-release)")
+		statusFilter = flag.String("status", "", "Only list deployments in this status (list)")
+		limit        = flag.Int("limit", 0, "Maximum deployments to list, most recent first (list)")
+		dryRun       = flag.Bool("dry-run", false, "Print the planned AWS operations without deploying (deploy)")
+		requireAppr  = flag.Bool("require-approval", false, "Hold the deployment until it is approved (deploy)")
+		force        = flag.Bool("force", false, "Rerun a finished deployment with the same -id (deploy)")
+		scheduleAt   = flag.String("scheduled-at", "", "Start the deployment at this RFC 3339 time instead of now (deploy)")
+		newID        = flag.String("new-id", "", "ID for the retried deployment (retry; default: <id>-retry-<n>)")
+		useTLS       = flag.Bool("tls", false, "Connect with TLS (implied by -ca-cert, -cert and -key)")
+		caCert       = flag.String("ca-cert", "", "CA certificate used to verify the server (default: system roots)")
+		clientCert   = flag.String("cert", "", "Client certificate for mutual TLS")
+		clientKey    = flag.String("key", "", "Client private key for mutual TLS")
+		serverName   = flag.String("server-name", "", "Server name to verify in the server certificate (default: host from -server)")
+		token        = flag.String("token", os.Getenv("ECS_PLUGIN_TOKEN"), "Bearer token or API key (default: $ECS_PLUGIN_TOKEN)")
+		correlation  = flag.String("correlation-id", "", "Correlation ID to tag the call's server logs and audit events with (default: minted by the server)")
 	)
 	flag.Parse()
 
-	conn, err := grpc.Dial(*server, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	creds := insecure.NewCredentials()
+	if *useTLS || *caCert != "" || *clientCert != "" || *clientKey != "" {
+		var err error
+		creds, err = clientTLSCredentials(*caCert, *clientCert, *clientKey, *serverName)
+		if err != nil {
+			log.Fatalf("tls setup failed: %v", err)
+		}
+	}
+
+	dialOpts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	if *token != "" {
+		dialOpts = append(dialOpts, grpc.WithPerRPCCredentials(bearerToken(*token)))
+	}
+
+	conn, err := grpc.Dial(*server, dialOpts...)
 	if err != nil {
 		log.Fatalf("connection failed: %v", err)
 	}
@@ -37,25 +79,179 @@ func main() {
 	client := pb.NewDeploymentServiceClient(conn)
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
+	if *correlation != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-correlation-id", *correlation)
+	}
 
 	switch *action {
 	case "deploy":
 		var config map[string]string
 		json.Unmarshal([]byte(*configJSON), &config)
 
+		var deps []string
+		if *dependsOn != "" {
+			deps = strings.Split(*dependsOn, ",")
+		}
+
+		var scheduledAt int64
+		if *scheduleAt != "" {
+			at, err := time.Parse(time.RFC3339, *scheduleAt)
+			if err != nil {
+				log.Fatalf("invalid -scheduled-at %q: %v", *scheduleAt, err)
+			}
+			scheduledAt = at.Unix()
+		}
+
 		resp, err := client.Deploy(ctx, &pb.DeployRequest{
-			DeploymentId:   *deployID,
-			ClusterArn:     *cluster,
-			ServiceName:    *service,
-			TaskDefinition: *taskDef,
-			Strategy:       *strategy,
-			Config:         config,
+			DeploymentId:    *deployID,
+			ClusterArn:      *cluster,
+			ServiceName:     *service,
+			TaskDefinition:  *taskDef,
+			Strategy:        *strategy,
+			Config:          config,
+			DependsOn:       deps,
+			DryRun:          *dryRun,
+			RequireApproval: *requireAppr,
+			Force:           *force,
+			ScheduledAtUnix: scheduledAt,
 		})
 		if err != nil {
 			log.Fatalf("deploy failed: %v", err)
 		}
 		fmt.Printf("Success: %v\nMessage: %s\nDeployment ID: %s\n",
 			resp.Success, resp.Message, resp.DeploymentId)
+		if resp.PendingApproval {
+			fmt.Println("Pending approval: yes")
+		}
+		if resp.QueuePosition > 0 {
+			fmt.Printf("Queue position: %d\n", resp.QueuePosition)
+		}
+		if resp.ExistingStatus != "" {
+			fmt.Printf("Existing deployment status: %s\n", resp.ExistingStatus)
+		}
+		if resp.ErrorCode != "" {
+			fmt.Printf("Error Code: %s\n", resp.ErrorCode)
+		}
+		for i, step := range resp.PlannedSteps {
+			fmt.Printf("  %d. %s\n", i+1, step)
+		}
+
+	case "deploy-batch":
+		var config map[string]string
+		json.Unmarshal([]byte(*configJSON), &config)
+
+		// -id names the batch; each service's deployment is <id>-<service>
+		req := &pb.BatchDeployRequest{BatchId: *deployID, AllOrNothing: *allOrNothing}
+		for _, svc := range strings.Split(*services, ",") {
+			if svc = strings.TrimSpace(svc); svc == "" {
+				continue
+			}
+			req.Deployments = append(req.Deployments, &pb.DeployRequest{
+				DeploymentId:    fmt.Sprintf("%s-%s", *deployID, svc),
+				ClusterArn:      *cluster,
+				ServiceName:     svc,
+				TaskDefinition:  *taskDef,
+				Strategy:        *strategy,
+				Config:          config,
+				DryRun:          *dryRun,
+				RequireApproval: *requireAppr,
+			})
+		}
+
+		resp, err := client.DeployBatch(ctx, req)
+		if err != nil {
+			log.Fatalf("batch deploy failed: %v", err)
+		}
+		fmt.Printf("Success: %v\nMessage: %s\nBatch ID: %s\n", resp.Success, resp.Message, resp.BatchId)
+		if resp.ErrorCode != "" {
+			fmt.Printf("Error Code: %s\n", resp.ErrorCode)
+		}
+		for _, result := range resp.Results {
+			fmt.Printf("  %s (%s/%s): success=%v %s\n", result.DeploymentId, result.ClusterArn, result.ServiceName, result.Success, result.Message)
+		}
+
+	case "batch-status":
+		resp, err := client.GetBatchStatus(ctx, &pb.BatchStatusRequest{BatchId: *deployID})
+		if err != nil {
+			log.Fatalf("batch status failed: %v", err)
+		}
+		fmt.Printf("Status: %s\nMessage: %s\nAll or nothing: %v\n", resp.Status, resp.Message, resp.AllOrNothing)
+		for _, d := range resp.Deployments {
+			fmt.Printf("  %s (%s): %s %d%% %s\n", d.DeploymentId, d.ServiceName, d.Status, d.Progress, d.Message)
+		}
+
+	case "diff":
+		resp, err := client.Diff(ctx, &pb.DiffRequest{
+			ClusterArn:     *cluster,
+			ServiceName:    *service,
+			TaskDefinition: *taskDef,
+		})
+		if err != nil {
+			log.Fatalf("diff failed: %v", err)
+		}
+		fmt.Printf("Success: %v\nMessage: %s\n", resp.Success, resp.Message)
+		if resp.ErrorCode != "" {
+			fmt.Printf("Error Code: %s\n", resp.ErrorCode)
+		}
+		if resp.CurrentTaskDefinition != "" {
+			fmt.Printf("Current: %s\n", resp.CurrentTaskDefinition)
+		}
+		for _, c := range resp.Changes {
+			field := c.Field
+			switch {
+			case c.Field == "container":
+				field = "container " + c.Container
+			case c.Container != "":
+				field = c.Container + " " + c.Field
+			}
+			switch c.Change {
+			case "added":
+				fmt.Printf("  + %s: %s\n", field, c.NewValue)
+			case "removed":
+				fmt.Printf("  - %s: %s\n", field, c.OldValue)
+			default:
+				fmt.Printf("  ~ %s: %s -> %s\n", field, c.OldValue, c.NewValue)
+			}
+		}
+
+	case "drift", "reconcile-drift":
+		req := &pb.DriftRequest{
+			ClusterArn:             *cluster,
+			ServiceName:            *service,
+			ExpectedTaskDefinition: *taskDef,
+			Operator:               *approver,
+		}
+		var resp *pb.DriftResponse
+		var err error
+		if *action == "drift" {
+			resp, err = client.DetectDrift(ctx, req)
+		} else {
+			// Waits up to 5 minutes for the service to stabilize, so it is not
+			// bound by the request timeout
+			reconcileCtx, cancelReconcile := context.WithTimeout(context.Background(), 6*time.Minute)
+			defer cancelReconcile()
+			resp, err = client.ReconcileDrift(reconcileCtx, req)
+		}
+		if err != nil {
+			log.Fatalf("%s failed: %v", *action, err)
+		}
+		fmt.Printf("Success: %v\nMessage: %s\n", resp.Success, resp.Message)
+		if resp.ErrorCode != "" {
+			fmt.Printf("Error Code: %s\n", resp.ErrorCode)
+		}
+		if resp.DriftStatus != "" {
+			fmt.Printf("Drift: %s\n", resp.DriftStatus)
+		}
+		for _, d := range resp.Drifts {
+			field := d.Field
+			if d.Container != "" {
+				field = "container " + d.Container + " " + d.Field
+			}
+			fmt.Printf("  %s: expected %q, found %q\n", field, d.Expected, d.Actual)
+		}
+		if resp.ReconcileAction != "" {
+			fmt.Printf("Action: %s\n", resp.ReconcileAction)
+		}
 
 	case "status":
 		resp, err := client.GetStatus(ctx, &pb.StatusRequest{
@@ -64,27 +260,341 @@ func main() {
 		if err != nil {
 			log.Fatalf("status check failed: %v", err)
 		}
-		fmt.Printf("Status: %s\nProgress: %d%%\nMessage: %s\n",
-			resp.Status, resp.Progress, resp.Message)
+		fmt.Printf("Status: %s\nProgress: %d%%\nMessage: %s\nStrategy: %s\nService: %s/%s\n",
+			resp.Status, resp.Progress, resp.Message, resp.Strategy, resp.ClusterArn, resp.ServiceName)
+		if resp.StartTimeUnix > 0 {
+			fmt.Printf("Started: %s\n", time.Unix(resp.StartTimeUnix, 0).Format(time.RFC3339))
+		}
+		if resp.EndTimeUnix > 0 {
+			fmt.Printf("Ended: %s\n", time.Unix(resp.EndTimeUnix, 0).Format(time.RFC3339))
+		}
+		fmt.Printf("Duration: %.1fs\n", resp.DurationSeconds)
+		if resp.EtaSeconds > 0 {
+			fmt.Printf("ETA: %v\n", time.Duration(resp.EtaSeconds)*time.Second)
+		}
+		if resp.ErrorCode != "" {
+			fmt.Printf("Error Code: %s\n", resp.ErrorCode)
+		}
+
+	case "watch":
+		// Streams until the deployment finishes, so it is not bound by the request timeout
+		stream, err := client.StreamStatus(context.Background(), &pb.StatusRequest{
+			DeploymentId: *deployID,
+		})
+		if err != nil {
+			log.Fatalf("watch failed: %v", err)
+		}
+		for {
+			resp, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				log.Fatalf("watch failed: %v", err)
+			}
+			fmt.Printf("[%s] %s %d%% %s\n", time.Now().Format(time.RFC3339), resp.Status, resp.Progress, resp.Message)
+		}
 
 	case "rollback":
 		resp, err := client.Rollback(ctx, &pb.RollbackRequest{
-			DeploymentId: *deployID,
-			ClusterArn:   *cluster,
-			ServiceName:  *service,
+			DeploymentId:   *deployID,
+			ClusterArn:     *cluster,
+			ServiceName:    *service,
+			TaskDefinition: *taskDef,
 		})
 		if err != nil {
 			log.Fatalf("rollback failed: %v", err)
 		}
 		fmt.Printf("Success: %v\nMessage: %s\n", resp.Success, resp.Message)
+		if resp.ErrorCode != "" {
+			fmt.Printf("Error Code: %s\n", resp.ErrorCode)
+		}
+		for _, result := range resp.Results {
+			fmt.Printf("  %s/%s: success=%v %s\n", result.ClusterArn, result.ServiceName, result.Success, result.Message)
+		}
+
+	case "cancel":
+		resp, err := client.Cancel(ctx, &pb.CancelRequest{
+			DeploymentId: *deployID,
+		})
+		if err != nil {
+			log.Fatalf("cancel failed: %v", err)
+		}
+		fmt.Printf("Success: %v\nMessage: %s\nStatus: %s\n", resp.Success, resp.Message, resp.Status)
+		if resp.ErrorCode != "" {
+			fmt.Printf("Error Code: %s\n", resp.ErrorCode)
+		}
+
+	case "retry":
+		resp, err := client.RetryDeployment(ctx, &pb.RetryRequest{
+			DeploymentId:    *deployID,
+			NewDeploymentId: *newID,
+		})
+		if err != nil {
+			log.Fatalf("retry failed: %v", err)
+		}
+		fmt.Printf("Success: %v\nMessage: %s\nDeployment ID: %s\nRetried from: %s\n",
+			resp.Success, resp.Message, resp.DeploymentId, resp.RetriedFrom)
+		if resp.QueuePosition > 0 {
+			fmt.Printf("Queue position: %d\n", resp.QueuePosition)
+		}
+		if resp.ErrorCode != "" {
+			fmt.Printf("Error Code: %s\n", resp.ErrorCode)
+		}
+
+	case "pause":
+		resp, err := client.Pause(ctx, &pb.PauseRequest{
+			DeploymentId: *deployID,
+		})
+		if err != nil {
+			log.Fatalf("pause failed: %v", err)
+		}
+		fmt.Printf("Success: %v\nMessage: %s\nStatus: %s\n", resp.Success, resp.Message, resp.Status)
+		if resp.ErrorCode != "" {
+			fmt.Printf("Error Code: %s\n", resp.ErrorCode)
+		}
+
+	case "resume":
+		resp, err := client.Resume(ctx, &pb.ResumeRequest{
+			DeploymentId: *deployID,
+		})
+		if err != nil {
+			log.Fatalf("resume failed: %v", err)
+		}
+		fmt.Printf("Success: %v\nMessage: %s\nStatus: %s\n", resp.Success, resp.Message, resp.Status)
+		if resp.ErrorCode != "" {
+			fmt.Printf("Error Code: %s\n", resp.ErrorCode)
+		}
+
+	case "approve", "reject":
+		resp, err := client.ApproveDeployment(ctx, &pb.ApprovalRequest{
+			DeploymentId: *deployID,
+			Approved:     *action == "approve",
+			Approver:     *approver,
+			Reason:       *reason,
+		})
+		if err != nil {
+			log.Fatalf("%s failed: %v", *action, err)
+		}
+		fmt.Printf("Success: %v\nMessage: %s\n", resp.Success, resp.Message)
+		if resp.ErrorCode != "" {
+			fmt.Printf("Error Code: %s\n", resp.ErrorCode)
+		}
+
+	case "approval-status":
+		resp, err := client.GetApprovalStatus(ctx, &pb.ApprovalStatusRequest{DeploymentId: *deployID})
+		if err != nil {
+			log.Fatalf("approval-status failed: %v", err)
+		}
+		if !resp.Success {
+			fmt.Printf("Success: false\nMessage: %s\nError Code: %s\n", resp.Message, resp.ErrorCode)
+			break
+		}
+		a := resp.Approval
+		fmt.Printf("Status: %s\nRequested: %s\nExpires: %s\n", a.Status,
+			time.Unix(a.RequestedAtUnix, 0).Format(time.RFC3339), time.Unix(a.ExpiresAtUnix, 0).Format(time.RFC3339))
+		if a.GroupId != "" {
+			fmt.Printf("Group: %s (%s)\n", a.GroupId, strings.Join(a.Members, ", "))
+		}
+		if a.Approver != "" {
+			fmt.Printf("Decided by: %s (%s)\n", a.Approver, a.Reason)
+		}
+
+	case "pending-approvals":
+		resp, err := client.ListPendingApprovals(ctx, &pb.PendingApprovalsRequest{})
+		if err != nil {
+			log.Fatalf("pending-approvals failed: %v", err)
+		}
+		for _, a := range resp.Approvals {
+			target := fmt.Sprintf("%s/%s\t%s", a.ClusterArn, a.ServiceName, a.Strategy)
+			if a.GroupId != "" {
+				target = fmt.Sprintf("group: %s", strings.Join(a.Members, ", "))
+			}
+			fmt.Printf("%s\t%s\t%s\n", a.DeploymentId, time.Unix(a.RequestedAtUnix, 0).Format(time.RFC3339), target)
+		}
+
+	case "force-release":
+		resp, err := client.ForceRelease(ctx, &pb.ForceReleaseRequest{
+			ClusterArn:  *cluster,
+			ServiceName: *service,
+			Operator:    *approver,
+			Reason:      *reason,
+		})
+		if err != nil {
+			log.Fatalf("force-release failed: %v", err)
+		}
+		fmt.Printf("Success: %v\nMessage: %s\n", resp.Success, resp.Message)
+		if resp.ErrorCode != "" {
+			fmt.Printf("Error Code: %s\n", resp.ErrorCode)
+		}
+
+	case "list":
+		resp, err := client.ListDeployments(ctx, &pb.ListRequest{
+			Status: *statusFilter,
+			Limit:  int32(*limit),
+		})
+		if err != nil {
+			log.Fatalf("list failed: %v", err)
+		}
+		for _, d := range resp.Deployments {
+			fmt.Printf("%s\t%s\t%s\t%s/%s\t%s\n", d.DeploymentId, d.Status, d.Strategy, d.ClusterArn, d.ServiceName,
+				time.Unix(d.StartTimeUnix, 0).Format(time.RFC3339))
+		}
+
+	case "summary":
+		resp, err := client.SummarizeServices(ctx, &pb.SummaryRequest{})
+		if err != nil {
+			log.Fatalf("summary failed: %v", err)
+		}
+		for _, svc := range resp.Services {
+			fmt.Printf("%s/%s\n  Last: %s (%s) %s\n  In flight: %s\n  Drift monitored: %v\n  Deployments: %d (%.1f%% success)\n",
+				svc.ClusterArn, svc.ServiceName, svc.LastDeploymentId, svc.LastStatus, svc.LastMessage,
+				svc.InFlightDeploymentId, svc.DriftMonitored, svc.TotalDeployments, svc.SuccessRate)
+		}
+
+	case "analytics":
+		// -strategy defaults to quicksync for deploys, so only filter when it was given
+		var strategyFilter string
+		flag.Visit(func(f *flag.Flag) {
+			if f.Name == "strategy" {
+				strategyFilter = *strategy
+			}
+		})
+		resp, err := client.GetAnalytics(ctx, &pb.AnalyticsRequest{Strategy: strategyFilter})
+		if err != nil {
+			log.Fatalf("analytics failed: %v", err)
+		}
+		fmt.Printf("Deployments: %d (success %d, failed %d, cancelled %d, %.1f%% success)\n",
+			resp.TotalDeployments, resp.SuccessfulDeployments, resp.FailedDeployments, resp.CancelledDeployments, resp.SuccessRate)
+		fmt.Printf("Duration: avg %.1fs, trimmed avg %.1fs, fastest %.1fs, slowest %.1fs (raw %.1fs)\n",
+			resp.AverageDurationSeconds, resp.TrimmedAverageDurationSeconds, resp.FastestDurationSeconds,
+			resp.SlowestDurationSeconds, resp.RawSlowestDurationSeconds)
+		for name, count := range resp.StrategyBreakdown {
+			fmt.Printf("  strategy %s: %d\n", name, count)
+		}
+		for msg, count := range resp.ErrorBreakdown {
+			fmt.Printf("  error %q: %d\n", msg, count)
+		}
+
+	case "metrics":
+		resp, err := client.GetMetrics(ctx, &pb.Empty{})
+		if err != nil {
+			log.Fatalf("metrics failed: %v", err)
+		}
+		fmt.Printf("Deployments: %d (in progress %d)\n", resp.DeploymentsTotal, resp.DeploymentsInProgress)
+		for status, count := range resp.DeploymentsByStatus {
+			fmt.Printf("  %s: %d\n", status, count)
+		}
+		fmt.Printf("AWS API calls: %d (errors %d)\n", resp.AwsApiCalls, resp.AwsApiErrors)
+		for component, count := range resp.ErrorsByComponent {
+			fmt.Printf("  errors in %s: %d\n", component, count)
+		}
+
+	case "manifest":
+		resp, err := client.GetManifest(ctx, &pb.ManifestRequest{
+			DeploymentId: *deployID,
+		})
+		if err != nil {
+			log.Fatalf("manifest failed: %v", err)
+		}
+		if !resp.Success {
+			log.Fatalf("manifest unavailable: %s", resp.Message)
+		}
+		var pretty bytes.Buffer
+		if err := json.Indent(&pretty, []byte(resp.ManifestJson), "", "  "); err != nil {
+			log.Fatalf("invalid manifest: %v", err)
+		}
+		fmt.Println(pretty.String())
+
+	case "api-calls":
+		resp, err := client.GetAPICalls(ctx, &pb.APICallsRequest{
+			DeploymentId: *deployID,
+		})
+		if err != nil {
+			log.Fatalf("api-calls failed: %v", err)
+		}
+		if !resp.Success {
+			log.Fatalf("api calls unavailable: %s", resp.Message)
+		}
+		fmt.Println(resp.Message)
+		for _, call := range resp.Calls {
+			fmt.Printf("  %s %s:%s %s %dms\n",
+				time.UnixMilli(call.TimestampUnixMs).Format(time.RFC3339), call.Service, call.Operation, call.Status, call.DurationMs)
+		}
+
+	case "health":
+		resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{
+			Service: *service,
+		})
+		if err != nil {
+			log.Fatalf("health check failed: %v", err)
+		}
+		fmt.Printf("Status: %s\n", resp.Status)
 
 	case "list-strategies":
+		resp, err := client.ListStrategies(ctx, &pb.Empty{})
+		if err != nil {
+			log.Fatalf("list strategies failed: %v", err)
+		}
 		fmt.Println("Available deployment strategies:")
-		fmt.Println("  - quicksync   : Instant deployment")
-		fmt.Println("  - canary      : Gradual rollout (configurable %)")
-		fmt.Println("  - bluegreen   : Complete traffic switch")
+		for _, info := range resp.Strategies {
+			fmt.Printf("  - %-12s: %s\n", info.Name, info.Description)
+			if len(info.ConfigKeys) > 0 {
+				fmt.Printf("      config: %s\n", strings.Join(info.ConfigKeys, ", "))
+			}
+			if info.SupportsPause {
+				fmt.Println("      supports pause/resume")
+			}
+		}
 
 	default:
-		log.Fatalf("unknown action: %s (available: deploy, status, rollback, list-strategies)", *action)
+		log.Fatalf("unknown action: %s (available: deploy, status, watch, rollback, cancel, pause, resume, approve, reject, list, summary, analytics, manifest, api-calls, force-release, health, list-strategies, deploy-batch, batch-status, diff, drift, reconcile-drift)", *action)
+	}
+}
+
+// clientTLSCredentials verifies the server against caFile (or the system
+// roots) and presents certFile/keyFile as the client certificate when set
+func clientTLSCredentials(caFile, certFile, keyFile, serverName string) (credentials.TransportCredentials, error) {
+	tlsConfig := &tls.Config{
+		ServerName: serverName,
+		MinVersion: tls.VersionTLS12,
+	}
+
+	if caFile != "" {
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA certificate: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in %s", caFile)
+		}
+		tlsConfig.RootCAs = pool
 	}
+
+	if certFile != "" || keyFile != "" {
+		if certFile == "" || keyFile == "" {
+			return nil, fmt.Errorf("-cert and -key must be given together")
+		}
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// bearerToken sends a token in the authorization header of every call
+type bearerToken string
+
+func (t bearerToken) GetRequestMetadata(ctx context.Context, uri ...string) (map[string]string, error) {
+	return map[string]string{"authorization": "Bearer " + string(t)}, nil
+}
+
+// RequireTransportSecurity allows tokens over plaintext for local servers;
+// use -tls when the server is remote
+func (t bearerToken) RequireTransportSecurity() bool {
+	return false
 }
@@ -3,6 +3,8 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
 	"log"
 	"net"
@@ -12,13 +14,20 @@ import (
 	"syscall"
 	"time"
 
+	"ecs-plugin-dev/internal/audit"
 	"ecs-plugin-dev/internal/config"
 	server "ecs-plugin-dev/internal/grpc"
+	"ecs-plugin-dev/internal/metrics"
+	"ecs-plugin-dev/internal/plugin"
+	"ecs-plugin-dev/internal/tracing"
 	pb "ecs-plugin-dev/proto"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
@@ -29,12 +38,26 @@ func main() {
 		log.Fatalf("failed to load config: %v", err)
 	}
 
-	// Start metrics server if enabled
-	var metricsServer *http.Server
-	if cfg.Server.EnableMetrics {
-		metricsServer = startMetricsServer(cfg.Server.MetricsPort)
+	// Export spans when an OTLP endpoint is configured; otherwise spans are no-ops
+	shutdownTracing, err := tracing.Init(context.Background())
+	if err != nil {
+		log.Fatalf("failed to set up tracing: %v", err)
+	}
+	if tracing.Configured() {
+		log.Println("Exporting OpenTelemetry traces over OTLP")
+	}
+
+	// Attach trace exemplars to duration histograms when tracing is enabled
+	metrics.SetTracingEnabled(cfg.Server.EnableTracing)
+
+	// Rotate the audit log before it grows without bound
+	if auditLogger := audit.GetGlobalAuditLogger(); auditLogger != nil {
+		auditLogger.SetRotation(cfg.Audit.MaxFileBytes, cfg.Audit.MaxBackups)
 	}
 
+	// Keep stuck deployments from skewing duration analytics
+	metrics.GetGlobalAnalysisEngine().SetOutlierHandling(cfg.Deployment.AnalysisTrimFraction, cfg.Deployment.AnalysisSlowestCap)
+
 	// Start gRPC server
 	port := fmt.Sprintf("%d", cfg.Server.Port)
 	lis, err := net.Listen("tcp", fmt.Sprintf(":%s", port))
@@ -43,35 +66,115 @@ func main() {
 	}
 
 	// Configure gRPC server options
+	unaryInterceptors := []grpc.UnaryServerInterceptor{
+		server.LoggingInterceptor(),
+		server.MetricsInterceptor(),
+		server.RecoveryInterceptor(),
+		// Either a status error without the response body, or the
+		// success=false response with its code only in trailers
+		server.StatusErrorInterceptor(cfg.Server.StatusErrors),
+	}
+	var streamInterceptors []grpc.StreamServerInterceptor
+	if len(cfg.Server.AuthTokens) > 0 {
+		// Authenticate before anything else sees the call
+		unaryInterceptors = append([]grpc.UnaryServerInterceptor{
+			server.AuthInterceptor(cfg.Server.AuthTokens, cfg.Server.AuthExemptMethods),
+		}, unaryInterceptors...)
+		streamInterceptors = append(streamInterceptors,
+			server.AuthStreamInterceptor(cfg.Server.AuthTokens, cfg.Server.AuthExemptMethods))
+		log.Printf("Authentication enabled for %d principals", len(cfg.Server.AuthTokens))
+	} else {
+		log.Println("Running without authentication")
+	}
+	// Correlation IDs come first so everything after can log them
+	unaryInterceptors = append([]grpc.UnaryServerInterceptor{
+		server.CorrelationInterceptor(),
+		server.TracingInterceptor(),
+	}, unaryInterceptors...)
+	streamInterceptors = append([]grpc.StreamServerInterceptor{
+		server.CorrelationStreamInterceptor(),
+		server.TracingStreamInterceptor(),
+	}, streamInterceptors...)
+	var limiter *server.RateLimiter
+	if limit := cfg.Server.RateLimit; limit.RPS > 0 {
+		// Runs after authentication so rejected callers do not consume tokens;
+		// the gateway draws on the same buckets
+		limiter = server.NewRateLimiter(limit.RPS, limit.Burst, limit.PerMethod)
+		unaryInterceptors = append(unaryInterceptors, server.RateLimitInterceptor(limiter))
+		log.Printf("Rate limiting RPCs to %.1f/s (burst %d, per method: %v)", limit.RPS, limit.Burst, limit.PerMethod)
+	}
+	if cfg.Server.RequestTimeout > 0 {
+		unaryInterceptors = append(unaryInterceptors, server.TimeoutInterceptor(cfg.Server.RequestTimeout))
+	}
 	serverOpts := []grpc.ServerOption{
-		grpc.ChainUnaryInterceptor(
-			server.LoggingInterceptor(),
-			server.MetricsInterceptor(),
-			server.RecoveryInterceptor(),
-		),
+		grpc.ChainUnaryInterceptor(unaryInterceptors...),
+		grpc.ChainStreamInterceptor(streamInterceptors...),
 	}
 
-	// Add TLS if certificates are provided
+	// Add TLS if certificates are provided; a client CA additionally requires
+	// every client to present a certificate it signed
 	certFile := os.Getenv("TLS_CERT_FILE")
 	keyFile := os.Getenv("TLS_KEY_FILE")
+	clientCAFile := os.Getenv("TLS_CLIENT_CA_FILE")
 	if certFile != "" && keyFile != "" {
 		log.Printf("Loading TLS certificates: cert=%s, key=%s", certFile, keyFile)
-		tlsCreds, err := credentials.NewServerTLSFromFile(certFile, keyFile)
+		tlsCreds, err := serverTLSCredentials(certFile, keyFile, clientCAFile)
 		if err != nil {
 			log.Fatalf("failed to load TLS credentials: %v", err)
 		}
 		serverOpts = append(serverOpts, grpc.Creds(tlsCreds))
-		log.Println("TLS enabled for gRPC server")
+		if clientCAFile != "" {
+			log.Printf("Mutual TLS enabled for gRPC server (client CA: %s)", clientCAFile)
+		} else {
+			log.Println("TLS enabled for gRPC server")
+		}
+	} else if clientCAFile != "" {
+		log.Fatalf("TLS_CLIENT_CA_FILE requires TLS_CERT_FILE and TLS_KEY_FILE")
 	} else {
 		log.Println("Running without TLS (insecure mode)")
 	}
 
 	grpcServer := grpc.NewServer(serverOpts...)
 
-	deploymentServer := server.NewDeploymentServer()
+	// Persist deployment statuses when a status directory is configured
+	var statusStore plugin.StatusStore = plugin.NewMemoryStatusStore()
+	if cfg.Deployment.StatusDir != "" {
+		fileStore, err := plugin.NewFileStatusStore(cfg.Deployment.StatusDir)
+		if err != nil {
+			log.Fatalf("failed to open status store: %v", err)
+		}
+		statusStore = fileStore
+		log.Printf("Persisting deployment statuses to %s", cfg.Deployment.StatusDir)
+	}
+
+	deploymentServer := server.NewDeploymentServerWithStore(cfg, statusStore)
+	validatePermissions(deploymentServer, cfg.AWS)
 	pb.RegisterDeploymentServiceServer(grpcServer, deploymentServer)
 	reflection.Register(grpcServer)
 
+	// Start metrics server if enabled, along with the HTTP/JSON gateway
+	var metricsServer *http.Server
+	if cfg.Server.EnableMetrics {
+		var gateway http.Handler
+		if cfg.Server.Gateway {
+			gateway = server.NewGateway(deploymentServer, cfg.Server, limiter)
+		}
+		metricsServer = startMetricsServer(cfg.Server.MetricsPort, gateway)
+	}
+
+	// Standard gRPC health service; the router flips it to NOT_SERVING while
+	// it cannot reach AWS, and shutdown flips it for good
+	healthServer := health.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	deploymentServer.SetHealthListener(func(serving bool, reason string) {
+		status := healthpb.HealthCheckResponse_SERVING
+		if !serving {
+			status = healthpb.HealthCheckResponse_NOT_SERVING
+		}
+		healthServer.SetServingStatus("", status)
+		healthServer.SetServingStatus(pb.DeploymentService_ServiceDesc.ServiceName, status)
+	})
+
 	// Graceful shutdown with timeout
 	shutdownCh := make(chan struct{})
 	go func() {
@@ -80,6 +183,10 @@ func main() {
 		sig := <-sigCh
 		log.Printf("Received signal: %v, initiating graceful shutdown", sig)
 
+		// Tell health checkers to stop routing new work here; later router
+		// health changes are ignored
+		healthServer.Shutdown()
+
 		// Shutdown metrics server
 		if metricsServer != nil {
 			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
@@ -89,22 +196,37 @@ func main() {
 			}
 		}
 
-		// Graceful stop with timeout
+		// Graceful stop with timeout; in-flight deployments share the same
+		// deadline to finish
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.Server.GracefulTimeout)
+		defer cancel()
+
 		stopped := make(chan struct{})
 		go func() {
 			grpcServer.GracefulStop()
 			close(stopped)
 		}()
 
+		if err := deploymentServer.Drain(shutdownCtx); err != nil {
+			log.Printf("Deployment drain incomplete: %v", err)
+		}
+
 		// Force stop after timeout
 		select {
 		case <-stopped:
 			log.Println("Server stopped gracefully")
-		case <-time.After(cfg.Server.GracefulTimeout):
+		case <-shutdownCtx.Done():
 			log.Println("Graceful shutdown timeout, forcing stop")
 			grpcServer.Stop()
 		}
 
+		// Flush spans of the deployments that just finished
+		flushCtx, cancelFlush := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancelFlush()
+		if err := shutdownTracing(flushCtx); err != nil {
+			log.Printf("Trace exporter shutdown error: %v", err)
+		}
+
 		close(shutdownCh)
 	}()
 
@@ -121,9 +243,64 @@ func main() {
 	log.Println("Server shutdown complete")
 }
 
-func startMetricsServer(port int) *http.Server {
+// validatePermissions checks the AWS credentials allow every action
+// deployments use, so a missing permission is reported at startup rather
+// than partway through a deployment. Per cfg.ValidatePermissions a denied
+// action is logged or stops the server.
+func validatePermissions(deploymentServer *server.DeploymentServer, cfg config.AWSConfig) {
+	if cfg.ValidatePermissions == "" || cfg.ValidatePermissions == "off" {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+	if err := deploymentServer.ValidatePermissions(ctx); err != nil {
+		if cfg.ValidatePermissions == "fail" {
+			log.Fatalf("AWS permission check failed: %v", err)
+		}
+		log.Printf("Warning: AWS permission check failed, deployments may fail: %v", err)
+		return
+	}
+	log.Println("AWS permission check passed")
+}
+
+// serverTLSCredentials loads the server certificate and, when clientCAFile is
+// set, requires and verifies client certificates against that CA
+func serverTLSCredentials(certFile, keyFile, clientCAFile string) (credentials.TransportCredentials, error) {
+	cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate: %w", err)
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+
+	if clientCAFile != "" {
+		pem, err := os.ReadFile(clientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read client CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in client CA %s", clientCAFile)
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return credentials.NewTLS(tlsConfig), nil
+}
+
+// startMetricsServer serves metrics and health on port, and the gateway's
+// routes when it is not nil
+func startMetricsServer(port int, gateway http.Handler) *http.Server {
 	mux := http.NewServeMux()
-	mux.Handle("/metrics", promhttp.Handler())
+	// OpenMetrics format is required for exemplars to be exposed
+	mux.Handle("/metrics", promhttp.InstrumentMetricHandler(
+		prometheus.DefaultRegisterer,
+		promhttp.HandlerFor(prometheus.DefaultGatherer, promhttp.HandlerOpts{EnableOpenMetrics: true}),
+	))
 	mux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte("OK"))
@@ -136,6 +313,13 @@ func startMetricsServer(port int) *http.Server {
 		WriteTimeout:      10 * time.Second,
 		IdleTimeout:       60 * time.Second,
 	}
+	if gateway != nil {
+		mux.Handle("/", gateway)
+		// Rollbacks can outlast the write timeout; server.request_timeout
+		// bounds gateway calls instead
+		server.WriteTimeout = 0
+		log.Printf("HTTP gateway enabled on port %d", port)
+	}
 
 	go func() {
 		log.Printf("Metrics server listening on %s", server.Addr)
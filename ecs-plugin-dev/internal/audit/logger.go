@@ -1,14 +1,20 @@
 package audit
 
 import (
+	"bufio"
 	"encoding/json"
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"sort"
 	"sync"
 	"time"
 )
 
+// backupTimeFormat suffixes rotated audit files; it sorts chronologically
+const backupTimeFormat = "20060102T150405.000000000"
+
 type AuditEventType string
 
 const (
@@ -17,11 +23,15 @@ const (
 	EventDeploymentFailed    AuditEventType = "deployment.failed"
 	EventDeploymentCancelled AuditEventType = "deployment.cancelled"
 	EventDeploymentRollback  AuditEventType = "deployment.rollback"
+	EventStatusTransition    AuditEventType = "deployment.transition"
 	EventApprovalRequested   AuditEventType = "approval.requested"
 	EventApprovalGranted     AuditEventType = "approval.granted"
 	EventApprovalRejected    AuditEventType = "approval.rejected"
+	EventApprovalTimeout     AuditEventType = "approval.timeout"
 	EventDriftDetected       AuditEventType = "drift.detected"
 	EventDriftReconciled     AuditEventType = "drift.reconciled"
+	EventHookFailed          AuditEventType = "hook.failed"
+	EventServiceReleased     AuditEventType = "service.released"
 )
 
 type AuditEvent struct {
@@ -36,6 +46,9 @@ type AuditEvent struct {
 	ErrorCode    string                 `json:"error_code,omitempty"`
 	ErrorMessage string                 `json:"error_message,omitempty"`
 	Metadata     map[string]interface{} `json:"metadata,omitempty"`
+
+	// CorrelationID ties the event to the request that caused it
+	CorrelationID string `json:"correlation_id,omitempty"`
 }
 
 type AuditLogger struct {
@@ -43,6 +56,11 @@ type AuditLogger struct {
 	file    *os.File
 	events  []AuditEvent
 	maxSize int
+
+	path         string
+	fileBytes    int64
+	maxFileBytes int64 // Rotate once the file would exceed this size; 0 disables rotation
+	maxBackups   int   // Rotated files to keep; 0 keeps all
 }
 
 func NewAuditLogger(logPath string) (*AuditLogger, error) {
@@ -64,15 +82,76 @@ func NewAuditLogger(logPath string) (*AuditLogger, error) {
 		return nil, fmt.Errorf("failed to open audit log file: %w", err)
 	}
 
+	var fileBytes int64
+	if info, err := file.Stat(); err == nil {
+		fileBytes = info.Size()
+	}
+
 	log.Printf("[AUDIT] Audit logging initialized: %s", logPath)
 
 	return &AuditLogger{
-		file:    file,
-		events:  []AuditEvent{},
-		maxSize: 10000,
+		file:      file,
+		events:    []AuditEvent{},
+		maxSize:   10000,
+		path:      logPath,
+		fileBytes: fileBytes,
 	}, nil
 }
 
+// SetRotation enables size-based rotation: when a write would grow the file
+// past maxFileBytes it is renamed with a timestamp suffix and a fresh file is
+// started, keeping at most maxBackups rotated files (all when 0)
+func (al *AuditLogger) SetRotation(maxFileBytes int64, maxBackups int) {
+	al.mu.Lock()
+	defer al.mu.Unlock()
+
+	al.maxFileBytes = maxFileBytes
+	al.maxBackups = maxBackups
+}
+
+// rotate replaces the current file with a fresh one; callers must hold al.mu
+func (al *AuditLogger) rotate() error {
+	if err := al.file.Close(); err != nil {
+		return fmt.Errorf("failed to close audit log: %w", err)
+	}
+
+	backup := fmt.Sprintf("%s.%s", al.path, time.Now().Format(backupTimeFormat))
+	if err := os.Rename(al.path, backup); err != nil {
+		// Keep appending to the current file rather than losing events
+		if file, openErr := os.OpenFile(al.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644); openErr == nil {
+			al.file = file
+		}
+		return fmt.Errorf("failed to rotate audit log: %w", err)
+	}
+
+	file, err := os.OpenFile(al.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open audit log file: %w", err)
+	}
+	al.file = file
+	al.fileBytes = 0
+	log.Printf("[AUDIT] Rotated audit log to %s", backup)
+
+	if al.maxBackups > 0 {
+		al.pruneBackups()
+	}
+	return nil
+}
+
+// pruneBackups removes the oldest rotated files beyond maxBackups
+func (al *AuditLogger) pruneBackups() {
+	backups, err := filepath.Glob(al.path + ".*")
+	if err != nil || len(backups) <= al.maxBackups {
+		return
+	}
+	sort.Strings(backups)
+	for _, old := range backups[:len(backups)-al.maxBackups] {
+		if err := os.Remove(old); err != nil {
+			log.Printf("[AUDIT] Failed to remove old audit log %s: %v", old, err)
+		}
+	}
+}
+
 func (al *AuditLogger) Log(event AuditEvent) error {
 	al.mu.Lock()
 	defer al.mu.Unlock()
@@ -85,12 +164,25 @@ func (al *AuditLogger) Log(event AuditEvent) error {
 		return fmt.Errorf("failed to marshal audit event: %w", err)
 	}
 
-	if _, err := al.file.Write(append(data, '\n')); err != nil {
+	data = append(data, '\n')
+	if al.maxFileBytes > 0 && al.fileBytes > 0 && al.fileBytes+int64(len(data)) > al.maxFileBytes {
+		if err := al.rotate(); err != nil {
+			return err
+		}
+	}
+
+	n, err := al.file.Write(data)
+	al.fileBytes += int64(n)
+	if err != nil {
 		return fmt.Errorf("failed to write audit event: %w", err)
 	}
 
 	// Also log to standard logger
-	log.Printf("[AUDIT] %s | %s | %s | %s", event.EventType, event.DeploymentID, event.Status, event.User)
+	if event.CorrelationID != "" {
+		log.Printf("[AUDIT] %s | %s | %s | %s correlation_id=%s", event.EventType, event.DeploymentID, event.Status, event.User, event.CorrelationID)
+	} else {
+		log.Printf("[AUDIT] %s | %s | %s | %s", event.EventType, event.DeploymentID, event.Status, event.User)
+	}
 
 	// Keep in memory for queries
 	al.events = append(al.events, event)
@@ -166,6 +258,88 @@ func (al *AuditLogger) GetEvents(limit int) []AuditEvent {
 	return result
 }
 
+// GetEventsByDeployment returns the in-memory events for a deployment, oldest first
+func (al *AuditLogger) GetEventsByDeployment(deploymentID string) []AuditEvent {
+	return al.filterEvents(func(event AuditEvent) bool {
+		return event.DeploymentID == deploymentID
+	})
+}
+
+// GetEventsInRange returns the in-memory events logged within [start, end], oldest first
+func (al *AuditLogger) GetEventsInRange(start, end time.Time) []AuditEvent {
+	return al.filterEvents(func(event AuditEvent) bool {
+		return !event.Timestamp.Before(start) && !event.Timestamp.After(end)
+	})
+}
+
+func (al *AuditLogger) filterEvents(match func(AuditEvent) bool) []AuditEvent {
+	al.mu.Lock()
+	defer al.mu.Unlock()
+
+	var result []AuditEvent
+	for _, event := range al.events {
+		if match(event) {
+			result = append(result, event)
+		}
+	}
+	return result
+}
+
+// ScanEvents reads every event still on disk, rotated files first, and
+// returns those accepted by match (all when nil). It reaches events that have
+// aged out of the in-memory buffer; lines that fail to decode are skipped.
+func (al *AuditLogger) ScanEvents(match func(AuditEvent) bool) ([]AuditEvent, error) {
+	al.mu.Lock()
+	path := al.path
+	al.mu.Unlock()
+
+	files, err := filepath.Glob(path + ".*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit logs: %w", err)
+	}
+	sort.Strings(files)
+	files = append(files, path)
+
+	var result []AuditEvent
+	for _, name := range files {
+		err := scanFile(name, func(event AuditEvent) {
+			if match == nil || match(event) {
+				result = append(result, event)
+			}
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// scanFile decodes an audit log line by line, ignoring files rotated away meanwhile
+func scanFile(name string, fn func(AuditEvent)) error {
+	file, err := os.Open(name)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to open audit log %s: %w", name, err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var event AuditEvent
+		if err := json.Unmarshal(scanner.Bytes(), &event); err != nil {
+			continue
+		}
+		fn(event)
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read audit log %s: %w", name, err)
+	}
+	return nil
+}
+
 func (al *AuditLogger) Close() error {
 	al.mu.Lock()
 	defer al.mu.Unlock()
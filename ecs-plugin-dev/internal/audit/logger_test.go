@@ -0,0 +1,101 @@
+package audit
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// newTestLogger opens an audit log in a temporary directory, which also
+// receives the fallback log when the default directory is unavailable
+func newTestLogger(t *testing.T) *AuditLogger {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("TMPDIR", dir)
+	al, err := NewAuditLogger(filepath.Join(dir, "audit.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { al.Close() })
+	return al
+}
+
+func logEvents(t *testing.T, al *AuditLogger, n int) {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		if err := al.Log(AuditEvent{EventType: EventDeploymentStarted, DeploymentID: fmt.Sprintf("deploy-%02d", i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestAuditLogRotation(t *testing.T) {
+	tests := []struct {
+		name        string
+		maxBackups  int
+		wantBackups int
+		wantAll     bool
+	}{
+		{name: "keeps every backup", maxBackups: 0, wantBackups: 4, wantAll: true},
+		{name: "prunes old backups", maxBackups: 2, wantBackups: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			al := newTestLogger(t)
+			// Two events fit in a file, so ten events leave four full backups
+			const maxFileBytes = 250
+			al.SetRotation(maxFileBytes, tt.maxBackups)
+			logEvents(t, al, 10)
+
+			backups, err := filepath.Glob(al.path + ".*")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(backups) != tt.wantBackups {
+				t.Fatalf("expected %d backups, got %v", tt.wantBackups, backups)
+			}
+			for _, name := range append(backups, al.path) {
+				info, err := os.Stat(name)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if info.Size() > maxFileBytes {
+					t.Errorf("%s grew to %d bytes, past the %d byte limit", name, info.Size(), maxFileBytes)
+				}
+			}
+
+			events, err := al.ScanEvents(nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			wantEvents := 2 * (tt.wantBackups + 1)
+			if len(events) != wantEvents {
+				t.Fatalf("expected %d events on disk, got %d", wantEvents, len(events))
+			}
+			// Rotated files are read oldest first, ending with the newest event
+			first := 10 - wantEvents
+			for i, event := range events {
+				if want := fmt.Sprintf("deploy-%02d", first+i); event.DeploymentID != want {
+					t.Errorf("event %d: expected %s, got %s", i, want, event.DeploymentID)
+				}
+			}
+			if tt.wantAll && first != 0 {
+				t.Errorf("expected every event to be kept, lost the first %d", first)
+			}
+		})
+	}
+}
+
+func TestAuditLogWithoutRotation(t *testing.T) {
+	al := newTestLogger(t)
+	logEvents(t, al, 10)
+
+	if backups, _ := filepath.Glob(al.path + ".*"); len(backups) != 0 {
+		t.Errorf("expected no rotation by default, got %v", backups)
+	}
+	if events, err := al.ScanEvents(nil); err != nil || len(events) != 10 {
+		t.Errorf("expected all 10 events in one file, got %d (%v)", len(events), err)
+	}
+}
@@ -0,0 +1,71 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"ecs-plugin-dev/internal/metrics"
+	"ecs-plugin-dev/internal/util"
+
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
+	"github.com/aws/smithy-go/middleware"
+	smithyhttp "github.com/aws/smithy-go/transport/http"
+)
+
+// newCircuitBreaker creates the breaker guarding one AWS service's calls,
+// or nil when retry disables breakers
+func newCircuitBreaker(service string, retry util.RetryConfig) *util.CircuitBreaker {
+	if retry.BreakerThreshold <= 0 {
+		return nil
+	}
+	metrics.SetCircuitBreakerState(service, int(util.BreakerClosed))
+	return util.NewCircuitBreaker(retry.BreakerThreshold, retry.BreakerCooldown, func(state util.BreakerState) {
+		log.Printf("[AWS] %s circuit breaker %s", service, state)
+		metrics.SetCircuitBreakerState(service, int(state))
+	})
+}
+
+// breakAPICalls fails a service's API calls fast while breaker is open.
+// It runs first in the initialize step, so one call counts once however
+// many times the SDK retries it.
+func breakAPICalls(service string, breaker *util.CircuitBreaker) func(*middleware.Stack) error {
+	return func(stack *middleware.Stack) error {
+		return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("CircuitBreaker",
+			func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+				if err := breaker.Allow(); err != nil {
+					metrics.RecordCircuitBreakerRejection(service)
+					return middleware.InitializeOutput{}, middleware.Metadata{}, err
+				}
+
+				out, metadata, err := next.HandleInitialize(ctx, in)
+				switch {
+				case err == nil:
+					breaker.Success()
+				case ctx.Err() != nil:
+					breaker.Abandon()
+				case isOutage(err):
+					breaker.Failure()
+				default:
+					// AWS answered, just not favourably
+					breaker.Success()
+				}
+				return out, metadata, err
+			}), middleware.Before)
+	}
+}
+
+// isOutage reports whether err suggests AWS itself is unavailable rather
+// than rejecting the request: a failure to reach it, a timeout, throttling
+// or a 5xx response
+func isOutage(err error) bool {
+	var sendErr *smithyhttp.RequestSendError
+	if errors.As(err, &sendErr) {
+		return true
+	}
+	var respErr *awshttp.ResponseError
+	if errors.As(err, &respErr) {
+		return respErr.HTTPStatusCode() >= 500 || respErr.HTTPStatusCode() == 429
+	}
+	return errors.Is(err, context.DeadlineExceeded) || util.IsRetryable(err)
+}
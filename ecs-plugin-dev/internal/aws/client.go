@@ -2,11 +2,18 @@ package aws
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
+	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"ecs-plugin-dev/internal/util"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	awshttp "github.com/aws/aws-sdk-go-v2/aws/transport/http"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 )
@@ -38,7 +45,7 @@ func loadConfig(ctx context.Context) (aws.Config, error) {
 
 	// LocalStack endpoint
 	if ep := os.Getenv("AWS_ENDPOINT_URL"); ep != "" {
-		log.Printf("[AWS] Using custom endpoint: %s (LocalStack mode)", ep)
+		util.Logf(ctx, "[AWS] Using custom endpoint: %s (LocalStack mode)", ep)
 		opts = append(opts, config.WithEndpointResolverWithOptions(
 			aws.EndpointResolverWithOptionsFunc(func(service, region string, options ...interface{}) (aws.Endpoint, error) {
 				return aws.Endpoint{
@@ -54,6 +61,38 @@ func loadConfig(ctx context.Context) (aws.Config, error) {
 	if err != nil {
 		return aws.Config{}, fmt.Errorf("failed to load AWS config: %w", err)
 	}
+	cfg.APIOptions = append(cfg.APIOptions, traceAPICalls)
 
 	return cfg, nil
 }
+
+// withRetryAfter attaches the Retry-After hint of a throttled AWS response to
+// err so ExponentialBackoff waits at least that long before retrying
+func withRetryAfter(err error) error {
+	var respErr *awshttp.ResponseError
+	if !errors.As(err, &respErr) || respErr.Response == nil {
+		return err
+	}
+
+	if delay, ok := parseRetryAfter(respErr.Response.Header.Get("Retry-After")); ok {
+		return util.RetryAfter(err, delay)
+	}
+	return err
+}
+
+// parseRetryAfter reads a Retry-After value given in seconds or as an HTTP date
+func parseRetryAfter(value string) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if delay := time.Until(at); delay > 0 {
+			return delay, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,269 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"ecs-plugin-dev/internal/metrics"
+	"ecs-plugin-dev/internal/util"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+// maxAlarmNamesPerCall is the DescribeAlarms limit on alarm names per request
+const maxAlarmNamesPerCall = 100
+
+type CloudWatchClient struct {
+	client *cloudwatch.Client
+	mock   bool
+
+	// MockTargetGroupStats scripts GetTargetGroupStats in mock mode per
+	// target group ARN, with "*" matching any group. Groups without an entry
+	// report defaultMockTargetGroupStats.
+	MockTargetGroupStats map[string]TargetGroupStats
+}
+
+// defaultMockTargetGroupStats is the traffic mock target groups report
+// unless scripted otherwise
+var defaultMockTargetGroupStats = TargetGroupStats{Requests: 1000, Errors: 1, AvgResponseTime: 0.05}
+
+func NewCloudWatchClient() *CloudWatchClient {
+	if isMock() {
+		log.Println("[MOCK] CloudWatch client in mock mode")
+		client := &CloudWatchClient{mock: true}
+		if value := os.Getenv("MOCK_TARGET_GROUP_STATS"); value != "" {
+			client.MockTargetGroupStats = parseMockTargetGroupStats(value)
+		}
+		return client
+	}
+	cfg, err := loadConfig(context.Background())
+	if err != nil {
+		panic(fmt.Sprintf("failed to create CloudWatch client: %v", err))
+	}
+	return &CloudWatchClient{
+		client: cloudwatch.NewFromConfig(cfg),
+	}
+}
+
+// DescribeAlarmStates returns the current state of each named metric or
+// composite alarm. Names that do not exist are absent from the result.
+func (c *CloudWatchClient) DescribeAlarmStates(ctx context.Context, alarmNames []string) (map[string]types.StateValue, error) {
+	states := make(map[string]types.StateValue, len(alarmNames))
+	if c.mock {
+		for _, name := range alarmNames {
+			states[name] = types.StateValueOk
+		}
+		util.Logf(ctx, "[MOCK] DescribeAlarmStates: %d alarms OK", len(alarmNames))
+		metrics.RecordMockAWSCall(ctx, "cloudwatch", "DescribeAlarms")
+		return states, nil
+	}
+
+	start := time.Now()
+	for i := 0; i < len(alarmNames); i += maxAlarmNamesPerCall {
+		end := i + maxAlarmNamesPerCall
+		if end > len(alarmNames) {
+			end = len(alarmNames)
+		}
+
+		paginator := cloudwatch.NewDescribeAlarmsPaginator(c.client, &cloudwatch.DescribeAlarmsInput{
+			AlarmNames: alarmNames[i:end],
+			AlarmTypes: []types.AlarmType{types.AlarmTypeMetricAlarm, types.AlarmTypeCompositeAlarm},
+		})
+		for paginator.HasMorePages() {
+			page, err := paginator.NextPage(ctx)
+			if err != nil {
+				metrics.RecordAWSCallContext(ctx, "cloudwatch", "DescribeAlarms", "error", time.Since(start))
+				metrics.RecordError("aws", "DescribeAlarms")
+				return nil, fmt.Errorf("describe alarms failed: %w", err)
+			}
+			for _, alarm := range page.MetricAlarms {
+				states[aws.ToString(alarm.AlarmName)] = alarm.StateValue
+			}
+			for _, alarm := range page.CompositeAlarms {
+				states[aws.ToString(alarm.AlarmName)] = alarm.StateValue
+			}
+		}
+	}
+	metrics.RecordAWSCallContext(ctx, "cloudwatch", "DescribeAlarms", "success", time.Since(start))
+
+	return states, nil
+}
+
+// TargetGroupStats is the traffic an ALB target group served over a window
+type TargetGroupStats struct {
+	Requests int64
+	// Errors counts 5XX responses returned by the group's targets
+	Errors int64
+	// AvgResponseTime is the mean target response time in seconds
+	AvgResponseTime float64
+}
+
+// ErrorRate is the fraction of requests that failed, or 0 without traffic
+func (s TargetGroupStats) ErrorRate() float64 {
+	if s.Requests == 0 {
+		return 0
+	}
+	return float64(s.Errors) / float64(s.Requests)
+}
+
+// GetTargetGroupStats reads the request count, target 5XX count and average
+// target response time of each target group behind listenerARN's load
+// balancer over the window ending now. The window is rounded up to whole
+// minutes, the finest period ALB metrics are published at.
+func (c *CloudWatchClient) GetTargetGroupStats(ctx context.Context, listenerARN string, targetGroupARNs []string, window time.Duration) (map[string]TargetGroupStats, error) {
+	stats := make(map[string]TargetGroupStats, len(targetGroupARNs))
+	if c.mock {
+		for _, arn := range targetGroupARNs {
+			stats[arn] = c.mockTargetGroupStats(arn)
+		}
+		util.Logf(ctx, "[MOCK] GetTargetGroupStats: %d target groups", len(targetGroupARNs))
+		metrics.RecordMockAWSCall(ctx, "cloudwatch", "GetMetricData")
+		return stats, nil
+	}
+
+	loadBalancer, err := loadBalancerDimension(listenerARN)
+	if err != nil {
+		return nil, err
+	}
+	period := int32((window + time.Minute - 1) / time.Minute * 60)
+	if period < 60 {
+		period = 60
+	}
+	end := time.Now()
+	start := end.Add(-time.Duration(period) * time.Second)
+
+	// One query per target group and metric; IDs must start with a lowercase letter
+	var queries []types.MetricDataQuery
+	for i, arn := range targetGroupARNs {
+		targetGroup, err := targetGroupDimension(arn)
+		if err != nil {
+			return nil, err
+		}
+		dimensions := []types.Dimension{
+			{Name: aws.String("LoadBalancer"), Value: aws.String(loadBalancer)},
+			{Name: aws.String("TargetGroup"), Value: aws.String(targetGroup)},
+		}
+		for _, m := range []struct{ id, name, stat string }{
+			{"requests", "RequestCount", "Sum"},
+			{"errors", "HTTPCode_Target_5XX_Count", "Sum"},
+			{"latency", "TargetResponseTime", "Average"},
+		} {
+			queries = append(queries, types.MetricDataQuery{
+				Id: aws.String(fmt.Sprintf("%s%d", m.id, i)),
+				MetricStat: &types.MetricStat{
+					Metric: &types.Metric{
+						Namespace:  aws.String("AWS/ApplicationELB"),
+						MetricName: aws.String(m.name),
+						Dimensions: dimensions,
+					},
+					Period: aws.Int32(period),
+					Stat:   aws.String(m.stat),
+				},
+			})
+		}
+	}
+
+	values := make(map[string]float64, len(queries))
+	callStart := time.Now()
+	paginator := cloudwatch.NewGetMetricDataPaginator(c.client, &cloudwatch.GetMetricDataInput{
+		MetricDataQueries: queries,
+		StartTime:         aws.Time(start),
+		EndTime:           aws.Time(end),
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			metrics.RecordAWSCallContext(ctx, "cloudwatch", "GetMetricData", "error", time.Since(callStart))
+			metrics.RecordError("aws", "GetMetricData")
+			return nil, fmt.Errorf("get metric data failed: %w", err)
+		}
+		for _, result := range page.MetricDataResults {
+			// A target group without traffic has no datapoints
+			for _, v := range result.Values {
+				values[aws.ToString(result.Id)] += v
+			}
+		}
+	}
+	metrics.RecordAWSCallContext(ctx, "cloudwatch", "GetMetricData", "success", time.Since(callStart))
+
+	for i, arn := range targetGroupARNs {
+		stats[arn] = TargetGroupStats{
+			Requests:        int64(values[fmt.Sprintf("requests%d", i)]),
+			Errors:          int64(values[fmt.Sprintf("errors%d", i)]),
+			AvgResponseTime: values[fmt.Sprintf("latency%d", i)],
+		}
+	}
+	return stats, nil
+}
+
+// loadBalancerDimension turns a listener ARN into the LoadBalancer metric
+// dimension, app/<name>/<id>
+func loadBalancerDimension(listenerARN string) (string, error) {
+	_, resource, ok := strings.Cut(listenerARN, ":listener/")
+	parts := strings.Split(resource, "/")
+	if !ok || len(parts) < 3 {
+		return "", fmt.Errorf("invalid listener ARN %q", listenerARN)
+	}
+	return strings.Join(parts[:3], "/"), nil
+}
+
+// targetGroupDimension turns a target group ARN into the TargetGroup metric
+// dimension, targetgroup/<name>/<id>
+func targetGroupDimension(targetGroupARN string) (string, error) {
+	i := strings.Index(targetGroupARN, ":targetgroup/")
+	if i < 0 {
+		return "", fmt.Errorf("invalid target group ARN %q", targetGroupARN)
+	}
+	return targetGroupARN[i+1:], nil
+}
+
+// mockTargetGroupStats resolves the scripted stats for a target group
+func (c *CloudWatchClient) mockTargetGroupStats(arn string) TargetGroupStats {
+	if stats, ok := c.MockTargetGroupStats[arn]; ok {
+		return stats
+	}
+	if stats, ok := c.MockTargetGroupStats["*"]; ok {
+		return stats
+	}
+	return defaultMockTargetGroupStats
+}
+
+// parseMockTargetGroupStats reads MOCK_TARGET_GROUP_STATS: comma-separated
+// requests:errors:latency_seconds entries, optionally prefixed with
+// "<target group ARN>=" to script a single group (e.g. "1000:1:0.05" or
+// "arn:...:targetgroup/mock-canary/6d0ecf831eec9f09=1000:50:0.3")
+func parseMockTargetGroupStats(value string) map[string]TargetGroupStats {
+	stats := make(map[string]TargetGroupStats)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		key, spec := "*", entry
+		if i := strings.LastIndex(entry, "="); i >= 0 {
+			key, spec = entry[:i], entry[i+1:]
+		}
+		fields := strings.Split(spec, ":")
+		if len(fields) != 3 {
+			log.Printf("[MOCK] Ignoring invalid MOCK_TARGET_GROUP_STATS entry %q", entry)
+			continue
+		}
+		requests, errRequests := strconv.ParseInt(fields[0], 10, 64)
+		errors, errErrors := strconv.ParseInt(fields[1], 10, 64)
+		latency, errLatency := strconv.ParseFloat(fields[2], 64)
+		if errRequests != nil || errErrors != nil || errLatency != nil || requests < 0 || errors < 0 || errors > requests || latency < 0 {
+			log.Printf("[MOCK] Ignoring invalid MOCK_TARGET_GROUP_STATS entry %q", entry)
+			continue
+		}
+		stats[key] = TargetGroupStats{Requests: requests, Errors: errors, AvgResponseTime: latency}
+	}
+	return stats
+}
@@ -3,9 +3,13 @@ package aws
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"ecs-plugin-dev/internal/metrics"
@@ -19,39 +23,65 @@ import (
 type ECSClient struct {
 	client *ecs.Client
 	mock   bool
+	retry  util.RetryConfig
+
+	// MockScenario scripts service state in mock mode; set from
+	// MOCK_SCENARIO_FILE, or replaced directly by tests
+	MockScenario *MockScenario
 }
 
 func NewECSClient() *ECSClient {
+	return NewECSClientWithConfig(util.DefaultRetryConfig())
+}
+
+// NewECSClientWithConfig creates an ECS client that retries calls with retry
+func NewECSClientWithConfig(retry util.RetryConfig) *ECSClient {
 	if isMock() {
 		log.Println("[MOCK] ECS client in mock mode")
-		return &ECSClient{mock: true}
+		return &ECSClient{mock: true, retry: retry, MockScenario: mockScenarioFromEnv()}
 	}
 	cfg, err := loadConfig(context.Background())
 	if err != nil {
 		panic(fmt.Sprintf("failed to create ECS client: %v", err))
 	}
+	if breaker := newCircuitBreaker("ecs", retry); breaker != nil {
+		cfg.APIOptions = append(cfg.APIOptions, breakAPICalls("ecs", breaker))
+	}
 	return &ECSClient{
 		client: ecs.NewFromConfig(cfg),
+		retry:  retry,
 	}
 }
 
 func (c *ECSClient) RegisterTaskDefinition(ctx context.Context, taskDefJSON string) error {
+	_, err := c.RegisterTaskDefinitionARN(ctx, taskDefJSON)
+	return err
+}
+
+// RegisterTaskDefinitionARN registers a task definition and returns the ARN of the new revision
+func (c *ECSClient) RegisterTaskDefinitionARN(ctx context.Context, taskDefJSON string) (string, error) {
 	if c.mock {
-		log.Printf("[MOCK] RegisterTaskDefinition: %s", taskDefJSON)
-		return nil
+		util.Logf(ctx, "[MOCK] RegisterTaskDefinition: %s", taskDefJSON)
+		metrics.RecordMockAWSCall(ctx, "ecs", "RegisterTaskDefinition")
+		return "arn:aws:ecs:us-east-1:123456789:task-definition/current:2", nil
 	}
 
 	start := time.Now()
 	var err error
+	var arn string
 
-	retryErr := util.ExponentialBackoff(ctx, util.DefaultRetryConfig(), func() error {
+	retryErr := util.ExponentialBackoff(ctx, c.retry, func() error {
 		var taskDef ecs.RegisterTaskDefinitionInput
 		if jsonErr := json.Unmarshal([]byte(taskDefJSON), &taskDef); jsonErr != nil {
 			return fmt.Errorf("invalid task definition: %w", jsonErr)
 		}
 
-		_, err = c.client.RegisterTaskDefinition(ctx, &taskDef)
-		return err
+		var out *ecs.RegisterTaskDefinitionOutput
+		out, err = c.client.RegisterTaskDefinition(ctx, &taskDef)
+		if err == nil && out.TaskDefinition != nil {
+			arn = aws.ToString(out.TaskDefinition.TaskDefinitionArn)
+		}
+		return withRetryAfter(err)
 	})
 
 	status := "success"
@@ -59,28 +89,73 @@ func (c *ECSClient) RegisterTaskDefinition(ctx context.Context, taskDefJSON stri
 		status = "error"
 		metrics.RecordError("ecs_client", "register_task_definition")
 	}
-	metrics.RecordAWSCall("ecs", "RegisterTaskDefinition", status, time.Since(start))
+	metrics.RecordAWSCallContext(ctx, "ecs", "RegisterTaskDefinition", status, time.Since(start))
 
-	return retryErr
+	return arn, retryErr
+}
+
+// DeploymentOptions configures the ECS-native deployment settings applied by
+// UpdateServiceWithOptions. Zero values leave the service's values unchanged.
+type DeploymentOptions struct {
+	// CircuitBreaker enables the ECS deployment circuit breaker with automatic rollback
+	CircuitBreaker        bool
+	MinimumHealthyPercent int32
+	MaximumPercent        int32
+
+	// DesiredCount sets the service's task count, as when restoring a snapshot
+	DesiredCount int32
+}
+
+// deploymentConfiguration returns the UpdateService deployment configuration
+// for opts, or nil when opts changes nothing
+func (o DeploymentOptions) deploymentConfiguration() *types.DeploymentConfiguration {
+	if !o.CircuitBreaker && o.MinimumHealthyPercent == 0 && o.MaximumPercent == 0 {
+		return nil
+	}
+
+	dc := &types.DeploymentConfiguration{}
+	if o.CircuitBreaker {
+		dc.DeploymentCircuitBreaker = &types.DeploymentCircuitBreaker{Enable: true, Rollback: true}
+	}
+	if o.MinimumHealthyPercent > 0 {
+		dc.MinimumHealthyPercent = aws.Int32(o.MinimumHealthyPercent)
+	}
+	if o.MaximumPercent > 0 {
+		dc.MaximumPercent = aws.Int32(o.MaximumPercent)
+	}
+	return dc
 }
 
 func (c *ECSClient) UpdateService(ctx context.Context, cluster, service, taskDef string) error {
+	return c.UpdateServiceWithOptions(ctx, cluster, service, taskDef, DeploymentOptions{})
+}
+
+// UpdateServiceWithOptions forces a new deployment of taskDef, applying opts
+// to the service's deployment configuration
+func (c *ECSClient) UpdateServiceWithOptions(ctx context.Context, cluster, service, taskDef string, opts DeploymentOptions) error {
 	if c.mock {
-		log.Printf("[MOCK] UpdateService: cluster=%s, service=%s", cluster, service)
+		util.Logf(ctx, "[MOCK] UpdateService: cluster=%s, service=%s, options=%+v", cluster, service, opts)
+		metrics.RecordMockAWSCall(ctx, "ecs", "UpdateService")
 		return nil
 	}
 
 	start := time.Now()
 	var err error
 
-	retryErr := util.ExponentialBackoff(ctx, util.DefaultRetryConfig(), func() error {
-		_, err = c.client.UpdateService(ctx, &ecs.UpdateServiceInput{
-			Cluster:            aws.String(cluster),
-			Service:            aws.String(service),
-			TaskDefinition:     aws.String(taskDef),
-			ForceNewDeployment: true,
-		})
-		return err
+	input := &ecs.UpdateServiceInput{
+		Cluster:                 aws.String(cluster),
+		Service:                 aws.String(service),
+		TaskDefinition:          aws.String(taskDef),
+		ForceNewDeployment:      true,
+		DeploymentConfiguration: opts.deploymentConfiguration(),
+	}
+	if opts.DesiredCount > 0 {
+		input.DesiredCount = aws.Int32(opts.DesiredCount)
+	}
+
+	retryErr := util.ExponentialBackoff(ctx, c.retry, func() error {
+		_, err = c.client.UpdateService(ctx, input)
+		return withRetryAfter(err)
 	})
 
 	status := "success"
@@ -88,46 +163,171 @@ func (c *ECSClient) UpdateService(ctx context.Context, cluster, service, taskDef
 		status = "error"
 		metrics.RecordError("ecs_client", "update_service")
 	}
-	metrics.RecordAWSCall("ecs", "UpdateService", status, time.Since(start))
+	metrics.RecordAWSCallContext(ctx, "ecs", "UpdateService", status, time.Since(start))
 
 	return retryErr
 }
 
-func (c *ECSClient) CreateTaskSet(ctx context.Context, cluster, service, taskDef string, weight int) error {
+func (c *ECSClient) CreateTaskSet(ctx context.Context, cluster, service, taskDef string, weight int) (string, error) {
+	return c.CreateTaskSetWithScale(ctx, cluster, service, taskDef, float64(weight), nil)
+}
+
+// CreateTaskSetWithScale creates a task set sized as a percentage of the
+// service's desired count, the only scale unit ECS accepts, placing its tasks
+// in network when set (required for awsvpc task definitions). It returns the
+// ID ECS assigned to the task set.
+func (c *ECSClient) CreateTaskSetWithScale(ctx context.Context, cluster, service, taskDef string, scalePercent float64, network *types.NetworkConfiguration) (string, error) {
 	if c.mock {
-		log.Printf("[MOCK] CreateTaskSet: cluster=%s, service=%s, weight=%d%%", cluster, service, weight)
-		return nil
+		taskSetID := fmt.Sprintf("ecs-svc/mock-%d", time.Now().UnixNano())
+		util.Logf(ctx, "[MOCK] CreateTaskSet: cluster=%s, service=%s, scale=%.2f%%, id=%s", cluster, service, scalePercent, taskSetID)
+		if network != nil && network.AwsvpcConfiguration != nil {
+			vpc := network.AwsvpcConfiguration
+			util.Logf(ctx, "[MOCK] CreateTaskSet network: subnets=%v, security_groups=%v, public_ip=%s", vpc.Subnets, vpc.SecurityGroups, vpc.AssignPublicIp)
+		}
+		metrics.RecordMockAWSCall(ctx, "ecs", "CreateTaskSet")
+		return taskSetID, nil
 	}
-	_, err := c.client.CreateTaskSet(ctx, &ecs.CreateTaskSetInput{
+
+	input := createTaskSetInput(cluster, service, taskDef, scalePercent, network)
+
+	start := time.Now()
+	var out *ecs.CreateTaskSetOutput
+	retryErr := util.ExponentialBackoff(ctx, c.retry, func() error {
+		var err error
+		out, err = c.client.CreateTaskSet(ctx, input)
+		return withRetryAfter(err)
+	})
+
+	status := "success"
+	if retryErr != nil {
+		status = "error"
+		metrics.RecordError("ecs_client", "create_task_set")
+	}
+	metrics.RecordAWSCallContext(ctx, "ecs", "CreateTaskSet", status, time.Since(start))
+
+	if retryErr != nil {
+		return "", retryErr
+	}
+	if out.TaskSet == nil || out.TaskSet.Id == nil {
+		return "", fmt.Errorf("CreateTaskSet returned no task set")
+	}
+	return *out.TaskSet.Id, nil
+}
+
+// createTaskSetInput builds the CreateTaskSet request for a task set scaled
+// to scalePercent of the service's desired count. The same client token on
+// every attempt keeps a retry after a lost response from creating a second
+// task set.
+func createTaskSetInput(cluster, service, taskDef string, scalePercent float64, network *types.NetworkConfiguration) *ecs.CreateTaskSetInput {
+	return &ecs.CreateTaskSetInput{
 		Cluster:        aws.String(cluster),
 		Service:        aws.String(service),
 		TaskDefinition: aws.String(taskDef),
 		Scale: &types.Scale{
 			Unit:  types.ScaleUnitPercent,
-			Value: float64(weight),
+			Value: scalePercent,
 		},
-	})
-	return err
+		NetworkConfiguration: network,
+		ClientToken:          aws.String(clientToken()),
+	}
+}
+
+// PrimaryTaskSetID returns the ID of the service's PRIMARY task set, or ""
+// when the service has none
+func (c *ECSClient) PrimaryTaskSetID(ctx context.Context, cluster, service string) (string, error) {
+	if c.mock {
+		metrics.RecordMockAWSCall(ctx, "ecs", "DescribeServices")
+		return c.MockScenario.PrimaryTaskSet, nil
+	}
+
+	svc, err := c.DescribeService(ctx, cluster, service)
+	if err != nil {
+		return "", err
+	}
+	for _, taskSet := range svc.TaskSets {
+		if aws.ToString(taskSet.Status) == "PRIMARY" {
+			return aws.ToString(taskSet.Id), nil
+		}
+	}
+	return "", nil
 }
 
 func (c *ECSClient) DeleteTaskSet(ctx context.Context, cluster, service, taskSetID string) error {
 	if c.mock {
-		log.Printf("[MOCK] DeleteTaskSet: cluster=%s, service=%s, taskSetID=%s", cluster, service, taskSetID)
+		util.Logf(ctx, "[MOCK] DeleteTaskSet: cluster=%s, service=%s, taskSetID=%s", cluster, service, taskSetID)
+		metrics.RecordMockAWSCall(ctx, "ecs", "DeleteTaskSet")
+		return nil
+	}
+	start := time.Now()
+	attempt := 0
+	retryErr := util.ExponentialBackoff(ctx, c.retry, func() error {
+		attempt++
+		_, err := c.client.DeleteTaskSet(ctx, &ecs.DeleteTaskSetInput{
+			Cluster: aws.String(cluster),
+			Service: aws.String(service),
+			TaskSet: aws.String(taskSetID),
+			Force:   aws.Bool(true),
+		})
+		// An earlier attempt may have deleted it before its response was lost
+		var notFound *types.TaskSetNotFoundException
+		if attempt > 1 && errors.As(err, &notFound) {
+			return nil
+		}
+		return withRetryAfter(err)
+	})
+
+	status := "success"
+	if retryErr != nil {
+		status = "error"
+		metrics.RecordError("ecs_client", "delete_task_set")
+	}
+	metrics.RecordAWSCallContext(ctx, "ecs", "DeleteTaskSet", status, time.Since(start))
+
+	return retryErr
+}
+
+// UpdateServicePrimaryTaskSet makes taskSetID the service's PRIMARY task set,
+// so ECS treats it as the current version once the old primary is deleted
+func (c *ECSClient) UpdateServicePrimaryTaskSet(ctx context.Context, cluster, service, taskSetID string) error {
+	if c.mock {
+		util.Logf(ctx, "[MOCK] UpdateServicePrimaryTaskSet: cluster=%s, service=%s, taskSetID=%s", cluster, service, taskSetID)
+		metrics.RecordMockAWSCall(ctx, "ecs", "UpdateServicePrimaryTaskSet")
 		return nil
 	}
-	_, err := c.client.DeleteTaskSet(ctx, &ecs.DeleteTaskSetInput{
-		Cluster: aws.String(cluster),
-		Service: aws.String(service),
-		TaskSet: aws.String(taskSetID),
-		Force:   aws.Bool(true),
+	start := time.Now()
+	retryErr := util.ExponentialBackoff(ctx, c.retry, func() error {
+		_, err := c.client.UpdateServicePrimaryTaskSet(ctx, &ecs.UpdateServicePrimaryTaskSetInput{
+			Cluster:        aws.String(cluster),
+			Service:        aws.String(service),
+			PrimaryTaskSet: aws.String(taskSetID),
+		})
+		return withRetryAfter(err)
 	})
-	return err
+
+	status := "success"
+	if retryErr != nil {
+		status = "error"
+		metrics.RecordError("ecs_client", "update_primary_task_set")
+	}
+	metrics.RecordAWSCallContext(ctx, "ecs", "UpdateServicePrimaryTaskSet", status, time.Since(start))
+
+	return retryErr
 }
 
+// ErrNoPreviousDeployment is returned when a service has never been deployed
+// before, so there is no earlier task definition to return to
+var ErrNoPreviousDeployment = errors.New("no previous deployment found")
+
+// GetPreviousTaskDefinition returns the task definition of the service's
+// deployment before the primary one
 func (c *ECSClient) GetPreviousTaskDefinition(ctx context.Context, cluster, service string) (string, error) {
 	if c.mock {
-		log.Printf("[MOCK] GetPreviousTaskDefinition: cluster=%s, service=%s", cluster, service)
-		return "arn:aws:ecs:us-east-1:123456789:task-definition/previous:1", nil
+		util.Logf(ctx, "[MOCK] GetPreviousTaskDefinition: cluster=%s, service=%s", cluster, service)
+		metrics.RecordMockAWSCall(ctx, "ecs", "DescribeServices")
+		if c.MockScenario.PreviousTaskDefinition == "" {
+			return "", ErrNoPreviousDeployment
+		}
+		return c.MockScenario.PreviousTaskDefinition, nil
 	}
 	resp, err := c.client.DescribeServices(ctx, &ecs.DescribeServicesInput{
 		Cluster:  aws.String(cluster),
@@ -143,7 +343,7 @@ func (c *ECSClient) GetPreviousTaskDefinition(ctx context.Context, cluster, serv
 
 	deployments := resp.Services[0].Deployments
 	if len(deployments) < 2 {
-		return "", fmt.Errorf("no previous deployment found")
+		return "", ErrNoPreviousDeployment
 	}
 
 	return *deployments[1].TaskDefinition, nil
@@ -152,40 +352,26 @@ func (c *ECSClient) GetPreviousTaskDefinition(ctx context.Context, cluster, serv
 // DescribeService retrieves service details with retry and metrics
 func (c *ECSClient) DescribeService(ctx context.Context, cluster, service string) (*types.Service, error) {
 	if c.mock {
-		desiredCount := int32(2)
-		runningCount := int32(2)
-		return &types.Service{
-			ServiceName:  aws.String(service),
-			Status:       aws.String("ACTIVE"),
-			DesiredCount: desiredCount,
-			RunningCount: runningCount,
-			Deployments: []types.Deployment{
-				{
-					Status:       aws.String("PRIMARY"),
-					RolloutState: types.DeploymentRolloutStateCompleted,
-					RunningCount: runningCount,
-					DesiredCount: desiredCount,
-				},
-			},
-		}, nil
+		metrics.RecordMockAWSCall(ctx, "ecs", "DescribeServices")
+		return c.MockScenario.service(service), nil
 	}
 
 	start := time.Now()
 	var result *ecs.DescribeServicesOutput
 
-	err := util.ExponentialBackoff(ctx, util.DefaultRetryConfig(), func() error {
+	err := util.ExponentialBackoff(ctx, c.retry, func() error {
 		var e error
 		result, e = c.client.DescribeServices(ctx, &ecs.DescribeServicesInput{
 			Cluster:  aws.String(cluster),
 			Services: []string{service},
 		})
-		return e
+		return withRetryAfter(e)
 	})
 
-	metrics.RecordAWSCall("ecs", "DescribeServices", "success", time.Since(start))
+	metrics.RecordAWSCallContext(ctx, "ecs", "DescribeServices", "success", time.Since(start))
 
 	if err != nil {
-		metrics.RecordAWSCall("ecs", "DescribeServices", "error", time.Since(start))
+		metrics.RecordAWSCallContext(ctx, "ecs", "DescribeServices", "error", time.Since(start))
 		metrics.RecordError("aws", "DescribeServices")
 		return nil, fmt.Errorf("describe services failed: %w", err)
 	}
@@ -198,9 +384,85 @@ func (c *ECSClient) DescribeService(ctx context.Context, cluster, service string
 	return &result.Services[0], nil
 }
 
+// ListTaskDefinitionRevisions returns the ARNs of family's ACTIVE revisions,
+// newest first
+func (c *ECSClient) ListTaskDefinitionRevisions(ctx context.Context, family string) ([]string, error) {
+	if c.mock {
+		metrics.RecordMockAWSCall(ctx, "ecs", "ListTaskDefinitions")
+		return c.MockScenario.taskDefinitionRevisions(family), nil
+	}
+
+	start := time.Now()
+	var arns []string
+	paginator := ecs.NewListTaskDefinitionsPaginator(c.client, &ecs.ListTaskDefinitionsInput{
+		FamilyPrefix: aws.String(family),
+		Status:       types.TaskDefinitionStatusActive,
+		Sort:         types.SortOrderDesc,
+	})
+	for paginator.HasMorePages() {
+		var page *ecs.ListTaskDefinitionsOutput
+		err := util.ExponentialBackoff(ctx, c.retry, func() error {
+			var e error
+			page, e = paginator.NextPage(ctx)
+			return withRetryAfter(e)
+		})
+		if err != nil {
+			metrics.RecordAWSCallContext(ctx, "ecs", "ListTaskDefinitions", "error", time.Since(start))
+			metrics.RecordError("aws", "ListTaskDefinitions")
+			return nil, fmt.Errorf("list task definitions failed: %w", err)
+		}
+		// The prefix also matches longer family names, e.g. api-worker for api
+		for _, arn := range page.TaskDefinitionArns {
+			if TaskDefinitionFamily(arn) == family {
+				arns = append(arns, arn)
+			}
+		}
+	}
+	metrics.RecordAWSCallContext(ctx, "ecs", "ListTaskDefinitions", "success", time.Since(start))
+	return arns, nil
+}
+
+// DeregisterTaskDefinition marks a task definition revision INACTIVE. Tasks
+// and services already using it keep running.
+func (c *ECSClient) DeregisterTaskDefinition(ctx context.Context, taskDefARN string) error {
+	if c.mock {
+		util.Logf(ctx, "[MOCK] DeregisterTaskDefinition: %s", taskDefARN)
+		metrics.RecordMockAWSCall(ctx, "ecs", "DeregisterTaskDefinition")
+		return nil
+	}
+
+	start := time.Now()
+	err := util.ExponentialBackoff(ctx, c.retry, func() error {
+		_, e := c.client.DeregisterTaskDefinition(ctx, &ecs.DeregisterTaskDefinitionInput{
+			TaskDefinition: aws.String(taskDefARN),
+		})
+		return withRetryAfter(e)
+	})
+
+	status := "success"
+	if err != nil {
+		status = "error"
+		metrics.RecordError("aws", "DeregisterTaskDefinition")
+		err = fmt.Errorf("deregister task definition %s failed: %w", taskDefARN, err)
+	}
+	metrics.RecordAWSCallContext(ctx, "ecs", "DeregisterTaskDefinition", status, time.Since(start))
+	return err
+}
+
+// TaskDefinitionFamily returns the family of a task definition ARN or
+// family:revision reference
+func TaskDefinitionFamily(taskDef string) string {
+	if i := strings.LastIndex(taskDef, "/"); i >= 0 {
+		taskDef = taskDef[i+1:]
+	}
+	family, _, _ := strings.Cut(taskDef, ":")
+	return family
+}
+
 // DescribeTaskDefinition retrieves task definition details
 func (c *ECSClient) DescribeTaskDefinition(ctx context.Context, taskDef string) (*types.TaskDefinition, error) {
 	if c.mock {
+		metrics.RecordMockAWSCall(ctx, "ecs", "DescribeTaskDefinition")
 		family := "mock-task"
 		return &types.TaskDefinition{
 			Family: &family,
@@ -210,21 +472,38 @@ func (c *ECSClient) DescribeTaskDefinition(ctx context.Context, taskDef string)
 	start := time.Now()
 	var result *ecs.DescribeTaskDefinitionOutput
 
-	err := util.ExponentialBackoff(ctx, util.DefaultRetryConfig(), func() error {
+	err := util.ExponentialBackoff(ctx, c.retry, func() error {
 		var e error
 		result, e = c.client.DescribeTaskDefinition(ctx, &ecs.DescribeTaskDefinitionInput{
 			TaskDefinition: aws.String(taskDef),
 		})
-		return e
+		return withRetryAfter(e)
 	})
 
-	metrics.RecordAWSCall("ecs", "DescribeTaskDefinition", "success", time.Since(start))
+	metrics.RecordAWSCallContext(ctx, "ecs", "DescribeTaskDefinition", "success", time.Since(start))
 
 	if err != nil {
-		metrics.RecordAWSCall("ecs", "DescribeTaskDefinition", "error", time.Since(start))
+		metrics.RecordAWSCallContext(ctx, "ecs", "DescribeTaskDefinition", "error", time.Since(start))
 		metrics.RecordError("aws", "DescribeTaskDefinition")
 		return nil, fmt.Errorf("describe task definition failed: %w", err)
 	}
 
 	return result.TaskDefinition, nil
 }
+
+// clientToken returns a random idempotency token for ECS create calls
+func clientToken() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return fmt.Sprintf("%032x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(b)
+}
+
+// callStatus maps an AWS call error to the status label used in metrics
+func callStatus(err error) string {
+	if err != nil {
+		return "error"
+	}
+	return "success"
+}
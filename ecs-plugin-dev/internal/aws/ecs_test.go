@@ -0,0 +1,22 @@
+package aws
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+)
+
+func TestCreateTaskSetInputScale(t *testing.T) {
+	input := createTaskSetInput("cluster", "web", "web:2", 50, nil)
+
+	if input.Scale == nil {
+		t.Fatal("expected a scale")
+	}
+	// ECS only accepts PERCENT, so counts arrive here already converted
+	if input.Scale.Unit != types.ScaleUnitPercent || input.Scale.Value != 50 {
+		t.Errorf("expected 50 PERCENT, got %v %s", input.Scale.Value, input.Scale.Unit)
+	}
+	if input.ClientToken == nil || *input.ClientToken == "" {
+		t.Error("expected a client token")
+	}
+}
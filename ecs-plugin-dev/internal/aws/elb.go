@@ -3,90 +3,232 @@ package aws
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"ecs-plugin-dev/internal/metrics"
+	"ecs-plugin-dev/internal/util"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2"
 	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
 )
 
+// ErrWeightedRoutingUnsupported is returned when traffic weights are requested
+// on a load balancer that cannot split traffic between target groups
+var ErrWeightedRoutingUnsupported = errors.New("weighted traffic shifting is not supported")
+
 type ELBClient struct {
 	client *elasticloadbalancingv2.Client
 	mock   bool
+	retry  util.RetryConfig
+
+	// MockHealthyTargets scripts DescribeTargetHealth in mock mode: the number
+	// of healthy targets per target group ARN, with "*" matching any group.
+	// Groups without an entry report defaultMockHealthyTargets.
+	MockHealthyTargets map[string]int
+	// MockRegisteredTargets optionally scripts how many targets each group
+	// has in total, the ones beyond its healthy count failing health checks
+	MockRegisteredTargets map[string]int
+	// MockRulePriorities scripts the rules on the mock listener: the ARN of
+	// the rule holding each priority. Rules created in mock mode are added
+	// to it and deleted ones removed.
+	MockRulePriorities map[int]string
+	// MockLoadBalancerType is the type of the mock listener's load balancer,
+	// an application load balancer unless set
+	MockLoadBalancerType types.LoadBalancerTypeEnum
+
+	mockRulesMu sync.Mutex
 }
 
+// The mock listener and the target groups it forwards to
+const (
+	MockListenerARN           = "arn:aws:elasticloadbalancing:us-east-1:123456789012:listener/app/my-load-balancer/50dc6c495c0c9188/f2f7dc8efc522ab2"
+	MockCanaryTargetGroupARN  = "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/mock-canary/6d0ecf831eec9f09"
+	MockPrimaryTargetGroupARN = "arn:aws:elasticloadbalancing:us-east-1:123456789012:targetgroup/mock-primary/73e2d6bc24d8a067"
+)
+
+// defaultMockHealthyTargets is the healthy target count mock target groups
+// report unless scripted otherwise
+const defaultMockHealthyTargets = 2
+
 func NewELBClient() *ELBClient {
+	return NewELBClientWithConfig(util.DefaultRetryConfig())
+}
+
+// NewELBClientWithConfig creates an ELB client that retries calls with retry
+func NewELBClientWithConfig(retry util.RetryConfig) *ELBClient {
 	if isMock() {
 		log.Println("[MOCK] ELB client in mock mode")
-		return &ELBClient{mock: true}
+		healthy, registered := parseMockHealthyTargets(os.Getenv("MOCK_HEALTHY_TARGETS"))
+		return &ELBClient{mock: true, retry: retry, MockHealthyTargets: healthy, MockRegisteredTargets: registered}
 	}
 	cfg, err := loadConfig(context.Background())
 	if err != nil {
 		panic(fmt.Sprintf("failed to create ELB client: %v", err))
 	}
+	if breaker := newCircuitBreaker("elbv2", retry); breaker != nil {
+		cfg.APIOptions = append(cfg.APIOptions, breakAPICalls("elbv2", breaker))
+	}
 	return &ELBClient{
 		client: elasticloadbalancingv2.NewFromConfig(cfg),
+		retry:  retry,
 	}
 }
 
+// TrafficRouting selects the listener and target groups a traffic shift
+// applies to. Zero values fall back to discovery from the service's first
+// load balancer and the listener's default forward action.
+type TrafficRouting struct {
+	// ListenerARN is the listener whose default action is reweighted
+	ListenerARN string
+	// TargetGroupTag is a tag key; the target groups on the listener's load
+	// balancer tagged with values "canary" and "primary" receive the weights
+	TargetGroupTag string
+	// MinHealthyPercent is the share of registered targets that must be
+	// healthy in every group about to receive traffic; when zero, one
+	// healthy target is enough
+	MinHealthyPercent int
+	// LenientHealth logs target groups failing the health check and shifts
+	// traffic anyway instead of refusing the shift
+	LenientHealth bool
+}
+
+const (
+	canaryTargetGroupRole  = "canary"
+	primaryTargetGroupRole = "primary"
+)
+
 func (c *ELBClient) UpdateTargetGroupWeights(ctx context.Context, cluster, service string, canaryWeight, primaryWeight int) error {
+	return c.UpdateTargetGroupWeightsWithRouting(ctx, cluster, service, TrafficRouting{}, canaryWeight, primaryWeight)
+}
+
+// UpdateTargetGroupWeightsWithRouting reweights the canary and primary target
+// groups selected by routing
+func (c *ELBClient) UpdateTargetGroupWeightsWithRouting(ctx context.Context, cluster, service string, routing TrafficRouting, canaryWeight, primaryWeight int) error {
 	if c.mock {
-		log.Printf("[MOCK] UpdateTargetGroupWeights: canary=%d%%, primary=%d%%", canaryWeight, primaryWeight)
+		util.Logf(ctx, "[MOCK] UpdateTargetGroupWeights: canary=%d%%, primary=%d%%, routing=%+v", canaryWeight, primaryWeight, routing)
+		if err := c.validateTargetGroupHealth(ctx, routing.MinHealthyPercent, receivingTraffic(MockCanaryTargetGroupARN, canaryWeight, MockPrimaryTargetGroupARN, primaryWeight)...); err != nil {
+			if !routing.LenientHealth {
+				return fmt.Errorf("refusing traffic shift: %w", err)
+			}
+			util.Logf(ctx, "[WARN] Target group health validation failed: %v", err)
+		}
+		metrics.RecordMockAWSCall(ctx, "elbv2", "ModifyListener")
 		return nil
 	}
 
-	// Discover listener ARN from service tags or configuration
-	listenerArn, err := c.discoverListenerArn(ctx, cluster, service)
+	groups, err := c.ResolveTargetGroups(ctx, cluster, service, routing)
 	if err != nil {
-		return fmt.Errorf("failed to discover listener ARN: %w", err)
+		return err
 	}
+	listenerArn, canaryTG, primaryTG := groups.ListenerARN, groups.Canary, groups.Primary
 
-	// Get target groups for this listener
-	canaryTG, primaryTG, err := c.getTargetGroups(ctx, listenerArn)
-	if err != nil {
-		return fmt.Errorf("failed to get target groups: %w", err)
-	}
-
-	// Validate target group health before shifting traffic
-	if err := c.validateTargetGroupHealth(ctx, canaryTG, primaryTG); err != nil {
-		log.Printf("[WARN] Target group health validation failed: %v", err)
+	// Shifting traffic onto too few healthy targets overloads them
+	if err := c.validateTargetGroupHealth(ctx, routing.MinHealthyPercent, receivingTraffic(canaryTG, canaryWeight, primaryTG, primaryWeight)...); err != nil {
+		if !routing.LenientHealth {
+			return fmt.Errorf("refusing traffic shift: %w", err)
+		}
+		util.Logf(ctx, "[WARN] Target group health validation failed: %v", err)
 	}
 
-	_, err = c.client.ModifyListener(ctx, &elasticloadbalancingv2.ModifyListenerInput{
-		ListenerArn: aws.String(listenerArn),
-		DefaultActions: []types.Action{
-			{
-				Type: types.ActionTypeEnumForward,
-				ForwardConfig: &types.ForwardActionConfig{
-					TargetGroups: []types.TargetGroupTuple{
-						{
-							TargetGroupArn: aws.String(canaryTG),
-							Weight:         aws.Int32(int32(canaryWeight)),
-						},
-						{
-							TargetGroupArn: aws.String(primaryTG),
-							Weight:         aws.Int32(int32(primaryWeight)),
+	start := time.Now()
+	err = util.ExponentialBackoff(ctx, c.retry, func() error {
+		_, modifyErr := c.client.ModifyListener(ctx, &elasticloadbalancingv2.ModifyListenerInput{
+			ListenerArn: aws.String(listenerArn),
+			DefaultActions: []types.Action{
+				{
+					Type: types.ActionTypeEnumForward,
+					ForwardConfig: &types.ForwardActionConfig{
+						TargetGroups: []types.TargetGroupTuple{
+							{
+								TargetGroupArn: aws.String(canaryTG),
+								Weight:         aws.Int32(int32(canaryWeight)),
+							},
+							{
+								TargetGroupArn: aws.String(primaryTG),
+								Weight:         aws.Int32(int32(primaryWeight)),
+							},
 						},
 					},
 				},
 			},
-		},
+		})
+		return withRetryAfter(modifyErr)
 	})
+	metrics.RecordAWSCallContext(ctx, "elbv2", "ModifyListener", callStatus(err), time.Since(start))
 	return err
 }
 
+// TargetGroups are the canary and primary target groups behind a service's
+// weighted listener
+type TargetGroups struct {
+	ListenerARN string
+	Canary      string
+	Primary     string
+}
+
+// ResolveTargetGroups finds the listener and target groups a traffic shift
+// for the service applies to, as selected by routing. Network load
+// balancers cannot weight traffic and are rejected.
+func (c *ELBClient) ResolveTargetGroups(ctx context.Context, cluster, service string, routing TrafficRouting) (TargetGroups, error) {
+	listenerArn := routing.ListenerARN
+	if listenerArn == "" {
+		var err error
+		listenerArn, err = c.discoverListenerArn(ctx, cluster, service)
+		if err != nil {
+			return TargetGroups{}, fmt.Errorf("failed to discover listener ARN: %w", err)
+		}
+	}
+
+	// NLB listeners forward to a single target group and cannot be weighted
+	lbType, err := c.detectLoadBalancerType(ctx, listenerArn)
+	if err != nil {
+		return TargetGroups{}, fmt.Errorf("failed to detect load balancer type: %w", err)
+	}
+	if lbType == types.LoadBalancerTypeEnumNetwork {
+		return TargetGroups{}, fmt.Errorf("%w for network load balancers (listener %s); shift traffic by promoting the primary task set instead",
+			ErrWeightedRoutingUnsupported, listenerArn)
+	}
+
+	if c.mock {
+		return TargetGroups{
+			ListenerARN: MockListenerARN,
+			Canary:      MockCanaryTargetGroupARN,
+			Primary:     MockPrimaryTargetGroupARN,
+		}, nil
+	}
+
+	// Get target groups for this listener
+	var canaryTG, primaryTG string
+	if routing.TargetGroupTag != "" {
+		canaryTG, primaryTG, err = c.getTargetGroupsByTag(ctx, listenerArn, routing.TargetGroupTag)
+	} else {
+		canaryTG, primaryTG, err = c.getTargetGroups(ctx, listenerArn)
+	}
+	if err != nil {
+		return TargetGroups{}, fmt.Errorf("failed to get target groups: %w", err)
+	}
+	return TargetGroups{ListenerARN: listenerArn, Canary: canaryTG, Primary: primaryTG}, nil
+}
+
 // discoverListenerArn discovers the ALB listener ARN from service configuration
 func (c *ELBClient) discoverListenerArn(ctx context.Context, cluster, service string) (string, error) {
 	if c.mock {
-		log.Println("[ELB] Mock mode: returning test listener ARN")
-		return "arn:aws:elasticloadbalancing:us-east-1:123456789012:listener/app/my-load-balancer/50dc6c495c0c9188/f2f7dc8efc522ab2", nil
+		util.Logf(ctx, "[ELB] Mock mode: returning test listener ARN")
+		return MockListenerARN, nil
 	}
 
-	log.Printf("[ELB] Discovering listener ARN for service %s", service)
+	util.Logf(ctx, "[ELB] Discovering listener ARN for service %s", service)
 
 	// Get ECS service to find load balancers
-	ecsClient := NewECSClient()
+	ecsClient := NewECSClientWithConfig(c.retry)
 	svc, err := ecsClient.DescribeService(ctx, cluster, service)
 	if err != nil {
 		return "", fmt.Errorf("failed to describe service: %w", err)
@@ -98,7 +240,7 @@ func (c *ELBClient) discoverListenerArn(ctx context.Context, cluster, service st
 
 	// Get target group ARN from service
 	targetGroupArn := *svc.LoadBalancers[0].TargetGroupArn
-	log.Printf("[ELB] Found target group: %s", targetGroupArn)
+	util.Logf(ctx, "[ELB] Found target group: %s", targetGroupArn)
 
 	// Describe target group to get load balancer ARN
 	tgResp, err := c.client.DescribeTargetGroups(ctx, &elasticloadbalancingv2.DescribeTargetGroupsInput{
@@ -113,7 +255,7 @@ func (c *ELBClient) discoverListenerArn(ctx context.Context, cluster, service st
 	}
 
 	lbArn := tgResp.TargetGroups[0].LoadBalancerArns[0]
-	log.Printf("[ELB] Found load balancer: %s", lbArn)
+	util.Logf(ctx, "[ELB] Found load balancer: %s", lbArn)
 
 	// Get listeners for the load balancer
 	listenersResp, err := c.client.DescribeListeners(ctx, &elasticloadbalancingv2.DescribeListenersInput{
@@ -128,11 +270,206 @@ func (c *ELBClient) discoverListenerArn(ctx context.Context, cluster, service st
 	}
 
 	listenerArn := *listenersResp.Listeners[0].ListenerArn
-	log.Printf("[ELB] Discovered listener ARN: %s", listenerArn)
+	util.Logf(ctx, "[ELB] Discovered listener ARN: %s", listenerArn)
 
 	return listenerArn, nil
 }
 
+// detectLoadBalancerType resolves the type of the load balancer owning the listener
+func (c *ELBClient) detectLoadBalancerType(ctx context.Context, listenerArn string) (types.LoadBalancerTypeEnum, error) {
+	if c.mock {
+		if c.MockLoadBalancerType != "" {
+			return c.MockLoadBalancerType, nil
+		}
+		return types.LoadBalancerTypeEnumApplication, nil
+	}
+
+	listenersResp, err := c.client.DescribeListeners(ctx, &elasticloadbalancingv2.DescribeListenersInput{
+		ListenerArns: []string{listenerArn},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to describe listener: %w", err)
+	}
+	if len(listenersResp.Listeners) == 0 || listenersResp.Listeners[0].LoadBalancerArn == nil {
+		return "", fmt.Errorf("listener not found")
+	}
+
+	lbResp, err := c.client.DescribeLoadBalancers(ctx, &elasticloadbalancingv2.DescribeLoadBalancersInput{
+		LoadBalancerArns: []string{*listenersResp.Listeners[0].LoadBalancerArn},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to describe load balancer: %w", err)
+	}
+	if len(lbResp.LoadBalancers) == 0 {
+		return "", fmt.Errorf("load balancer not found for listener %s", listenerArn)
+	}
+
+	lbType := lbResp.LoadBalancers[0].Type
+	util.Logf(ctx, "[ELB] Load balancer type for listener %s: %s", listenerArn, lbType)
+	return lbType, nil
+}
+
+// maxListenerRulePriority is the highest priority an ALB listener rule may use
+const maxListenerRulePriority = 50000
+
+// ResolveListenerRulePriority checks a listener's existing rules before a new
+// rule (such as a header or cookie based A/B rule) is created. A preferred
+// priority is validated against the rules already on the listener; with no
+// preference the lowest free priority is selected.
+func (c *ELBClient) ResolveListenerRulePriority(ctx context.Context, listenerArn string, preferred int) (int, error) {
+	if preferred < 0 || preferred > maxListenerRulePriority {
+		return 0, fmt.Errorf("listener rule priority %d out of range 1-%d", preferred, maxListenerRulePriority)
+	}
+
+	occupied, err := c.listenerRulePriorities(ctx, listenerArn)
+	if err != nil {
+		return 0, err
+	}
+
+	if preferred > 0 {
+		if ruleArn, taken := occupied[preferred]; taken {
+			return 0, fmt.Errorf("listener rule priority %d is already used by rule %s on listener %s; choose an unused priority or leave it unset to pick the lowest free one",
+				preferred, ruleArn, listenerArn)
+		}
+		return preferred, nil
+	}
+
+	for priority := 1; priority <= maxListenerRulePriority; priority++ {
+		if _, taken := occupied[priority]; !taken {
+			util.Logf(ctx, "[ELB] Selected free rule priority %d on listener %s (%d rules present)", priority, listenerArn, len(occupied))
+			return priority, nil
+		}
+	}
+	return 0, fmt.Errorf("no free rule priority on listener %s", listenerArn)
+}
+
+// listenerRulePriorities maps the priority of each rule on a listener, other
+// than its default rule, to the rule's ARN
+func (c *ELBClient) listenerRulePriorities(ctx context.Context, listenerArn string) (map[int]string, error) {
+	occupied := make(map[int]string)
+	if c.mock {
+		c.mockRulesMu.Lock()
+		defer c.mockRulesMu.Unlock()
+		for priority, ruleArn := range c.MockRulePriorities {
+			occupied[priority] = ruleArn
+		}
+		metrics.RecordMockAWSCall(ctx, "elbv2", "DescribeRules")
+		return occupied, nil
+	}
+
+	input := &elasticloadbalancingv2.DescribeRulesInput{ListenerArn: aws.String(listenerArn)}
+	for {
+		resp, err := c.client.DescribeRules(ctx, input)
+		if err != nil {
+			return nil, fmt.Errorf("failed to describe listener rules: %w", err)
+		}
+
+		for _, rule := range resp.Rules {
+			// The default rule reports its priority as "default"
+			priority, err := strconv.Atoi(aws.ToString(rule.Priority))
+			if err != nil {
+				continue
+			}
+			occupied[priority] = aws.ToString(rule.RuleArn)
+		}
+
+		if resp.NextMarker == nil {
+			break
+		}
+		input.Marker = resp.NextMarker
+	}
+	return occupied, nil
+}
+
+// CreateHeaderRule adds a rule to a listener forwarding every request whose
+// header carries value to targetGroupArn, ahead of the weighted default
+// action. The rule takes priority, or the lowest free one when it is zero.
+// It returns the new rule's ARN.
+func (c *ELBClient) CreateHeaderRule(ctx context.Context, listenerArn, header, value, targetGroupArn string, priority int) (string, error) {
+	priority, err := c.ResolveListenerRulePriority(ctx, listenerArn, priority)
+	if err != nil {
+		return "", err
+	}
+
+	if c.mock {
+		c.mockRulesMu.Lock()
+		defer c.mockRulesMu.Unlock()
+		// The priority may have been taken since it was resolved
+		if ruleArn, taken := c.MockRulePriorities[priority]; taken {
+			return "", fmt.Errorf("failed to create listener rule: priority %d is already used by rule %s", priority, ruleArn)
+		}
+		if c.MockRulePriorities == nil {
+			c.MockRulePriorities = make(map[int]string)
+		}
+		ruleArn := fmt.Sprintf("%s/mock-rule-%d", strings.Replace(listenerArn, ":listener/", ":listener-rule/", 1), priority)
+		c.MockRulePriorities[priority] = ruleArn
+		util.Logf(ctx, "[MOCK] CreateRule: %s: %s -> %s at priority %d", header, value, targetGroupArn, priority)
+		metrics.RecordMockAWSCall(ctx, "elbv2", "CreateRule")
+		return ruleArn, nil
+	}
+
+	start := time.Now()
+	var ruleArn string
+	err = util.ExponentialBackoff(ctx, c.retry, func() error {
+		resp, createErr := c.client.CreateRule(ctx, &elasticloadbalancingv2.CreateRuleInput{
+			ListenerArn: aws.String(listenerArn),
+			Priority:    aws.Int32(int32(priority)),
+			Conditions: []types.RuleCondition{
+				{
+					Field: aws.String("http-header"),
+					HttpHeaderConfig: &types.HttpHeaderConditionConfig{
+						HttpHeaderName: aws.String(header),
+						Values:         []string{value},
+					},
+				},
+			},
+			Actions: []types.Action{
+				{
+					Type:           types.ActionTypeEnumForward,
+					TargetGroupArn: aws.String(targetGroupArn),
+				},
+			},
+		})
+		if createErr == nil && len(resp.Rules) > 0 {
+			ruleArn = aws.ToString(resp.Rules[0].RuleArn)
+		}
+		return withRetryAfter(createErr)
+	})
+	metrics.RecordAWSCallContext(ctx, "elbv2", "CreateRule", callStatus(err), time.Since(start))
+	if err != nil {
+		return "", fmt.Errorf("failed to create listener rule: %w", err)
+	}
+	util.Logf(ctx, "[ELB] Created rule %s forwarding %s: %s to %s at priority %d", ruleArn, header, value, targetGroupArn, priority)
+	return ruleArn, nil
+}
+
+// DeleteListenerRule removes a rule created by CreateHeaderRule
+func (c *ELBClient) DeleteListenerRule(ctx context.Context, ruleArn string) error {
+	if c.mock {
+		c.mockRulesMu.Lock()
+		defer c.mockRulesMu.Unlock()
+		for priority, arn := range c.MockRulePriorities {
+			if arn == ruleArn {
+				delete(c.MockRulePriorities, priority)
+			}
+		}
+		util.Logf(ctx, "[MOCK] DeleteRule: %s", ruleArn)
+		metrics.RecordMockAWSCall(ctx, "elbv2", "DeleteRule")
+		return nil
+	}
+
+	start := time.Now()
+	err := util.ExponentialBackoff(ctx, c.retry, func() error {
+		_, deleteErr := c.client.DeleteRule(ctx, &elasticloadbalancingv2.DeleteRuleInput{RuleArn: aws.String(ruleArn)})
+		return withRetryAfter(deleteErr)
+	})
+	metrics.RecordAWSCallContext(ctx, "elbv2", "DeleteRule", callStatus(err), time.Since(start))
+	if err != nil {
+		return fmt.Errorf("failed to delete listener rule %s: %w", ruleArn, err)
+	}
+	return nil
+}
+
 // getTargetGroups retrieves target group ARNs for canary and primary
 func (c *ELBClient) getTargetGroups(ctx context.Context, listenerArn string) (string, string, error) {
 	// Query listener to get current target groups
@@ -161,31 +498,219 @@ func (c *ELBClient) getTargetGroups(ctx context.Context, listenerArn string) (st
 	return "", "", fmt.Errorf("target groups not found in listener configuration")
 }
 
-// validateTargetGroupHealth checks target group health before traffic shift
-func (c *ELBClient) validateTargetGroupHealth(ctx context.Context, canaryTG, primaryTG string) error {
-	for _, tgArn := range []string{canaryTG, primaryTG} {
-		healthResult, err := c.client.DescribeTargetHealth(ctx, &elasticloadbalancingv2.DescribeTargetHealthInput{
-			TargetGroupArn: aws.String(tgArn),
-		})
+// getTargetGroupsByTag selects the canary and primary target groups among those
+// attached to the listener's load balancer by the value of their tagKey tag
+func (c *ELBClient) getTargetGroupsByTag(ctx context.Context, listenerArn, tagKey string) (string, string, error) {
+	listeners, err := c.client.DescribeListeners(ctx, &elasticloadbalancingv2.DescribeListenersInput{
+		ListenerArns: []string{listenerArn},
+	})
+	if err != nil {
+		return "", "", fmt.Errorf("failed to describe listener: %w", err)
+	}
+	if len(listeners.Listeners) == 0 || listeners.Listeners[0].LoadBalancerArn == nil {
+		return "", "", fmt.Errorf("listener not found")
+	}
 
+	var arns []string
+	input := &elasticloadbalancingv2.DescribeTargetGroupsInput{LoadBalancerArn: listeners.Listeners[0].LoadBalancerArn}
+	for {
+		resp, err := c.client.DescribeTargetGroups(ctx, input)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to describe target groups: %w", err)
+		}
+		for _, tg := range resp.TargetGroups {
+			arns = append(arns, aws.ToString(tg.TargetGroupArn))
+		}
+		if resp.NextMarker == nil {
+			break
+		}
+		input.Marker = resp.NextMarker
+	}
+
+	roles := make(map[string]string)
+	// DescribeTags accepts at most 20 resources per call
+	for start := 0; start < len(arns); start += 20 {
+		end := start + 20
+		if end > len(arns) {
+			end = len(arns)
+		}
+		resp, err := c.client.DescribeTags(ctx, &elasticloadbalancingv2.DescribeTagsInput{ResourceArns: arns[start:end]})
+		if err != nil {
+			return "", "", fmt.Errorf("failed to describe target group tags: %w", err)
+		}
+		for _, desc := range resp.TagDescriptions {
+			for _, tag := range desc.Tags {
+				if aws.ToString(tag.Key) != tagKey {
+					continue
+				}
+				role := aws.ToString(tag.Value)
+				if existing, ok := roles[role]; ok {
+					return "", "", fmt.Errorf("target groups %s and %s are both tagged %s=%s", existing, aws.ToString(desc.ResourceArn), tagKey, role)
+				}
+				roles[role] = aws.ToString(desc.ResourceArn)
+			}
+		}
+	}
+
+	canaryTG, primaryTG := roles[canaryTargetGroupRole], roles[primaryTargetGroupRole]
+	if canaryTG == "" || primaryTG == "" {
+		return "", "", fmt.Errorf("no target groups tagged %s=%s and %s=%s on the listener's load balancer",
+			tagKey, canaryTargetGroupRole, tagKey, primaryTargetGroupRole)
+	}
+	util.Logf(ctx, "[ELB] Selected target groups by tag %s: canary=%s, primary=%s", tagKey, canaryTG, primaryTG)
+	return canaryTG, primaryTG, nil
+}
+
+// receivingTraffic returns the target groups a shift gives a non-zero weight.
+// Groups weighted to zero, like the canary during a rollback, are not checked.
+func receivingTraffic(canaryTG string, canaryWeight int, primaryTG string, primaryWeight int) []string {
+	var groups []string
+	if canaryWeight > 0 {
+		groups = append(groups, canaryTG)
+	}
+	if primaryWeight > 0 {
+		groups = append(groups, primaryTG)
+	}
+	return groups
+}
+
+// validateTargetGroupHealth checks target group health before traffic shift:
+// every group needs a healthy target, and at least minHealthyPercent of its
+// registered targets healthy. Draining targets do not count as registered.
+func (c *ELBClient) validateTargetGroupHealth(ctx context.Context, minHealthyPercent int, targetGroups ...string) error {
+	for _, tgArn := range targetGroups {
+		descriptions, err := c.describeTargetHealth(ctx, tgArn)
 		if err != nil {
 			return fmt.Errorf("failed to describe target health for %s: %w", tgArn, err)
 		}
 
-		// Check if at least one target is healthy
-		healthyCount := 0
-		for _, target := range healthResult.TargetHealthDescriptions {
-			if target.TargetHealth != nil && target.TargetHealth.State == types.TargetHealthStateEnumHealthy {
+		healthyCount, registeredCount := 0, 0
+		for _, target := range descriptions {
+			if target.TargetHealth == nil {
+				continue
+			}
+			switch target.TargetHealth.State {
+			case types.TargetHealthStateEnumDraining:
+				continue
+			case types.TargetHealthStateEnumHealthy:
 				healthyCount++
 			}
+			registeredCount++
 		}
 
 		if healthyCount == 0 {
 			return fmt.Errorf("no healthy targets in target group %s", tgArn)
 		}
+		if healthyCount*100 < minHealthyPercent*registeredCount {
+			return fmt.Errorf("only %d of %d targets healthy in target group %s, below the required %d%%",
+				healthyCount, registeredCount, tgArn, minHealthyPercent)
+		}
 
-		log.Printf("[ELB] Target group %s has %d healthy targets", tgArn, healthyCount)
+		util.Logf(ctx, "[ELB] Target group %s has %d/%d healthy targets", tgArn, healthyCount, registeredCount)
 	}
 
 	return nil
 }
+
+// describeTargetHealth returns the health of every target in a target group
+func (c *ELBClient) describeTargetHealth(ctx context.Context, tgArn string) ([]types.TargetHealthDescription, error) {
+	if c.mock {
+		metrics.RecordMockAWSCall(ctx, "elbv2", "DescribeTargetHealth")
+		return mockTargetHealth(c.mockHealthyTargets(tgArn), c.mockRegisteredTargets(tgArn)), nil
+	}
+
+	resp, err := c.client.DescribeTargetHealth(ctx, &elasticloadbalancingv2.DescribeTargetHealthInput{
+		TargetGroupArn: aws.String(tgArn),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return resp.TargetHealthDescriptions, nil
+}
+
+// mockHealthyTargets resolves the scripted healthy target count for a group
+func (c *ELBClient) mockHealthyTargets(tgArn string) int {
+	if count, ok := c.MockHealthyTargets[tgArn]; ok {
+		return count
+	}
+	if count, ok := c.MockHealthyTargets["*"]; ok {
+		return count
+	}
+	return defaultMockHealthyTargets
+}
+
+// mockRegisteredTargets resolves the scripted registered target count for a
+// group, or 0 when only its healthy count is scripted
+func (c *ELBClient) mockRegisteredTargets(tgArn string) int {
+	if count, ok := c.MockRegisteredTargets[tgArn]; ok {
+		return count
+	}
+	if _, ok := c.MockHealthyTargets[tgArn]; ok {
+		return 0
+	}
+	return c.MockRegisteredTargets["*"]
+}
+
+// mockTargetHealth builds the given number of healthy targets followed by
+// unhealthy ones up to registered, with at least one target failing its
+// health checks when none are healthy
+func mockTargetHealth(healthy, registered int) []types.TargetHealthDescription {
+	if registered < healthy {
+		registered = healthy
+	}
+	if registered == 0 {
+		registered = 1
+	}
+
+	descriptions := make([]types.TargetHealthDescription, registered)
+	for i := range descriptions {
+		health := &types.TargetHealth{State: types.TargetHealthStateEnumHealthy}
+		if i >= healthy {
+			health = &types.TargetHealth{
+				State:  types.TargetHealthStateEnumUnhealthy,
+				Reason: types.TargetHealthReasonEnumFailedHealthChecks,
+			}
+		}
+		descriptions[i] = types.TargetHealthDescription{
+			Target:       &types.TargetDescription{Id: aws.String(fmt.Sprintf("10.0.0.%d", i+1)), Port: aws.Int32(80)},
+			TargetHealth: health,
+		}
+	}
+	return descriptions
+}
+
+// parseMockHealthyTargets reads MOCK_HEALTHY_TARGETS: a bare count applies to
+// every target group, and comma-separated ARN=count pairs script single groups
+// (e.g. "0" or "arn:...:targetgroup/mock-canary/6d0ecf831eec9f09=0"). A count
+// written healthy/registered also scripts the group's total target count
+// (e.g. "2/10").
+func parseMockHealthyTargets(value string) (healthy, registered map[string]int) {
+	healthy, registered = make(map[string]int), make(map[string]int)
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		key, countStr := "*", entry
+		if i := strings.LastIndex(entry, "="); i >= 0 {
+			key, countStr = entry[:i], entry[i+1:]
+		}
+		healthyStr, registeredStr, hasTotal := strings.Cut(countStr, "/")
+		count, err := strconv.Atoi(healthyStr)
+		if err != nil || count < 0 {
+			log.Printf("[MOCK] Ignoring invalid MOCK_HEALTHY_TARGETS entry %q", entry)
+			continue
+		}
+		if hasTotal {
+			total, err := strconv.Atoi(registeredStr)
+			if err != nil || total < count {
+				log.Printf("[MOCK] Ignoring invalid MOCK_HEALTHY_TARGETS entry %q", entry)
+				continue
+			}
+			registered[key] = total
+		}
+		healthy[key] = count
+	}
+	return healthy, registered
+}
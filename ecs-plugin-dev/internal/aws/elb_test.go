@@ -0,0 +1,120 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/elasticloadbalancingv2/types"
+)
+
+func newMockELBClient(t *testing.T) *ELBClient {
+	t.Helper()
+	t.Setenv("MOCK_MODE", "true")
+	t.Setenv("MOCK_HEALTHY_TARGETS", "")
+	return NewELBClient()
+}
+
+func TestResolveListenerRulePriority(t *testing.T) {
+	occupied := map[int]string{
+		1: "arn:rule/one",
+		2: "arn:rule/two",
+		4: "arn:rule/four",
+	}
+
+	tests := []struct {
+		name      string
+		preferred int
+		want      int
+		wantErr   string
+	}{
+		{name: "lowest free priority", preferred: 0, want: 3},
+		{name: "free preferred priority", preferred: 10, want: 10},
+		{name: "occupied preferred priority", preferred: 2, wantErr: "already used by rule arn:rule/two"},
+		{name: "out of range", preferred: maxListenerRulePriority + 1, wantErr: "out of range"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newMockELBClient(t)
+			c.MockRulePriorities = occupied
+
+			got, err := c.ResolveListenerRulePriority(context.Background(), MockListenerARN, tt.preferred)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected priority %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestCreateHeaderRuleTakesAndReleasesPriority(t *testing.T) {
+	ctx := context.Background()
+	c := newMockELBClient(t)
+	c.MockRulePriorities = map[int]string{1: "arn:rule/one"}
+
+	ruleArn, err := c.CreateHeaderRule(ctx, MockListenerARN, "X-Canary", "true", MockCanaryTargetGroupARN, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if c.MockRulePriorities[2] != ruleArn {
+		t.Fatalf("expected the rule at the lowest free priority 2, got %v", c.MockRulePriorities)
+	}
+
+	// The priority the rule holds is no longer free
+	if _, err := c.CreateHeaderRule(ctx, MockListenerARN, "X-Canary", "true", MockCanaryTargetGroupARN, 2); err == nil {
+		t.Fatal("expected a second rule at priority 2 to be refused")
+	}
+
+	if err := c.DeleteListenerRule(ctx, ruleArn); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, err := c.ResolveListenerRulePriority(ctx, MockListenerARN, 0); err != nil || got != 2 {
+		t.Fatalf("expected priority 2 free after deleting the rule, got %d (%v)", got, err)
+	}
+}
+
+func TestResolveTargetGroupsByLoadBalancerType(t *testing.T) {
+	tests := []struct {
+		name    string
+		lbType  types.LoadBalancerTypeEnum
+		wantErr bool
+	}{
+		{name: "default", lbType: ""},
+		{name: "application", lbType: types.LoadBalancerTypeEnumApplication},
+		{name: "network", lbType: types.LoadBalancerTypeEnumNetwork, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newMockELBClient(t)
+			c.MockLoadBalancerType = tt.lbType
+
+			groups, err := c.ResolveTargetGroups(context.Background(), "cluster", "web", TrafficRouting{})
+			if tt.wantErr {
+				if !errors.Is(err, ErrWeightedRoutingUnsupported) {
+					t.Fatalf("expected weighted routing to be rejected, got %v", err)
+				}
+				if !strings.Contains(err.Error(), MockListenerARN) {
+					t.Errorf("expected the error to name the listener, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if groups.Canary != MockCanaryTargetGroupARN || groups.Primary != MockPrimaryTargetGroupARN {
+				t.Errorf("unexpected target groups %+v", groups)
+			}
+		})
+	}
+}
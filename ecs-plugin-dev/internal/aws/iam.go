@@ -4,7 +4,12 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"sort"
+	"strings"
 
+	"ecs-plugin-dev/internal/util"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/iam"
 	iamtypes "github.com/aws/aws-sdk-go-v2/service/iam/types"
 	"github.com/aws/aws-sdk-go-v2/service/sts"
@@ -34,29 +39,101 @@ func NewIAMClient() *IAMClient {
 	}
 }
 
-func (c *IAMClient) ValidatePermissions(ctx context.Context, requiredActions []string) error {
+// CheckConnectivity confirms AWS is reachable with the current credentials.
+// GetCallerIdentity needs no IAM permissions, so only network or credential
+// problems fail it.
+func (c *IAMClient) CheckConnectivity(ctx context.Context) error {
 	if c.mock {
-		log.Println("[IAM] Mock mode: skipping permission validation")
 		return nil
 	}
 
-	log.Printf("[IAM] Validating permissions for %d required actions", len(requiredActions))
+	if _, err := c.stsClient.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{}); err != nil {
+		return fmt.Errorf("failed to get caller identity: %w", err)
+	}
+	return nil
+}
+
+// ValidatePermissionsResult captures the policy simulator decision for each action
+type ValidatePermissionsResult struct {
+	CallerARN string
+	Allowed   []string
+	Denied    map[string]string // Action -> decision (implicitDeny or explicitDeny)
+}
+
+// ValidatePermissions simulates the caller's policies against requiredActions
+// and returns an error listing every action that would be denied
+func (c *IAMClient) ValidatePermissions(ctx context.Context, requiredActions []string) (*ValidatePermissionsResult, error) {
+	if c.mock {
+		util.Logf(ctx, "[IAM] Mock mode: skipping permission validation")
+		return &ValidatePermissionsResult{
+			Allowed: requiredActions,
+			Denied:  map[string]string{},
+		}, nil
+	}
+
+	util.Logf(ctx, "[IAM] Validating permissions for %d required actions", len(requiredActions))
 
 	// Get current identity
 	identity, err := c.stsClient.GetCallerIdentity(ctx, &sts.GetCallerIdentityInput{})
 	if err != nil {
-		return fmt.Errorf("failed to get caller identity: %w", err)
+		return nil, fmt.Errorf("failed to get caller identity: %w", err)
 	}
 
-	log.Printf("[IAM] Caller identity: %s (Account: %s)", *identity.Arn, *identity.Account)
+	util.Logf(ctx, "[IAM] Caller identity: %s (Account: %s)", *identity.Arn, *identity.Account)
 
-	// Check if user has required permissions
-	// Note: This is a simplified check. In production, you'd use IAM Policy Simulator
-	for _, action := range requiredActions {
-		log.Printf("[IAM] Checking permission: %s", action)
+	result := &ValidatePermissionsResult{
+		CallerARN: principalARN(*identity.Arn),
+		Denied:    map[string]string{},
 	}
 
-	return nil
+	paginator := iam.NewSimulatePrincipalPolicyPaginator(c.iamClient, &iam.SimulatePrincipalPolicyInput{
+		PolicySourceArn: aws.String(result.CallerARN),
+		ActionNames:     requiredActions,
+	})
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to simulate principal policy: %w", err)
+		}
+
+		for _, eval := range page.EvaluationResults {
+			action := aws.ToString(eval.EvalActionName)
+			if eval.EvalDecision == iamtypes.PolicyEvaluationDecisionTypeAllowed {
+				result.Allowed = append(result.Allowed, action)
+				continue
+			}
+			util.Logf(ctx, "[IAM] Permission denied: %s (%s)", action, eval.EvalDecision)
+			result.Denied[action] = string(eval.EvalDecision)
+		}
+	}
+
+	if len(result.Denied) > 0 {
+		denied := make([]string, 0, len(result.Denied))
+		for action, decision := range result.Denied {
+			denied = append(denied, fmt.Sprintf("%s (%s)", action, decision))
+		}
+		sort.Strings(denied)
+		return result, fmt.Errorf("insufficient permissions for %s: %s", result.CallerARN, strings.Join(denied, ", "))
+	}
+
+	util.Logf(ctx, "[IAM] All %d required actions allowed", len(result.Allowed))
+	return result, nil
+}
+
+// principalARN converts an assumed-role session ARN into the IAM role ARN the
+// policy simulator expects; other ARNs are returned unchanged
+func principalARN(callerARN string) string {
+	// arn:aws:sts::123456789012:assumed-role/RoleName/SessionName
+	parts := strings.SplitN(callerARN, ":", 6)
+	if len(parts) != 6 || parts[2] != "sts" || !strings.HasPrefix(parts[5], "assumed-role/") {
+		return callerARN
+	}
+
+	resource := strings.Split(parts[5], "/")
+	if len(resource) < 2 {
+		return callerARN
+	}
+	return fmt.Sprintf("arn:%s:iam::%s:role/%s", parts[1], parts[4], resource[1])
 }
 
 func (c *IAMClient) GetRequiredECSPermissions() []string {
@@ -67,33 +144,42 @@ func (c *IAMClient) GetRequiredECSPermissions() []string {
 		"ecs:UpdateService",
 		"ecs:CreateTaskSet",
 		"ecs:DeleteTaskSet",
+		"ecs:UpdateServicePrimaryTaskSet",
+		"ecs:ListTaskDefinitions",
+		"ecs:DeregisterTaskDefinition",
 		"elasticloadbalancing:DescribeTargetGroups",
 		"elasticloadbalancing:DescribeTargetHealth",
 		"elasticloadbalancing:DescribeListeners",
 		"elasticloadbalancing:DescribeLoadBalancers",
+		"elasticloadbalancing:DescribeTags",
+		"elasticloadbalancing:DescribeRules",
+		"elasticloadbalancing:CreateRule",
+		"elasticloadbalancing:DeleteRule",
 		"elasticloadbalancing:ModifyListener",
+		"cloudwatch:DescribeAlarms",
+		"cloudwatch:GetMetricData",
 	}
 }
 
 func (c *IAMClient) ValidateRole(ctx context.Context, roleArn string) error {
 	if c.mock {
-		log.Printf("[IAM] Mock mode: skipping role validation for %s", roleArn)
+		util.Logf(ctx, "[IAM] Mock mode: skipping role validation for %s", roleArn)
 		return nil
 	}
 
-	log.Printf("[IAM] Validating IAM role: %s", roleArn)
+	util.Logf(ctx, "[IAM] Validating IAM role: %s", roleArn)
 
 	// Extract role name from ARN
 	// ARN format: arn:aws:iam::account-id:role/role-name
 	// For simplicity, we'll just log the validation
-	log.Printf("[IAM] Role %s validated", roleArn)
+	util.Logf(ctx, "[IAM] Role %s validated", roleArn)
 
 	return nil
 }
 
 func (c *IAMClient) ListAttachedPolicies(ctx context.Context, roleName string) ([]iamtypes.AttachedPolicy, error) {
 	if c.mock {
-		log.Printf("[IAM] Mock mode: returning empty policy list for role %s", roleName)
+		util.Logf(ctx, "[IAM] Mock mode: returning empty policy list for role %s", roleName)
 		return []iamtypes.AttachedPolicy{}, nil
 	}
 
@@ -106,6 +192,6 @@ func (c *IAMClient) ListAttachedPolicies(ctx context.Context, roleName string) (
 		return nil, fmt.Errorf("failed to list attached policies: %w", err)
 	}
 
-	log.Printf("[IAM] Found %d attached policies for role %s", len(result.AttachedPolicies), roleName)
+	util.Logf(ctx, "[IAM] Found %d attached policies for role %s", len(result.AttachedPolicies), roleName)
 	return result.AttachedPolicies, nil
 }
@@ -0,0 +1,129 @@
+package aws
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+)
+
+// MockScenario scripts what the ECS client reports in mock mode, so drift,
+// unstable-service and first-deployment paths can be exercised without AWS.
+// Fields left out of a scenario file keep the defaults from
+// DefaultMockScenario.
+type MockScenario struct {
+	DesiredCount int32 `json:"desired_count"`
+	RunningCount int32 `json:"running_count"`
+	// RolloutState of the PRIMARY deployment: COMPLETED, IN_PROGRESS or FAILED
+	RolloutState       string `json:"rollout_state"`
+	RolloutStateReason string `json:"rollout_state_reason"`
+	// ActiveDeployments older deployments still draining next to PRIMARY,
+	// which keeps the service from counting as stable
+	ActiveDeployments int `json:"active_deployments"`
+	// TaskDefinition the service currently runs, as seen by drift detection
+	// and service snapshots; empty for a service never deployed
+	TaskDefinition string `json:"task_definition"`
+	// PreviousTaskDefinition is what rollbacks return to; an empty value means
+	// the service has no previous deployment
+	PreviousTaskDefinition string `json:"previous_task_definition"`
+	// TaskDefinitionRevisions is how many ACTIVE revisions every task
+	// definition family has, numbered 1 up to this
+	TaskDefinitionRevisions int `json:"task_definition_revisions"`
+	// PrimaryTaskSet is the ID of the service's PRIMARY task set; an empty
+	// value means the service has none
+	PrimaryTaskSet string `json:"primary_task_set"`
+}
+
+// DefaultMockScenario returns the responses mock mode gives unless scripted
+func DefaultMockScenario() *MockScenario {
+	return &MockScenario{
+		DesiredCount:           2,
+		RunningCount:           2,
+		RolloutState:           string(types.DeploymentRolloutStateCompleted),
+		TaskDefinition:         "arn:aws:ecs:us-east-1:123456789:task-definition/current:1",
+		PreviousTaskDefinition: "arn:aws:ecs:us-east-1:123456789:task-definition/previous:1",
+		// Mock registration always returns current:2
+		TaskDefinitionRevisions: 2,
+		PrimaryTaskSet:          "ecs-svc/mock-primary",
+	}
+}
+
+// LoadMockScenario reads a JSON scenario from path on top of the defaults
+func LoadMockScenario(path string) (*MockScenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mock scenario: %w", err)
+	}
+
+	scenario := DefaultMockScenario()
+	if err := json.Unmarshal(data, scenario); err != nil {
+		return nil, fmt.Errorf("failed to parse mock scenario %s: %w", path, err)
+	}
+	switch types.DeploymentRolloutState(scenario.RolloutState) {
+	case types.DeploymentRolloutStateCompleted, types.DeploymentRolloutStateInProgress, types.DeploymentRolloutStateFailed:
+	default:
+		return nil, fmt.Errorf("mock scenario %s: rollout_state %q must be COMPLETED, IN_PROGRESS or FAILED", path, scenario.RolloutState)
+	}
+	return scenario, nil
+}
+
+// mockScenarioFromEnv loads MOCK_SCENARIO_FILE, falling back to the defaults
+// when it is unset or unusable
+func mockScenarioFromEnv() *MockScenario {
+	path := os.Getenv("MOCK_SCENARIO_FILE")
+	if path == "" {
+		return DefaultMockScenario()
+	}
+
+	scenario, err := LoadMockScenario(path)
+	if err != nil {
+		log.Printf("[MOCK] Ignoring MOCK_SCENARIO_FILE: %v", err)
+		return DefaultMockScenario()
+	}
+	log.Printf("[MOCK] Loaded ECS mock scenario from %s", path)
+	return scenario
+}
+
+// service builds the DescribeServices response the scenario describes
+func (s *MockScenario) service(name string) *types.Service {
+	svc := &types.Service{
+		ServiceName:  aws.String(name),
+		Status:       aws.String("ACTIVE"),
+		DesiredCount: s.DesiredCount,
+		RunningCount: s.RunningCount,
+		Deployments: []types.Deployment{
+			{
+				Status:       aws.String("PRIMARY"),
+				RolloutState: types.DeploymentRolloutState(s.RolloutState),
+				RunningCount: s.RunningCount,
+				DesiredCount: s.DesiredCount,
+			},
+		},
+	}
+	if s.RolloutStateReason != "" {
+		svc.Deployments[0].RolloutStateReason = aws.String(s.RolloutStateReason)
+	}
+	if s.TaskDefinition != "" {
+		svc.TaskDefinition = aws.String(s.TaskDefinition)
+		svc.Deployments[0].TaskDefinition = aws.String(s.TaskDefinition)
+	}
+	for i := 0; i < s.ActiveDeployments; i++ {
+		svc.Deployments = append(svc.Deployments, types.Deployment{
+			Status:         aws.String("ACTIVE"),
+			TaskDefinition: aws.String(s.PreviousTaskDefinition),
+		})
+	}
+	return svc
+}
+
+// taskDefinitionRevisions lists family's scripted revisions, newest first
+func (s *MockScenario) taskDefinitionRevisions(family string) []string {
+	arns := make([]string, 0, s.TaskDefinitionRevisions)
+	for revision := s.TaskDefinitionRevisions; revision > 0; revision-- {
+		arns = append(arns, fmt.Sprintf("arn:aws:ecs:us-east-1:123456789:task-definition/%s:%d", family, revision))
+	}
+	return arns
+}
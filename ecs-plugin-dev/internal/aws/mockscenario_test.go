@@ -0,0 +1,108 @@
+package aws
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+)
+
+// writeScenario writes a scenario file and returns its path
+func writeScenario(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "scenario.json")
+	if err := os.WriteFile(path, []byte(contents), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestLoadMockScenario(t *testing.T) {
+	scenario, err := LoadMockScenario(writeScenario(t, `{
+		"running_count": 1,
+		"rollout_state": "IN_PROGRESS",
+		"active_deployments": 1,
+		"previous_task_definition": ""
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defaults := DefaultMockScenario()
+	if scenario.RunningCount != 1 || scenario.RolloutState != "IN_PROGRESS" || scenario.ActiveDeployments != 1 || scenario.PreviousTaskDefinition != "" {
+		t.Errorf("expected the scripted fields, got %+v", scenario)
+	}
+	if scenario.DesiredCount != defaults.DesiredCount || scenario.TaskDefinition != defaults.TaskDefinition {
+		t.Errorf("expected fields left out to keep their defaults, got %+v", scenario)
+	}
+}
+
+func TestLoadMockScenarioErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+		wantErr  string
+	}{
+		{name: "invalid JSON", contents: `{"desired_count": `, wantErr: "failed to parse mock scenario"},
+		{name: "unknown rollout state", contents: `{"rollout_state": "STUCK"}`, wantErr: `rollout_state "STUCK" must be COMPLETED, IN_PROGRESS or FAILED`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadMockScenario(writeScenario(t, tt.contents))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	if _, err := LoadMockScenario(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestMockScenarioDrivesECSClient(t *testing.T) {
+	t.Setenv("MOCK_MODE", "true")
+	t.Setenv("MOCK_SCENARIO_FILE", writeScenario(t, `{
+		"desired_count": 3,
+		"running_count": 2,
+		"rollout_state": "FAILED",
+		"rollout_state_reason": "tasks failed to start",
+		"active_deployments": 1,
+		"previous_task_definition": "",
+		"task_definition_revisions": 4
+	}`))
+	c := NewECSClient()
+	ctx := context.Background()
+
+	svc, err := c.DescribeService(ctx, "cluster", "web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if svc.DesiredCount != 3 || svc.RunningCount != 2 || len(svc.Deployments) != 2 {
+		t.Fatalf("expected the scripted service, got desired %d running %d with %d deployments", svc.DesiredCount, svc.RunningCount, len(svc.Deployments))
+	}
+	primary := svc.Deployments[0]
+	if primary.RolloutState != types.DeploymentRolloutStateFailed || primary.RolloutStateReason == nil || *primary.RolloutStateReason != "tasks failed to start" {
+		t.Errorf("expected the scripted rollout failure, got %+v", primary)
+	}
+
+	if _, err := c.GetPreviousTaskDefinition(ctx, "cluster", "web"); !errors.Is(err, ErrNoPreviousDeployment) {
+		t.Errorf("expected no previous deployment, got %v", err)
+	}
+	revisions := c.MockScenario.taskDefinitionRevisions("web")
+	if len(revisions) != 4 || !strings.HasSuffix(revisions[0], "task-definition/web:4") {
+		t.Errorf("expected four revisions, newest first, got %v", revisions)
+	}
+}
+
+func TestMockScenarioFallsBackToDefaults(t *testing.T) {
+	t.Setenv("MOCK_SCENARIO_FILE", writeScenario(t, `{"rollout_state": "STUCK"}`))
+	if scenario := mockScenarioFromEnv(); *scenario != *DefaultMockScenario() {
+		t.Errorf("expected an unusable scenario file to fall back to the defaults, got %+v", scenario)
+	}
+}
@@ -0,0 +1,30 @@
+package aws
+
+import (
+	"context"
+
+	"ecs-plugin-dev/internal/tracing"
+
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/smithy-go/middleware"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// traceAPICalls wraps every AWS API call, retries included, in a span named
+// after its service and operation. Service metadata is registered earlier in
+// the initialize step, so the middleware goes last.
+func traceAPICalls(stack *middleware.Stack) error {
+	return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("TraceAPICall",
+		func(ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler) (middleware.InitializeOutput, middleware.Metadata, error) {
+			service, operation := awsmiddleware.GetServiceID(ctx), awsmiddleware.GetOperationName(ctx)
+			ctx, span := tracing.Start(ctx, service+"."+operation,
+				attribute.String("rpc.system", "aws-api"),
+				attribute.String("rpc.service", service),
+				attribute.String("rpc.method", operation),
+				attribute.String("cloud.region", awsmiddleware.GetRegion(ctx)),
+			)
+			out, metadata, err := next.HandleInitialize(ctx, in)
+			tracing.End(span, err)
+			return out, metadata, err
+		}), middleware.After)
+}
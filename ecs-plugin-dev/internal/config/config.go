@@ -1,8 +1,10 @@
 package config
 
 import (
+	"log"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
@@ -10,10 +12,12 @@ import (
 
 // Config holds all application configuration
 type Config struct {
-	Server   ServerConfig   `yaml:"server"`
-	AWS      AWSConfig      `yaml:"aws"`
-	Strategy StrategyConfig `yaml:"strategy"`
-	Hooks    HooksConfig    `yaml:"hooks"`
+	Server     ServerConfig     `yaml:"server"`
+	AWS        AWSConfig        `yaml:"aws"`
+	Strategy   StrategyConfig   `yaml:"strategy"`
+	Hooks      HooksConfig      `yaml:"hooks"`
+	Deployment DeploymentConfig `yaml:"deployment"`
+	Audit      AuditConfig      `yaml:"audit"`
 }
 
 // ServerConfig holds server configuration
@@ -22,6 +26,43 @@ type ServerConfig struct {
 	GracefulTimeout time.Duration `yaml:"graceful_timeout"`
 	EnableMetrics   bool          `yaml:"enable_metrics"`
 	MetricsPort     int           `yaml:"metrics_port"`
+	EnableTracing   bool          `yaml:"enable_tracing"`
+
+	// AuthTokens maps principal names to the bearer tokens or API keys they
+	// authenticate with; every RPC requires one when any are configured.
+	// AuthExemptMethods bypass authentication; entries ending in "/" match a
+	// whole service.
+	AuthTokens        map[string]string `yaml:"auth_tokens"`
+	AuthExemptMethods []string          `yaml:"auth_exempt_methods"`
+
+	RateLimit RateLimitConfig `yaml:"rate_limit"`
+	// RequestTimeout caps the deadline of each unary RPC; 0 leaves client
+	// deadlines unbounded. Deployments run detached from the Deploy call, so
+	// it never cuts one short.
+	RequestTimeout time.Duration `yaml:"request_timeout"`
+	// HealthProbeInterval is how often the server checks it can reach AWS,
+	// reporting NOT_SERVING on the gRPC health service while it cannot; 0
+	// disables the probe
+	HealthProbeInterval time.Duration `yaml:"health_probe_interval"`
+	// StatusErrors fails unary RPCs with a gRPC status code and error details
+	// instead of returning a success=false response. The modes cannot be
+	// combined: failing the call drops the response body, while a
+	// success=false response completes with codes.OK and carries its code
+	// only in the x-grpc-code trailer.
+	StatusErrors bool `yaml:"status_errors"`
+	// Gateway serves Deploy, GetStatus, Rollback and Cancel as HTTP/JSON on
+	// the metrics server
+	Gateway bool `yaml:"gateway"`
+}
+
+// RateLimitConfig throttles unary RPCs with a token bucket
+type RateLimitConfig struct {
+	// RPS is the sustained requests per second allowed; 0 disables limiting
+	RPS float64 `yaml:"rps"`
+	// Burst is how many requests may arrive at once above the sustained rate
+	Burst int `yaml:"burst"`
+	// PerMethod gives every RPC method its own bucket instead of one shared bucket
+	PerMethod bool `yaml:"per_method"`
 }
 
 // AWSConfig holds AWS client configuration
@@ -30,6 +71,17 @@ type AWSConfig struct {
 	MaxRetries    int           `yaml:"max_retries"`
 	RetryDelay    time.Duration `yaml:"retry_delay"`
 	MaxRetryDelay time.Duration `yaml:"max_retry_delay"`
+	// RetryJitter randomizes retry delays: none (the default), full or equal
+	RetryJitter string `yaml:"retry_jitter"`
+	// After CircuitBreakerThreshold consecutive ECS or ELB calls fail because
+	// AWS is unreachable, throttling or erroring, further calls to that
+	// service fail fast for CircuitBreakerCooldown; 0 disables the breaker
+	CircuitBreakerThreshold int           `yaml:"circuit_breaker_threshold"`
+	CircuitBreakerCooldown  time.Duration `yaml:"circuit_breaker_cooldown"`
+	// ValidatePermissions checks at startup that the credentials are allowed
+	// every AWS action deployments use: off, warn (log denied actions) or
+	// fail (refuse to start)
+	ValidatePermissions string `yaml:"validate_permissions"`
 }
 
 // StrategyConfig holds strategy configuration
@@ -43,6 +95,24 @@ type StrategyConfig struct {
 type CanaryConfig struct {
 	Stages       []int         `yaml:"stages"`
 	StageTimeout time.Duration `yaml:"stage_timeout"`
+
+	Analysis CanaryAnalysisConfig `yaml:"analysis"`
+}
+
+// CanaryAnalysisConfig holds the thresholds a canary's target group metrics
+// are held to against the primary's after each stage
+type CanaryAnalysisConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// MaxErrorRateIncrease is the percentage the canary error rate may exceed
+	// the baseline error rate by
+	MaxErrorRateIncrease float64 `yaml:"max_error_rate_increase"`
+	// MaxLatencyIncrease is the percentage the canary's average response time
+	// may exceed the baseline's by
+	MaxLatencyIncrease float64 `yaml:"max_latency_increase"`
+	// MinRequests each target group must serve in a stage for a verdict
+	MinRequests int `yaml:"min_requests"`
+	// Confidence an error rate increase must be significant at (0.5-1)
+	Confidence float64 `yaml:"confidence"`
 }
 
 // BlueGreenConfig holds blue-green strategy configuration
@@ -53,8 +123,101 @@ type BlueGreenConfig struct {
 
 // HooksConfig holds deployment hooks configuration
 type HooksConfig struct {
+	// PreDeploy and PostDeploy are shell commands run as hooks, registered as
+	// "exec:<command>"; the deployment is passed in DEPLOYMENT_ID, CLUSTER and SERVICE
 	PreDeploy  []string `yaml:"pre_deploy"`
 	PostDeploy []string `yaml:"post_deploy"`
+	// CommandTimeout kills a pre/post-deploy command that runs longer (no limit when zero)
+	CommandTimeout time.Duration `yaml:"command_timeout"`
+	// DependencyChecks lists HTTP endpoints of dependent services that must
+	// report healthy before a deployment starts
+	DependencyChecks  []string      `yaml:"dependency_checks"`
+	DependencyTimeout time.Duration `yaml:"dependency_timeout"`
+	// BestEffort names registered hooks whose failures are logged and audited
+	// without failing the deployment
+	BestEffort []string `yaml:"best_effort"`
+	// NotificationURL receives a JSON notification after each successful
+	// deployment when set; failures only fail the deployment when
+	// NotificationRequired is true
+	NotificationURL      string        `yaml:"notification_url"`
+	NotificationRequired bool          `yaml:"notification_required"`
+	NotificationTimeout  time.Duration `yaml:"notification_timeout"`
+
+	// HealthCheckURL is polled by the post-deploy health-check hook when set;
+	// {service} and {cluster} are replaced with the deployment's values
+	HealthCheckURL            string        `yaml:"health_check_url"`
+	HealthCheckExpectedStatus int           `yaml:"health_check_expected_status"`
+	HealthCheckTimeout        time.Duration `yaml:"health_check_timeout"`
+	HealthCheckRetries        int           `yaml:"health_check_retries"`
+	HealthCheckRetryInterval  time.Duration `yaml:"health_check_retry_interval"`
+
+	// EventWebhookURLs each receive a JSON event on every deployment status
+	// transition, posted in order off the deployment goroutine
+	EventWebhookURLs    []string      `yaml:"event_webhook_urls"`
+	EventWebhookTimeout time.Duration `yaml:"event_webhook_timeout"`
+	// EventBuffer is how many undelivered events a subscriber may fall
+	// behind by before further events to it are dropped
+	EventBuffer int `yaml:"event_buffer"`
+	// AuditTransitions records every status transition in the audit log as
+	// deployment.transition
+	AuditTransitions bool `yaml:"audit_transitions"`
+}
+
+// DeploymentConfig holds deployment routing configuration
+type DeploymentConfig struct {
+	// DedupWindow enables content-hash deduplication of identical requests
+	// when greater than zero
+	DedupWindow time.Duration `yaml:"dedup_window"`
+	// StatusDir persists deployment statuses as JSON files when set;
+	// statuses are kept in memory only when empty
+	StatusDir string `yaml:"status_dir"`
+	// ManifestDir receives a JSON manifest for every finished deployment when set
+	ManifestDir string `yaml:"manifest_dir"`
+	// AnalysisTrimFraction is the share of durations dropped from each end of
+	// the trimmed average; AnalysisSlowestCap excludes longer deployments from
+	// the reported slowest duration when greater than zero
+	AnalysisTrimFraction float64       `yaml:"analysis_trim_fraction"`
+	AnalysisSlowestCap   time.Duration `yaml:"analysis_slowest_cap"`
+
+	// ApprovalStrategies lists strategies whose deployments always wait for
+	// approval before running
+	ApprovalStrategies []string `yaml:"approval_strategies"`
+	// Finished deployments are pruned from memory (and status_dir) once they
+	// ended more than StatusRetention ago, or beyond the newest MaxStatuses;
+	// zero disables either limit. RecentStatusCache pruned statuses remain
+	// queryable through GetStatus.
+	StatusRetention   time.Duration `yaml:"status_retention"`
+	MaxStatuses       int           `yaml:"max_statuses"`
+	RecentStatusCache int           `yaml:"recent_status_cache"`
+
+	// MaxConcurrent caps deployments running at once across all clusters and
+	// MaxConcurrentPerCluster caps them per cluster ARN; 0 is unlimited
+	MaxConcurrent           int `yaml:"max_concurrent"`
+	MaxConcurrentPerCluster int `yaml:"max_concurrent_per_cluster"`
+
+	// ConcurrentPolicy decides what happens to a deploy for a service that
+	// already has one running: "reject" it, or "queue" it (FIFO, at most
+	// MaxQueueDepth waiting per service) until the running one finishes
+	ConcurrentPolicy string `yaml:"concurrent_policy"`
+	MaxQueueDepth    int    `yaml:"max_queue_depth"`
+
+	// ServiceLockTTL expires a service's lock once the deployment holding it
+	// has not heartbeated for this long, so a deployment that ended without
+	// releasing its service does not lock it forever; 0 never expires locks
+	ServiceLockTTL time.Duration `yaml:"service_lock_ttl"`
+
+	// DeregisterOldRevisions deregisters the task definition family's ACTIVE
+	// revisions beyond the newest KeepRevisions after each successful deploy
+	DeregisterOldRevisions bool `yaml:"deregister_old_revisions"`
+	KeepRevisions          int  `yaml:"keep_revisions"`
+}
+
+// AuditConfig holds audit log configuration
+type AuditConfig struct {
+	// MaxFileBytes rotates the audit log once it would exceed this size; 0 disables rotation
+	MaxFileBytes int64 `yaml:"max_file_bytes"`
+	// MaxBackups is how many rotated audit logs to keep; 0 keeps all
+	MaxBackups int `yaml:"max_backups"`
 }
 
 // LoadConfig loads configuration from file or defaults
@@ -75,6 +238,9 @@ func LoadConfig(path string) (*Config, error) {
 	// Override with environment variables
 	cfg.ApplyEnvOverrides()
 
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
 	return cfg, nil
 }
 
@@ -86,17 +252,38 @@ func DefaultConfig() *Config {
 			GracefulTimeout: 30 * time.Second,
 			EnableMetrics:   true,
 			MetricsPort:     9090,
+			RequestTimeout:  time.Minute,
+
+			HealthProbeInterval: 30 * time.Second,
+
+			AuthExemptMethods: []string{
+				"/grpc.reflection.v1.ServerReflection/",
+				"/grpc.reflection.v1alpha.ServerReflection/",
+				"/grpc.health.v1.Health/",
+			},
 		},
 		AWS: AWSConfig{
 			Timeout:       30 * time.Second,
 			MaxRetries:    3,
 			RetryDelay:    time.Second,
 			MaxRetryDelay: 30 * time.Second,
+			RetryJitter:   "none",
+
+			CircuitBreakerThreshold: 5,
+			CircuitBreakerCooldown:  30 * time.Second,
+
+			ValidatePermissions: "warn",
 		},
 		Strategy: StrategyConfig{
 			Canary: CanaryConfig{
 				Stages:       []int{20, 50, 100},
 				StageTimeout: 2 * time.Minute,
+				Analysis: CanaryAnalysisConfig{
+					MaxErrorRateIncrease: 10,
+					MaxLatencyIncrease:   20,
+					MinRequests:          100,
+					Confidence:           0.95,
+				},
 			},
 			BlueGreen: BlueGreenConfig{
 				StabilizationTime: 30 * time.Second,
@@ -105,29 +292,160 @@ func DefaultConfig() *Config {
 			Timeout: 10 * time.Minute,
 		},
 		Hooks: HooksConfig{
-			PreDeploy:  []string{},
-			PostDeploy: []string{},
+			PreDeploy:           []string{},
+			PostDeploy:          []string{},
+			CommandTimeout:      5 * time.Minute,
+			DependencyChecks:    []string{},
+			DependencyTimeout:   5 * time.Second,
+			BestEffort:          []string{},
+			NotificationTimeout: 5 * time.Second,
+
+			HealthCheckExpectedStatus: 200,
+			HealthCheckTimeout:        5 * time.Second,
+			HealthCheckRetries:        3,
+			HealthCheckRetryInterval:  5 * time.Second,
+
+			EventWebhookURLs:    []string{},
+			EventWebhookTimeout: 5 * time.Second,
+			EventBuffer:         100,
+			AuditTransitions:    true,
+		},
+		Deployment: DeploymentConfig{
+			DedupWindow:          0,
+			AnalysisTrimFraction: 0.1,
+			StatusRetention:      24 * time.Hour,
+			RecentStatusCache:    100,
+			ConcurrentPolicy:     "reject",
+			MaxQueueDepth:        10,
+			ServiceLockTTL:       5 * time.Minute,
+			KeepRevisions:        10,
+		},
+		Audit: AuditConfig{
+			MaxFileBytes: 100 * 1024 * 1024,
+			MaxBackups:   10,
 		},
 	}
 }
 
-// ApplyEnvOverrides applies environment variable overrides
+// ApplyEnvOverrides applies environment variable overrides. Malformed values
+// are logged and the existing setting is kept.
 func (c *Config) ApplyEnvOverrides() {
-	if port := os.Getenv("GRPC_PORT"); port != "" {
-		if p, err := strconv.Atoi(port); err == nil {
-			c.Server.Port = p
-		}
+	envInt("GRPC_PORT", &c.Server.Port)
+	envInt("METRICS_PORT", &c.Server.MetricsPort)
+	envDuration("GRACEFUL_TIMEOUT", &c.Server.GracefulTimeout)
+	envBool("ENABLE_METRICS", &c.Server.EnableMetrics)
+	envDuration("REQUEST_TIMEOUT", &c.Server.RequestTimeout)
+	envDuration("HEALTH_PROBE_INTERVAL", &c.Server.HealthProbeInterval)
+	envBool("STATUS_ERRORS", &c.Server.StatusErrors)
+	envBool("ENABLE_GATEWAY", &c.Server.Gateway)
+	envTokens("AUTH_TOKENS", &c.Server.AuthTokens)
+
+	if webhookURL := os.Getenv("NOTIFICATION_WEBHOOK_URL"); webhookURL != "" {
+		c.Hooks.NotificationURL = webhookURL
+	}
+
+	if urls := os.Getenv("EVENT_WEBHOOK_URLS"); urls != "" {
+		c.Hooks.EventWebhookURLs = strings.Split(urls, ",")
+	}
+	envBool("AUDIT_TRANSITIONS", &c.Hooks.AuditTransitions)
+
+	if statusDir := os.Getenv("STATUS_DIR"); statusDir != "" {
+		c.Deployment.StatusDir = statusDir
+	}
+
+	envDuration("AWS_TIMEOUT", &c.AWS.Timeout)
+	envInt("AWS_MAX_RETRIES", &c.AWS.MaxRetries)
+	envDuration("AWS_RETRY_DELAY", &c.AWS.RetryDelay)
+	envInt("AWS_CIRCUIT_BREAKER_THRESHOLD", &c.AWS.CircuitBreakerThreshold)
+	envDuration("AWS_CIRCUIT_BREAKER_COOLDOWN", &c.AWS.CircuitBreakerCooldown)
+	if mode := os.Getenv("AWS_VALIDATE_PERMISSIONS"); mode != "" {
+		c.AWS.ValidatePermissions = mode
 	}
 
-	if metricsPort := os.Getenv("METRICS_PORT"); metricsPort != "" {
-		if p, err := strconv.Atoi(metricsPort); err == nil {
-			c.Server.MetricsPort = p
+	envDuration("STRATEGY_TIMEOUT", &c.Strategy.Timeout)
+	envInts("CANARY_STAGES", &c.Strategy.Canary.Stages)
+
+	envBool("DEREGISTER_OLD_REVISIONS", &c.Deployment.DeregisterOldRevisions)
+	envInt("KEEP_REVISIONS", &c.Deployment.KeepRevisions)
+	envDuration("SERVICE_LOCK_TTL", &c.Deployment.ServiceLockTTL)
+	envBool("CANARY_ANALYSIS", &c.Strategy.Canary.Analysis.Enabled)
+}
+
+// envInt overrides target with an integer environment variable
+func envInt(name string, target *int) {
+	value := os.Getenv(name)
+	if value == "" {
+		return
+	}
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil {
+		log.Printf("[CONFIG] Warning: ignoring %s=%q: not an integer", name, value)
+		return
+	}
+	*target = n
+}
+
+// envDuration overrides target with a duration environment variable such as "30s"
+func envDuration(name string, target *time.Duration) {
+	value := os.Getenv(name)
+	if value == "" {
+		return
+	}
+	d, err := time.ParseDuration(strings.TrimSpace(value))
+	if err != nil {
+		log.Printf("[CONFIG] Warning: ignoring %s=%q: not a duration (e.g. 30s, 5m)", name, value)
+		return
+	}
+	*target = d
+}
+
+// envBool overrides target with a boolean environment variable
+func envBool(name string, target *bool) {
+	value := os.Getenv(name)
+	if value == "" {
+		return
+	}
+	b, err := strconv.ParseBool(strings.TrimSpace(value))
+	if err != nil {
+		log.Printf("[CONFIG] Warning: ignoring %s=%q: not a boolean", name, value)
+		return
+	}
+	*target = b
+}
+
+// envTokens replaces target with comma-separated name:token pairs
+func envTokens(name string, target *map[string]string) {
+	value := os.Getenv(name)
+	if value == "" {
+		return
+	}
+	tokens := make(map[string]string)
+	for _, entry := range strings.Split(value, ",") {
+		principal, token, ok := strings.Cut(strings.TrimSpace(entry), ":")
+		if !ok || principal == "" || token == "" {
+			log.Printf("[CONFIG] Warning: ignoring %s: entries must be name:token", name)
+			return
 		}
+		tokens[principal] = token
 	}
+	*target = tokens
+}
 
-	if timeout := os.Getenv("AWS_TIMEOUT"); timeout != "" {
-		if t, err := time.ParseDuration(timeout); err == nil {
-			c.AWS.Timeout = t
+// envInts overrides target with a comma-separated list of integers
+func envInts(name string, target *[]int) {
+	value := os.Getenv(name)
+	if value == "" {
+		return
+	}
+	parts := strings.Split(value, ",")
+	values := make([]int, 0, len(parts))
+	for _, part := range parts {
+		n, err := strconv.Atoi(strings.TrimSpace(part))
+		if err != nil {
+			log.Printf("[CONFIG] Warning: ignoring %s=%q: %q is not an integer", name, value, part)
+			return
 		}
+		values = append(values, n)
 	}
+	*target = values
 }
@@ -0,0 +1,132 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Validate checks the configuration for values that would only fail at
+// runtime, reporting every problem found
+func (c *Config) Validate() error {
+	var problems []string
+	check := func(ok bool, format string, args ...interface{}) {
+		if !ok {
+			problems = append(problems, fmt.Sprintf(format, args...))
+		}
+	}
+
+	check(validPort(c.Server.Port), "server.port %d must be between 1 and 65535", c.Server.Port)
+	if c.Server.EnableMetrics {
+		check(validPort(c.Server.MetricsPort), "server.metrics_port %d must be between 1 and 65535", c.Server.MetricsPort)
+		check(c.Server.MetricsPort != c.Server.Port, "server.metrics_port must differ from server.port (both %d)", c.Server.Port)
+	}
+	for principal, token := range c.Server.AuthTokens {
+		check(token != "", "server.auth_tokens: %s has an empty token", principal)
+	}
+	check(c.Server.RateLimit.RPS >= 0, "server.rate_limit.rps must not be negative, got %v", c.Server.RateLimit.RPS)
+	if c.Server.RateLimit.RPS > 0 {
+		check(c.Server.RateLimit.Burst > 0, "server.rate_limit.burst must be positive when rps is set, got %d", c.Server.RateLimit.Burst)
+	}
+	check(c.Server.RequestTimeout >= 0, "server.request_timeout must not be negative, got %v", c.Server.RequestTimeout)
+	check(c.Server.HealthProbeInterval >= 0, "server.health_probe_interval must not be negative, got %v", c.Server.HealthProbeInterval)
+	check(!c.Server.Gateway || c.Server.EnableMetrics, "server.gateway requires server.enable_metrics, whose HTTP server it runs on")
+	check(c.Server.GracefulTimeout > 0, "server.graceful_timeout must be positive, got %v", c.Server.GracefulTimeout)
+
+	check(c.AWS.Timeout > 0, "aws.timeout must be positive, got %v", c.AWS.Timeout)
+	check(c.AWS.MaxRetries >= 0, "aws.max_retries must not be negative, got %d", c.AWS.MaxRetries)
+	check(c.AWS.RetryDelay >= 0, "aws.retry_delay must not be negative, got %v", c.AWS.RetryDelay)
+	check(c.AWS.MaxRetryDelay >= c.AWS.RetryDelay, "aws.max_retry_delay %v must be at least aws.retry_delay %v", c.AWS.MaxRetryDelay, c.AWS.RetryDelay)
+	check(c.AWS.CircuitBreakerThreshold >= 0, "aws.circuit_breaker_threshold must not be negative, got %d", c.AWS.CircuitBreakerThreshold)
+	if c.AWS.CircuitBreakerThreshold > 0 {
+		check(c.AWS.CircuitBreakerCooldown > 0, "aws.circuit_breaker_cooldown must be positive, got %v", c.AWS.CircuitBreakerCooldown)
+	}
+	switch c.AWS.RetryJitter {
+	case "", "none", "full", "equal":
+	default:
+		check(false, "aws.retry_jitter %q must be none, full or equal", c.AWS.RetryJitter)
+	}
+	switch c.AWS.ValidatePermissions {
+	case "", "off", "warn", "fail":
+	default:
+		check(false, "aws.validate_permissions %q must be off, warn or fail", c.AWS.ValidatePermissions)
+	}
+
+	check(c.Strategy.Timeout > 0, "strategy.timeout must be positive, got %v", c.Strategy.Timeout)
+	if err := validateCanaryStages(c.Strategy.Canary.Stages); err != nil {
+		check(false, "strategy.canary.stages: %v", err)
+	}
+	check(c.Strategy.Canary.StageTimeout > 0, "strategy.canary.stage_timeout must be positive, got %v", c.Strategy.Canary.StageTimeout)
+	if analysis := c.Strategy.Canary.Analysis; analysis.Enabled {
+		check(analysis.MaxErrorRateIncrease >= 0, "strategy.canary.analysis.max_error_rate_increase must not be negative, got %v", analysis.MaxErrorRateIncrease)
+		check(analysis.MaxLatencyIncrease >= 0, "strategy.canary.analysis.max_latency_increase must not be negative, got %v", analysis.MaxLatencyIncrease)
+		check(analysis.MinRequests >= 0, "strategy.canary.analysis.min_requests must not be negative, got %d", analysis.MinRequests)
+		check(analysis.Confidence >= 0.5 && analysis.Confidence < 1, "strategy.canary.analysis.confidence must be at least 0.5 and below 1, got %v", analysis.Confidence)
+	}
+	check(c.Strategy.BlueGreen.StabilizationTime > 0, "strategy.bluegreen.stabilization_time must be positive, got %v", c.Strategy.BlueGreen.StabilizationTime)
+	check(c.Strategy.BlueGreen.CleanupDelay >= 0, "strategy.bluegreen.cleanup_delay must not be negative, got %v", c.Strategy.BlueGreen.CleanupDelay)
+
+	check(c.Hooks.CommandTimeout >= 0, "hooks.command_timeout must not be negative, got %v", c.Hooks.CommandTimeout)
+	check(c.Hooks.DependencyTimeout > 0, "hooks.dependency_timeout must be positive, got %v", c.Hooks.DependencyTimeout)
+	check(c.Hooks.NotificationTimeout > 0, "hooks.notification_timeout must be positive, got %v", c.Hooks.NotificationTimeout)
+	check(c.Hooks.EventWebhookTimeout > 0, "hooks.event_webhook_timeout must be positive, got %v", c.Hooks.EventWebhookTimeout)
+	check(c.Hooks.EventBuffer > 0, "hooks.event_buffer must be positive, got %d", c.Hooks.EventBuffer)
+	for _, url := range c.Hooks.EventWebhookURLs {
+		check(strings.HasPrefix(url, "http://") || strings.HasPrefix(url, "https://"), "hooks.event_webhook_urls entry %q must be an http or https URL", url)
+	}
+	if c.Hooks.HealthCheckURL != "" {
+		check(c.Hooks.HealthCheckExpectedStatus >= 100 && c.Hooks.HealthCheckExpectedStatus <= 599,
+			"hooks.health_check_expected_status %d is not an HTTP status", c.Hooks.HealthCheckExpectedStatus)
+		check(c.Hooks.HealthCheckTimeout > 0, "hooks.health_check_timeout must be positive, got %v", c.Hooks.HealthCheckTimeout)
+		check(c.Hooks.HealthCheckRetries >= 0, "hooks.health_check_retries must not be negative, got %d", c.Hooks.HealthCheckRetries)
+		check(c.Hooks.HealthCheckRetryInterval >= 0, "hooks.health_check_retry_interval must not be negative, got %v", c.Hooks.HealthCheckRetryInterval)
+	}
+
+	check(c.Deployment.DedupWindow >= 0, "deployment.dedup_window must not be negative, got %v", c.Deployment.DedupWindow)
+	check(c.Deployment.AnalysisTrimFraction >= 0 && c.Deployment.AnalysisTrimFraction < 0.5,
+		"deployment.analysis_trim_fraction %v must be at least 0 and below 0.5", c.Deployment.AnalysisTrimFraction)
+	check(c.Deployment.AnalysisSlowestCap >= 0, "deployment.analysis_slowest_cap must not be negative, got %v", c.Deployment.AnalysisSlowestCap)
+	check(c.Deployment.StatusRetention >= 0, "deployment.status_retention must not be negative, got %v", c.Deployment.StatusRetention)
+	check(c.Deployment.MaxStatuses >= 0, "deployment.max_statuses must not be negative, got %d", c.Deployment.MaxStatuses)
+	check(c.Deployment.RecentStatusCache >= 0, "deployment.recent_status_cache must not be negative, got %d", c.Deployment.RecentStatusCache)
+	check(c.Deployment.MaxConcurrent >= 0, "deployment.max_concurrent must not be negative, got %d", c.Deployment.MaxConcurrent)
+	check(c.Deployment.MaxConcurrentPerCluster >= 0, "deployment.max_concurrent_per_cluster must not be negative, got %d", c.Deployment.MaxConcurrentPerCluster)
+	check(c.Deployment.ConcurrentPolicy == "reject" || c.Deployment.ConcurrentPolicy == "queue",
+		"deployment.concurrent_policy must be reject or queue, got %q", c.Deployment.ConcurrentPolicy)
+	check(c.Deployment.MaxQueueDepth >= 0, "deployment.max_queue_depth must not be negative, got %d", c.Deployment.MaxQueueDepth)
+	check(c.Deployment.ServiceLockTTL == 0 || c.Deployment.ServiceLockTTL >= time.Second,
+		"deployment.service_lock_ttl must be 0 or at least 1s, got %v", c.Deployment.ServiceLockTTL)
+	check(c.Deployment.KeepRevisions > 0, "deployment.keep_revisions must be positive, got %d", c.Deployment.KeepRevisions)
+
+	check(c.Audit.MaxFileBytes >= 0, "audit.max_file_bytes must not be negative, got %d", c.Audit.MaxFileBytes)
+	check(c.Audit.MaxBackups >= 0, "audit.max_backups must not be negative, got %d", c.Audit.MaxBackups)
+
+	if len(problems) > 0 {
+		return fmt.Errorf("invalid config: %s", strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func validPort(port int) bool {
+	return port > 0 && port <= 65535
+}
+
+// validateCanaryStages requires strictly increasing percentages between 1
+// and 100 that end at 100
+func validateCanaryStages(stages []int) error {
+	if len(stages) == 0 {
+		return fmt.Errorf("at least one stage is required")
+	}
+	for i, percent := range stages {
+		if percent <= 0 || percent > 100 {
+			return fmt.Errorf("stage %d%% is outside 1-100", percent)
+		}
+		if i > 0 && percent <= stages[i-1] {
+			return fmt.Errorf("stages must be strictly increasing, got %d%% after %d%%", percent, stages[i-1])
+		}
+	}
+	if last := stages[len(stages)-1]; last != 100 {
+		return fmt.Errorf("final stage must be 100%%, got %d%%", last)
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(c *Config)
+		wantErr string
+	}{
+		{name: "defaults", mutate: func(c *Config) {}},
+		{name: "highest port", mutate: func(c *Config) { c.Server.Port = 65535 }},
+		{name: "port zero", mutate: func(c *Config) { c.Server.Port = 0 }, wantErr: "server.port 0 must be between 1 and 65535"},
+		{name: "port too high", mutate: func(c *Config) { c.Server.Port = 65536 }, wantErr: "server.port 65536"},
+		{name: "metrics port clash", mutate: func(c *Config) { c.Server.MetricsPort = c.Server.Port }, wantErr: "server.metrics_port must differ"},
+		{name: "metrics port clash with metrics off", mutate: func(c *Config) {
+			c.Server.EnableMetrics = false
+			c.Server.MetricsPort = c.Server.Port
+		}},
+		{name: "gateway without metrics", mutate: func(c *Config) {
+			c.Server.EnableMetrics = false
+			c.Server.Gateway = true
+		}, wantErr: "server.gateway requires server.enable_metrics"},
+		{name: "empty auth token", mutate: func(c *Config) { c.Server.AuthTokens = map[string]string{"ci": ""} }, wantErr: "ci has an empty token"},
+		{name: "rate limit without burst", mutate: func(c *Config) {
+			c.Server.RateLimit.RPS = 10
+			c.Server.RateLimit.Burst = 0
+		}, wantErr: "server.rate_limit.burst must be positive"},
+		{name: "max retry delay below retry delay", mutate: func(c *Config) {
+			c.AWS.RetryDelay = time.Second
+			c.AWS.MaxRetryDelay = time.Millisecond
+		}, wantErr: "aws.max_retry_delay"},
+		{name: "unknown jitter", mutate: func(c *Config) { c.AWS.RetryJitter = "random" }, wantErr: `aws.retry_jitter "random"`},
+		{name: "unknown permission check mode", mutate: func(c *Config) { c.AWS.ValidatePermissions = "strict" }, wantErr: `aws.validate_permissions "strict"`},
+		{name: "permission check off", mutate: func(c *Config) { c.AWS.ValidatePermissions = "off" }},
+		{name: "no canary stages", mutate: func(c *Config) { c.Strategy.Canary.Stages = nil }, wantErr: "at least one stage is required"},
+		{name: "canary stages out of order", mutate: func(c *Config) { c.Strategy.Canary.Stages = []int{50, 20, 100} }, wantErr: "strictly increasing"},
+		{name: "canary stages short of 100", mutate: func(c *Config) { c.Strategy.Canary.Stages = []int{10, 50} }, wantErr: "final stage must be 100%"},
+		{name: "canary stage over 100", mutate: func(c *Config) { c.Strategy.Canary.Stages = []int{50, 150} }, wantErr: "stage 150% is outside 1-100"},
+		{name: "single full canary stage", mutate: func(c *Config) { c.Strategy.Canary.Stages = []int{100} }},
+		{name: "analysis confidence of 1", mutate: func(c *Config) {
+			c.Strategy.Canary.Analysis.Enabled = true
+			c.Strategy.Canary.Analysis.Confidence = 1
+		}, wantErr: "confidence must be at least 0.5 and below 1"},
+		{name: "analysis confidence ignored when disabled", mutate: func(c *Config) {
+			c.Strategy.Canary.Analysis.Enabled = false
+			c.Strategy.Canary.Analysis.Confidence = 1
+		}},
+		{name: "non-http event webhook", mutate: func(c *Config) { c.Hooks.EventWebhookURLs = []string{"ftp://example.com"} }, wantErr: "must be an http or https URL"},
+		{name: "health check status", mutate: func(c *Config) {
+			c.Hooks.HealthCheckURL = "http://{service}.internal/health"
+			c.Hooks.HealthCheckExpectedStatus = 42
+		}, wantErr: "hooks.health_check_expected_status 42"},
+		{name: "trim fraction of one half", mutate: func(c *Config) { c.Deployment.AnalysisTrimFraction = 0.5 }, wantErr: "deployment.analysis_trim_fraction 0.5"},
+		{name: "unknown concurrent policy", mutate: func(c *Config) { c.Deployment.ConcurrentPolicy = "wait" }, wantErr: `deployment.concurrent_policy must be reject or queue, got "wait"`},
+		{name: "sub-second service lock", mutate: func(c *Config) { c.Deployment.ServiceLockTTL = 500 * time.Millisecond }, wantErr: "deployment.service_lock_ttl"},
+		{name: "service lock disabled", mutate: func(c *Config) { c.Deployment.ServiceLockTTL = 0 }},
+		{name: "no revisions kept", mutate: func(c *Config) { c.Deployment.KeepRevisions = 0 }, wantErr: "deployment.keep_revisions must be positive"},
+		{name: "negative audit backups", mutate: func(c *Config) { c.Audit.MaxBackups = -1 }, wantErr: "audit.max_backups"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			tt.mutate(cfg)
+
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Fatalf("expected an error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Server.Port = 0
+	cfg.AWS.Timeout = 0
+	cfg.Deployment.KeepRevisions = 0
+
+	err := cfg.Validate()
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	for _, want := range []string{"server.port", "aws.timeout", "deployment.keep_revisions"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %s in %v", want, err)
+		}
+	}
+}
+
+func TestLoadConfigValidates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("server:\n  port: 70000\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadConfig(path); err == nil || !strings.Contains(err.Error(), "server.port 70000") {
+		t.Fatalf("expected the file's port to be rejected, got %v", err)
+	}
+
+	// Environment overrides are validated too, and can fix the file
+	t.Setenv("GRPC_PORT", "50051")
+	if _, err := LoadConfig(path); err != nil {
+		t.Fatalf("expected GRPC_PORT to override the invalid port, got %v", err)
+	}
+	t.Setenv("KEEP_REVISIONS", "0")
+	if _, err := LoadConfig(path); err == nil || !strings.Contains(err.Error(), "deployment.keep_revisions") {
+		t.Fatalf("expected the KEEP_REVISIONS override to be rejected, got %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+)
+
+// ValidateAlarms checks that every named CloudWatch alarm exists, so a typo
+// cannot silently disable alarm-based rollback
+func (e *Executor) ValidateAlarms(ctx context.Context, alarmNames []string) error {
+	if dryRunPlan(ctx) != nil {
+		return nil
+	}
+	states, err := e.cwClient.DescribeAlarmStates(ctx, alarmNames)
+	if err != nil {
+		return err
+	}
+
+	var missing []string
+	for _, name := range alarmNames {
+		if _, ok := states[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("rollback alarms not found: %v", missing)
+	}
+	return nil
+}
+
+// FiringAlarms returns the named alarms currently in ALARM state
+func (e *Executor) FiringAlarms(ctx context.Context, alarmNames []string) ([]string, error) {
+	if dryRunPlan(ctx) != nil {
+		return nil, nil
+	}
+	states, err := e.cwClient.DescribeAlarmStates(ctx, alarmNames)
+	if err != nil {
+		return nil, err
+	}
+
+	var firing []string
+	for name, state := range states {
+		if state == types.StateValueAlarm {
+			firing = append(firing, name)
+		}
+	}
+	sort.Strings(firing)
+	return firing, nil
+}
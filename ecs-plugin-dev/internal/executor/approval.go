@@ -2,10 +2,14 @@ package executor
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"sort"
 	"sync"
 	"time"
+
+	"ecs-plugin-dev/internal/util"
 )
 
 type ApprovalStatus string
@@ -14,8 +18,21 @@ const (
 	ApprovalPending  ApprovalStatus = "pending"
 	ApprovalApproved ApprovalStatus = "approved"
 	ApprovalRejected ApprovalStatus = "rejected"
+	ApprovalExpired  ApprovalStatus = "expired"
 )
 
+const (
+	// defaultApprovalTTL is how long a request stays pending before it expires
+	defaultApprovalTTL = 30 * time.Minute
+	// approvalRetention keeps decided and expired requests queryable for a
+	// while before they are dropped
+	approvalRetention = time.Hour
+)
+
+// ErrApprovalTimeout is returned by WaitForApproval when a request is not
+// decided in time
+var ErrApprovalTimeout = errors.New("approval timeout")
+
 type ApprovalRequest struct {
 	DeploymentID string
 	ClusterARN   string
@@ -25,44 +42,137 @@ type ApprovalRequest struct {
 	Status       ApprovalStatus
 	Approver     string
 	Reason       string
+	GroupID      string    // Set when this request gates a deployment group
+	Members      []string  // Deployment IDs released by a group approval
+	ExpiresAt    time.Time // A still pending request expires after this
+	DecidedAt    time.Time // When the request was approved, rejected or expired
 }
 
 type ApprovalManager struct {
-	mu       sync.RWMutex
-	requests map[string]*ApprovalRequest
+	mu           sync.RWMutex
+	requests     map[string]*ApprovalRequest
+	memberGroups map[string]string // Deployment ID -> approval group ID
+	ttl          time.Duration
 }
 
 func NewApprovalManager() *ApprovalManager {
 	return &ApprovalManager{
-		requests: make(map[string]*ApprovalRequest),
+		requests:     make(map[string]*ApprovalRequest),
+		memberGroups: make(map[string]string),
+		ttl:          defaultApprovalTTL,
 	}
 }
 
-func (am *ApprovalManager) RequestApproval(ctx context.Context, deploymentID, cluster, service, strategy string) error {
+// sweepLocked expires pending requests past their deadline and drops requests
+// decided longer than approvalRetention ago. Callers must hold the write lock.
+func (am *ApprovalManager) sweepLocked(now time.Time) {
+	for id, req := range am.requests {
+		if req.Status == ApprovalPending && !req.ExpiresAt.IsZero() && now.After(req.ExpiresAt) {
+			req.Status = ApprovalExpired
+			req.DecidedAt = req.ExpiresAt
+			log.Printf("[APPROVAL] Approval request %s expired", id)
+		}
+		if req.Status != ApprovalPending && now.Sub(req.DecidedAt) > approvalRetention {
+			delete(am.requests, id)
+			for _, member := range req.Members {
+				if am.memberGroups[member] == id {
+					delete(am.memberGroups, member)
+				}
+			}
+		}
+	}
+}
+
+// RequestApproval issues an approval request for a deployment that expires
+// after timeout, or the manager's default when timeout is 0
+func (am *ApprovalManager) RequestApproval(ctx context.Context, deploymentID, cluster, service, strategy string, timeout time.Duration) error {
 	am.mu.Lock()
 	defer am.mu.Unlock()
 
+	now := time.Now()
+	am.sweepLocked(now)
+	if timeout <= 0 {
+		timeout = am.ttl
+	}
+
 	req := &ApprovalRequest{
 		DeploymentID: deploymentID,
 		ClusterARN:   cluster,
 		ServiceName:  service,
 		Strategy:     strategy,
-		RequestedAt:  time.Now(),
+		RequestedAt:  now,
 		Status:       ApprovalPending,
+		ExpiresAt:    now.Add(timeout),
 	}
 	am.requests[deploymentID] = req
 
-	log.Printf("[APPROVAL] Deployment %s requires approval (cluster: %s, service: %s, strategy: %s)",
+	util.Logf(ctx, "[APPROVAL] Deployment %s requires approval (cluster: %s, service: %s, strategy: %s)",
 		deploymentID, cluster, service, strategy)
 
 	return nil
 }
 
+// RequestGroupApproval adds a deployment to an approval group. The approval
+// request is issued once, by the first member; later members join it while it
+// is still pending. A single approval of the group releases every member.
+// A new request expires after timeout (the manager's default when 0); members
+// joining later share its expiry.
+func (am *ApprovalManager) RequestGroupApproval(ctx context.Context, groupID, deploymentID string, timeout time.Duration) error {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	now := time.Now()
+	am.sweepLocked(now)
+
+	if req, exists := am.requests[groupID]; exists && req.GroupID == "" {
+		return fmt.Errorf("approval group %s conflicts with an existing deployment approval", groupID)
+	}
+
+	req, exists := am.requests[groupID]
+	if !exists || req.Status != ApprovalPending {
+		// Start a new approval round for this group
+		if timeout <= 0 {
+			timeout = am.ttl
+		}
+		req = &ApprovalRequest{
+			DeploymentID: groupID,
+			GroupID:      groupID,
+			RequestedAt:  now,
+			Status:       ApprovalPending,
+			ExpiresAt:    now.Add(timeout),
+		}
+		am.requests[groupID] = req
+		util.Logf(ctx, "[APPROVAL] Approval group %s requires approval", groupID)
+	}
+
+	req.Members = append(req.Members, deploymentID)
+	am.memberGroups[deploymentID] = groupID
+
+	util.Logf(ctx, "[APPROVAL] Deployment %s joined approval group %s (%d members)", deploymentID, groupID, len(req.Members))
+	return nil
+}
+
+// resolve finds the approval request for an ID, falling back to the group a
+// deployment belongs to so approving any member approves its group
+func (am *ApprovalManager) resolve(id string) (*ApprovalRequest, bool) {
+	if req, exists := am.requests[id]; exists {
+		return req, true
+	}
+	if groupID, ok := am.memberGroups[id]; ok {
+		req, exists := am.requests[groupID]
+		return req, exists
+	}
+	return nil, false
+}
+
 func (am *ApprovalManager) ApproveDeployment(ctx context.Context, deploymentID, approver, reason string) error {
 	am.mu.Lock()
 	defer am.mu.Unlock()
 
-	req, exists := am.requests[deploymentID]
+	now := time.Now()
+	am.sweepLocked(now)
+
+	req, exists := am.resolve(deploymentID)
 	if !exists {
 		return fmt.Errorf("approval request not found for deployment %s", deploymentID)
 	}
@@ -74,8 +184,14 @@ func (am *ApprovalManager) ApproveDeployment(ctx context.Context, deploymentID,
 	req.Status = ApprovalApproved
 	req.Approver = approver
 	req.Reason = reason
+	req.DecidedAt = now
 
-	log.Printf("[APPROVAL] Deployment %s approved by %s: %s", deploymentID, approver, reason)
+	if req.GroupID != "" {
+		util.Logf(ctx, "[APPROVAL] Approval group %s approved by %s, releasing %d deployments: %s",
+			req.GroupID, approver, len(req.Members), reason)
+		return nil
+	}
+	util.Logf(ctx, "[APPROVAL] Deployment %s approved by %s: %s", deploymentID, approver, reason)
 	return nil
 }
 
@@ -83,7 +199,10 @@ func (am *ApprovalManager) RejectDeployment(ctx context.Context, deploymentID, a
 	am.mu.Lock()
 	defer am.mu.Unlock()
 
-	req, exists := am.requests[deploymentID]
+	now := time.Now()
+	am.sweepLocked(now)
+
+	req, exists := am.resolve(deploymentID)
 	if !exists {
 		return fmt.Errorf("approval request not found for deployment %s", deploymentID)
 	}
@@ -95,14 +214,22 @@ func (am *ApprovalManager) RejectDeployment(ctx context.Context, deploymentID, a
 	req.Status = ApprovalRejected
 	req.Approver = approver
 	req.Reason = reason
+	req.DecidedAt = now
 
-	log.Printf("[APPROVAL] Deployment %s rejected by %s: %s", deploymentID, approver, reason)
+	if req.GroupID != "" {
+		util.Logf(ctx, "[APPROVAL] Approval group %s rejected by %s, stopping %d deployments: %s",
+			req.GroupID, approver, len(req.Members), reason)
+		return nil
+	}
+	util.Logf(ctx, "[APPROVAL] Deployment %s rejected by %s: %s", deploymentID, approver, reason)
 	return nil
 }
 
 func (am *ApprovalManager) GetApprovalStatus(deploymentID string) (ApprovalStatus, error) {
-	am.mu.RLock()
-	defer am.mu.RUnlock()
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	am.sweepLocked(time.Now())
 
 	req, exists := am.requests[deploymentID]
 	if !exists {
@@ -112,16 +239,74 @@ func (am *ApprovalManager) GetApprovalStatus(deploymentID string) (ApprovalStatu
 	return req.Status, nil
 }
 
+// GetApprovalRequest returns a copy of the approval request gating a
+// deployment, resolving group members to their group's request
+func (am *ApprovalManager) GetApprovalRequest(deploymentID string) (ApprovalRequest, error) {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	am.sweepLocked(time.Now())
+
+	req, exists := am.resolve(deploymentID)
+	if !exists {
+		return ApprovalRequest{}, fmt.Errorf("approval request not found for deployment %s", deploymentID)
+	}
+	return copyApprovalRequest(req), nil
+}
+
+// ListPendingApprovals returns copies of all requests still awaiting a
+// decision, oldest first
+func (am *ApprovalManager) ListPendingApprovals() []ApprovalRequest {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	am.sweepLocked(time.Now())
+
+	var pending []ApprovalRequest
+	for _, req := range am.requests {
+		if req.Status == ApprovalPending {
+			pending = append(pending, copyApprovalRequest(req))
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool { return pending[i].RequestedAt.Before(pending[j].RequestedAt) })
+	return pending
+}
+
+func copyApprovalRequest(req *ApprovalRequest) ApprovalRequest {
+	c := *req
+	c.Members = append([]string(nil), req.Members...)
+	return c
+}
+
+// expire marks a still pending request expired so a late decision cannot
+// release it
+func (am *ApprovalManager) expire(deploymentID string) {
+	am.mu.Lock()
+	defer am.mu.Unlock()
+
+	if req, exists := am.requests[deploymentID]; exists && req.Status == ApprovalPending {
+		req.Status = ApprovalExpired
+		req.DecidedAt = time.Now()
+		log.Printf("[APPROVAL] Approval request %s expired", deploymentID)
+	}
+}
+
+// WaitForApproval blocks until the request is decided. A zero timeout waits
+// until the request's own expiry; either way the request is expired when the
+// wait times out.
 func (am *ApprovalManager) WaitForApproval(ctx context.Context, deploymentID string, timeout time.Duration) error {
 	if timeout == 0 {
-		timeout = 30 * time.Minute
+		timeout = am.ttl
+		if req, err := am.GetApprovalRequest(deploymentID); err == nil && !req.ExpiresAt.IsZero() {
+			timeout = time.Until(req.ExpiresAt)
+		}
 	}
 
 	deadline := time.Now().Add(timeout)
 	ticker := time.NewTicker(5 * time.Second)
 	defer ticker.Stop()
 
-	log.Printf("[APPROVAL] Waiting for approval of deployment %s (timeout: %v)", deploymentID, timeout)
+	util.Logf(ctx, "[APPROVAL] Waiting for approval of deployment %s (timeout: %v)", deploymentID, timeout)
 
 	for {
 		select {
@@ -129,7 +314,8 @@ func (am *ApprovalManager) WaitForApproval(ctx context.Context, deploymentID str
 			return ctx.Err()
 		case <-ticker.C:
 			if time.Now().After(deadline) {
-				return fmt.Errorf("approval timeout for deployment %s", deploymentID)
+				am.expire(deploymentID)
+				return fmt.Errorf("%w for deployment %s", ErrApprovalTimeout, deploymentID)
 			}
 
 			status, err := am.GetApprovalStatus(deploymentID)
@@ -139,10 +325,12 @@ func (am *ApprovalManager) WaitForApproval(ctx context.Context, deploymentID str
 
 			switch status {
 			case ApprovalApproved:
-				log.Printf("[APPROVAL] Deployment %s approved, proceeding", deploymentID)
+				util.Logf(ctx, "[APPROVAL] Deployment %s approved, proceeding", deploymentID)
 				return nil
 			case ApprovalRejected:
 				return fmt.Errorf("deployment %s rejected", deploymentID)
+			case ApprovalExpired:
+				return fmt.Errorf("%w for deployment %s", ErrApprovalTimeout, deploymentID)
 			}
 		}
 	}
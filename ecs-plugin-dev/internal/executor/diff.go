@@ -0,0 +1,166 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+)
+
+// Kinds of change reported by DiffTaskDefinition
+const (
+	ChangeAdded    = "added"
+	ChangeRemoved  = "removed"
+	ChangeModified = "modified"
+)
+
+// FieldChange describes one field that differs between the running task
+// definition and a new one. Container is empty for task-level fields.
+type FieldChange struct {
+	Field     string `json:"field"`
+	Container string `json:"container,omitempty"`
+	Change    string `json:"change"`
+	Old       string `json:"old,omitempty"`
+	New       string `json:"new,omitempty"`
+}
+
+// TaskDefinitionDiff is the set of changes deploying a task definition would make
+type TaskDefinitionDiff struct {
+	// CurrentTaskDefinition is the ARN the service runs now
+	CurrentTaskDefinition string
+	Changes               []FieldChange
+}
+
+// DiffTaskDefinition compares the task definition a service currently runs
+// with newTaskDefJSON, reporting changes to task CPU and memory and to each
+// container's image, CPU, memory, environment, secrets and port mappings
+func (e *Executor) DiffTaskDefinition(ctx context.Context, cluster, service, newTaskDefJSON string) (*TaskDefinitionDiff, error) {
+	var proposed ecs.RegisterTaskDefinitionInput
+	if err := json.Unmarshal([]byte(newTaskDefJSON), &proposed); err != nil {
+		return nil, fmt.Errorf("invalid task definition: %w", err)
+	}
+
+	svc, err := e.ecsClient.DescribeService(ctx, cluster, service)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe service: %w", err)
+	}
+	currentARN := aws.ToString(svc.TaskDefinition)
+	if currentARN == "" {
+		return nil, fmt.Errorf("service %s has no task definition to diff against", service)
+	}
+
+	current, err := e.ecsClient.DescribeTaskDefinition(ctx, currentARN)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TaskDefinitionDiff{
+		CurrentTaskDefinition: currentARN,
+		Changes:               diffTaskDefinitions(current, &proposed),
+	}, nil
+}
+
+// diffTaskDefinitions lists the changes from current to proposed, task-level
+// fields first, then containers in the proposed order, then removed containers
+func diffTaskDefinitions(current *types.TaskDefinition, proposed *ecs.RegisterTaskDefinitionInput) []FieldChange {
+	var changes []FieldChange
+	compare := func(field, container, old, new string) {
+		switch {
+		case old == new:
+		case old == "":
+			changes = append(changes, FieldChange{Field: field, Container: container, Change: ChangeAdded, New: new})
+		case new == "":
+			changes = append(changes, FieldChange{Field: field, Container: container, Change: ChangeRemoved, Old: old})
+		default:
+			changes = append(changes, FieldChange{Field: field, Container: container, Change: ChangeModified, Old: old, New: new})
+		}
+	}
+
+	compare("cpu", "", aws.ToString(current.Cpu), aws.ToString(proposed.Cpu))
+	compare("memory", "", aws.ToString(current.Memory), aws.ToString(proposed.Memory))
+
+	currentContainers := make(map[string]types.ContainerDefinition, len(current.ContainerDefinitions))
+	for _, c := range current.ContainerDefinitions {
+		currentContainers[aws.ToString(c.Name)] = c
+	}
+
+	for _, next := range proposed.ContainerDefinitions {
+		name := aws.ToString(next.Name)
+		prev, ok := currentContainers[name]
+		if !ok {
+			changes = append(changes, FieldChange{Field: "container", Container: name, Change: ChangeAdded, New: aws.ToString(next.Image)})
+			continue
+		}
+		delete(currentContainers, name)
+
+		compare("image", name, aws.ToString(prev.Image), aws.ToString(next.Image))
+		compare("cpu", name, formatUnits(prev.Cpu), formatUnits(next.Cpu))
+		compare("memory", name, formatUnits(aws.ToInt32(prev.Memory)), formatUnits(aws.ToInt32(next.Memory)))
+		compare("memory_reservation", name, formatUnits(aws.ToInt32(prev.MemoryReservation)), formatUnits(aws.ToInt32(next.MemoryReservation)))
+
+		prevEnv, nextEnv := environmentMap(prev.Environment), environmentMap(next.Environment)
+		for _, key := range sortedKeys(prevEnv, nextEnv) {
+			compare("env."+key, name, prevEnv[key], nextEnv[key])
+		}
+
+		// Secrets are references (ARNs), never the secret values themselves
+		prevSecrets, nextSecrets := secretMap(prev.Secrets), secretMap(next.Secrets)
+		for _, key := range sortedKeys(prevSecrets, nextSecrets) {
+			compare("secret."+key, name, prevSecrets[key], nextSecrets[key])
+		}
+
+		compare("port_mappings", name, formatPortMappings(prev.PortMappings), formatPortMappings(next.PortMappings))
+	}
+
+	removed := make([]string, 0, len(currentContainers))
+	for name := range currentContainers {
+		removed = append(removed, name)
+	}
+	sort.Strings(removed)
+	for _, name := range removed {
+		changes = append(changes, FieldChange{Field: "container", Container: name, Change: ChangeRemoved, Old: aws.ToString(currentContainers[name].Image)})
+	}
+
+	return changes
+}
+
+// formatUnits renders a CPU or memory value, leaving unset (zero) values empty
+func formatUnits(v int32) string {
+	if v == 0 {
+		return ""
+	}
+	return strconv.Itoa(int(v))
+}
+
+func secretMap(secrets []types.Secret) map[string]string {
+	m := make(map[string]string, len(secrets))
+	for _, s := range secrets {
+		m[aws.ToString(s.Name)] = aws.ToString(s.ValueFrom)
+	}
+	return m
+}
+
+// formatPortMappings renders port mappings as a sorted, comma-separated list
+// of containerPort[:hostPort]/protocol so reordering does not count as a change
+func formatPortMappings(mappings []types.PortMapping) string {
+	ports := make([]string, 0, len(mappings))
+	for _, pm := range mappings {
+		port := strconv.Itoa(int(aws.ToInt32(pm.ContainerPort)))
+		if host := aws.ToInt32(pm.HostPort); host != 0 && host != aws.ToInt32(pm.ContainerPort) {
+			port += ":" + strconv.Itoa(int(host))
+		}
+		protocol := string(pm.Protocol)
+		if protocol == "" {
+			protocol = string(types.TransportProtocolTcp)
+		}
+		ports = append(ports, port+"/"+protocol)
+	}
+	sort.Strings(ports)
+	return strings.Join(ports, ",")
+}
@@ -3,8 +3,16 @@ package executor
 import (
 	"context"
 	"fmt"
-	"log"
+	"sort"
+	"strconv"
+	"sync"
 	"time"
+
+	"ecs-plugin-dev/internal/audit"
+	"ecs-plugin-dev/internal/util"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
 )
 
 type DriftStatus string
@@ -15,20 +23,35 @@ const (
 	DriftFixed    DriftStatus = "fixed"
 )
 
+// DriftDetail describes one field that differs from the expected state
+type DriftDetail struct {
+	Field     string `json:"field"`
+	Container string `json:"container,omitempty"`
+	Expected  string `json:"expected"`
+	Actual    string `json:"actual"`
+}
+
+func (d DriftDetail) String() string {
+	if d.Container != "" {
+		return fmt.Sprintf("container %s %s drift: expected %q, found %q", d.Container, d.Field, d.Expected, d.Actual)
+	}
+	return fmt.Sprintf("%s drift: expected %q, found %q", d.Field, d.Expected, d.Actual)
+}
+
 type DriftResult struct {
 	Status          DriftStatus
-	Drifts          []string
+	Drifts          []DriftDetail
 	DetectedAt      time.Time
 	ReconciledAt    time.Time
 	ReconcileAction string
 }
 
 func (e *Executor) DetectDrift(ctx context.Context, cluster, service, expectedTaskDef string) (*DriftResult, error) {
-	log.Printf("[DRIFT] Detecting drift for service %s", service)
+	util.Logf(ctx, "[DRIFT] Detecting drift for service %s", service)
 
 	result := &DriftResult{
 		Status:     DriftNone,
-		Drifts:     []string{},
+		Drifts:     []DriftDetail{},
 		DetectedAt: time.Now(),
 	}
 
@@ -38,68 +61,169 @@ func (e *Executor) DetectDrift(ctx context.Context, cluster, service, expectedTa
 		return nil, fmt.Errorf("failed to describe service: %w", err)
 	}
 
-	currentTaskDef := *currentSvc.TaskDefinition
+	currentTaskDef := aws.ToString(currentSvc.TaskDefinition)
 
-	// Check task definition drift
+	// Check task definition drift, down to the container settings that differ
 	if currentTaskDef != expectedTaskDef {
-		result.Status = DriftDetected
-		result.Drifts = append(result.Drifts, fmt.Sprintf("Task definition drift: expected %s, found %s", expectedTaskDef, currentTaskDef))
-		log.Printf("[DRIFT] Task definition drift detected: expected %s, found %s", expectedTaskDef, currentTaskDef)
+		result.Drifts = append(result.Drifts, DriftDetail{Field: "task_definition", Expected: expectedTaskDef, Actual: currentTaskDef})
+		util.Logf(ctx, "[DRIFT] Task definition drift detected: expected %s, found %s", expectedTaskDef, currentTaskDef)
+
+		details, err := e.compareTaskDefinitions(ctx, expectedTaskDef, currentTaskDef)
+		if err != nil {
+			util.Logf(ctx, "[DRIFT] Could not compare task definition contents: %v", err)
+		}
+		result.Drifts = append(result.Drifts, details...)
 	}
 
 	// Check desired count drift (if configured)
 	if currentSvc.DesiredCount == 0 {
-		result.Status = DriftDetected
-		result.Drifts = append(result.Drifts, fmt.Sprintf("Service scaled to zero: desired count is %d", currentSvc.DesiredCount))
-		log.Printf("[DRIFT] Service scaled to zero unexpectedly")
+		result.Drifts = append(result.Drifts, DriftDetail{Field: "desired_count", Expected: "> 0", Actual: "0"})
+		util.Logf(ctx, "[DRIFT] Service scaled to zero unexpectedly")
 	}
 
 	// Check running count vs desired
 	if currentSvc.RunningCount < currentSvc.DesiredCount {
+		result.Drifts = append(result.Drifts, DriftDetail{
+			Field:    "running_count",
+			Expected: strconv.Itoa(int(currentSvc.DesiredCount)),
+			Actual:   strconv.Itoa(int(currentSvc.RunningCount)),
+		})
+		util.Logf(ctx, "[DRIFT] Running count drift: running=%d, desired=%d", currentSvc.RunningCount, currentSvc.DesiredCount)
+	}
+
+	if len(result.Drifts) > 0 {
 		result.Status = DriftDetected
-		result.Drifts = append(result.Drifts, fmt.Sprintf("Running count (%d) less than desired (%d)", currentSvc.RunningCount, currentSvc.DesiredCount))
-		log.Printf("[DRIFT] Running count drift: running=%d, desired=%d", currentSvc.RunningCount, currentSvc.DesiredCount)
 	}
 
 	if result.Status == DriftNone {
-		log.Printf("[DRIFT] No drift detected for service %s", service)
+		util.Logf(ctx, "[DRIFT] No drift detected for service %s", service)
 	} else {
-		log.Printf("[DRIFT] Detected %d drift(s) for service %s", len(result.Drifts), service)
+		util.Logf(ctx, "[DRIFT] Detected %d drift(s) for service %s", len(result.Drifts), service)
 	}
 
 	return result, nil
 }
 
-func (e *Executor) ReconcileDrift(ctx context.Context, cluster, service, expectedTaskDef string) error {
-	log.Printf("[DRIFT] Reconciling drift for service %s", service)
+// compareTaskDefinitions reports differences in task-level CPU and memory and
+// in each container's image, CPU, memory and environment variables
+func (e *Executor) compareTaskDefinitions(ctx context.Context, expectedTaskDef, currentTaskDef string) ([]DriftDetail, error) {
+	expected, err := e.ecsClient.DescribeTaskDefinition(ctx, expectedTaskDef)
+	if err != nil {
+		return nil, fmt.Errorf("expected task definition: %w", err)
+	}
+	current, err := e.ecsClient.DescribeTaskDefinition(ctx, currentTaskDef)
+	if err != nil {
+		return nil, fmt.Errorf("current task definition: %w", err)
+	}
+
+	var details []DriftDetail
+	addIfChanged := func(field, container, want, got string) {
+		if want != got {
+			details = append(details, DriftDetail{Field: field, Container: container, Expected: want, Actual: got})
+		}
+	}
+
+	addIfChanged("cpu", "", aws.ToString(expected.Cpu), aws.ToString(current.Cpu))
+	addIfChanged("memory", "", aws.ToString(expected.Memory), aws.ToString(current.Memory))
+
+	currentContainers := make(map[string]types.ContainerDefinition, len(current.ContainerDefinitions))
+	for _, c := range current.ContainerDefinitions {
+		currentContainers[aws.ToString(c.Name)] = c
+	}
+
+	for _, want := range expected.ContainerDefinitions {
+		name := aws.ToString(want.Name)
+		got, ok := currentContainers[name]
+		if !ok {
+			details = append(details, DriftDetail{Field: "container", Container: name, Expected: "present", Actual: "missing"})
+			continue
+		}
+		delete(currentContainers, name)
+
+		addIfChanged("image", name, aws.ToString(want.Image), aws.ToString(got.Image))
+		addIfChanged("cpu", name, strconv.Itoa(int(want.Cpu)), strconv.Itoa(int(got.Cpu)))
+		addIfChanged("memory", name, strconv.Itoa(int(aws.ToInt32(want.Memory))), strconv.Itoa(int(aws.ToInt32(got.Memory))))
+
+		wantEnv, gotEnv := environmentMap(want.Environment), environmentMap(got.Environment)
+		for _, key := range sortedKeys(wantEnv, gotEnv) {
+			addIfChanged("env."+key, name, wantEnv[key], gotEnv[key])
+		}
+	}
+
+	extra := make([]string, 0, len(currentContainers))
+	for name := range currentContainers {
+		extra = append(extra, name)
+	}
+	sort.Strings(extra)
+	for _, name := range extra {
+		details = append(details, DriftDetail{Field: "container", Container: name, Expected: "absent", Actual: "present"})
+	}
+
+	return details, nil
+}
+
+func environmentMap(env []types.KeyValuePair) map[string]string {
+	m := make(map[string]string, len(env))
+	for _, kv := range env {
+		m[aws.ToString(kv.Name)] = aws.ToString(kv.Value)
+	}
+	return m
+}
+
+// sortedKeys returns the union of both maps' keys in sorted order
+func sortedKeys(a, b map[string]string) []string {
+	seen := make(map[string]struct{}, len(a)+len(b))
+	for k := range a {
+		seen[k] = struct{}{}
+	}
+	for k := range b {
+		seen[k] = struct{}{}
+	}
+	keys := make([]string, 0, len(seen))
+	for k := range seen {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ReconcileDrift detects drift and, if there is any, updates the service
+// back to expectedTaskDef and waits for it to stabilize. The result lists
+// the drift found; it is DriftFixed once reconciled. If reconciling fails
+// the result still reports the drift alongside the error.
+func (e *Executor) ReconcileDrift(ctx context.Context, cluster, service, expectedTaskDef string) (*DriftResult, error) {
+	util.Logf(ctx, "[DRIFT] Reconciling drift for service %s", service)
 
 	// Detect drift first
 	drift, err := e.DetectDrift(ctx, cluster, service, expectedTaskDef)
 	if err != nil {
-		return fmt.Errorf("failed to detect drift: %w", err)
+		return nil, fmt.Errorf("failed to detect drift: %w", err)
 	}
 
 	if drift.Status == DriftNone {
-		log.Printf("[DRIFT] No drift to reconcile")
-		return nil
+		util.Logf(ctx, "[DRIFT] No drift to reconcile")
+		return drift, nil
 	}
 
-	log.Printf("[DRIFT] Found %d drift(s), reconciling...", len(drift.Drifts))
+	util.Logf(ctx, "[DRIFT] Found %d drift(s), reconciling...", len(drift.Drifts))
 
 	// Reconcile by updating service to expected task definition
 	err = e.UpdateService(ctx, cluster, service, expectedTaskDef)
 	if err != nil {
-		return fmt.Errorf("failed to reconcile drift: %w", err)
+		return drift, fmt.Errorf("failed to reconcile drift: %w", err)
 	}
 
 	// Wait for service to stabilize
 	err = e.WaitForServiceStable(ctx, cluster, service, 5*time.Minute)
 	if err != nil {
-		return fmt.Errorf("service failed to stabilize after reconciliation: %w", err)
+		return drift, fmt.Errorf("service failed to stabilize after reconciliation: %w", err)
 	}
 
-	log.Printf("[DRIFT] Successfully reconciled drift for service %s", service)
-	return nil
+	drift.Status = DriftFixed
+	drift.ReconciledAt = time.Now()
+	drift.ReconcileAction = fmt.Sprintf("updated service to %s", expectedTaskDef)
+	util.Logf(ctx, "[DRIFT] Successfully reconciled drift for service %s", service)
+	return drift, nil
 }
 
 func (e *Executor) MonitorDrift(ctx context.Context, cluster, service, expectedTaskDef string, interval time.Duration) error {
@@ -110,27 +234,128 @@ func (e *Executor) MonitorDrift(ctx context.Context, cluster, service, expectedT
 	ticker := time.NewTicker(interval)
 	defer ticker.Stop()
 
-	log.Printf("[DRIFT] Starting drift monitoring for service %s (interval: %v)", service, interval)
+	util.Logf(ctx, "[DRIFT] Starting drift monitoring for service %s (interval: %v)", service, interval)
 
 	for {
 		select {
 		case <-ctx.Done():
-			log.Printf("[DRIFT] Drift monitoring stopped for service %s", service)
+			util.Logf(ctx, "[DRIFT] Drift monitoring stopped for service %s", service)
 			return ctx.Err()
 		case <-ticker.C:
 			drift, err := e.DetectDrift(ctx, cluster, service, expectedTaskDef)
 			if err != nil {
-				log.Printf("[DRIFT] Error detecting drift: %v", err)
+				util.Logf(ctx, "[DRIFT] Error detecting drift: %v", err)
 				continue
 			}
 
 			if drift.Status == DriftDetected {
-				log.Printf("[DRIFT] Drift detected, auto-reconciling...")
-				err = e.ReconcileDrift(ctx, cluster, service, expectedTaskDef)
-				if err != nil {
-					log.Printf("[DRIFT] Failed to auto-reconcile: %v", err)
+				auditDrift(ctx, audit.EventDriftDetected, cluster, service, expectedTaskDef, drift.Drifts)
+				util.Logf(ctx, "[DRIFT] Drift detected, auto-reconciling...")
+				if _, err := e.ReconcileDrift(ctx, cluster, service, expectedTaskDef); err != nil {
+					util.Logf(ctx, "[DRIFT] Failed to auto-reconcile: %v", err)
+					continue
 				}
+				auditDrift(ctx, audit.EventDriftReconciled, cluster, service, expectedTaskDef, drift.Drifts)
 			}
 		}
 	}
 }
+
+// auditDrift records which fields drifted on a service
+func auditDrift(ctx context.Context, eventType audit.AuditEventType, cluster, service, expectedTaskDef string, drifts []DriftDetail) {
+	auditLogger := audit.GetGlobalAuditLogger()
+	if auditLogger == nil {
+		return
+	}
+	auditLogger.Log(audit.AuditEvent{
+		EventType:   eventType,
+		ClusterARN:  cluster,
+		ServiceName: service,
+		Status:      string(eventType),
+		Metadata: map[string]interface{}{
+			"expected_task_definition": expectedTaskDef,
+			"drifts":                   drifts,
+		},
+		CorrelationID: util.CorrelationIDFromContext(ctx),
+	})
+}
+
+// DriftMonitorManager tracks background drift monitors per service
+type DriftMonitorManager struct {
+	mu       sync.Mutex
+	executor *Executor
+	monitors map[string]context.CancelFunc
+}
+
+func NewDriftMonitorManager(exec *Executor) *DriftMonitorManager {
+	return &DriftMonitorManager{
+		executor: exec,
+		monitors: make(map[string]context.CancelFunc),
+	}
+}
+
+// Start launches a background drift monitor for the service
+func (m *DriftMonitorManager) Start(cluster, service, expectedTaskDef string, interval time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := driftMonitorKey(cluster, service)
+	if _, exists := m.monitors[key]; exists {
+		return fmt.Errorf("drift monitor already running for service %s", service)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.monitors[key] = cancel
+
+	go func() {
+		m.executor.MonitorDrift(ctx, cluster, service, expectedTaskDef, interval)
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		delete(m.monitors, key)
+	}()
+
+	return nil
+}
+
+// Stop cancels the drift monitor for the service, returning false if none was running
+func (m *DriftMonitorManager) Stop(cluster, service string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := driftMonitorKey(cluster, service)
+	cancel, exists := m.monitors[key]
+	if !exists {
+		return false
+	}
+
+	cancel()
+	delete(m.monitors, key)
+	return true
+}
+
+// IsMonitoring reports whether a drift monitor is running for the service
+func (m *DriftMonitorManager) IsMonitoring(cluster, service string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	_, exists := m.monitors[driftMonitorKey(cluster, service)]
+	return exists
+}
+
+// List returns the cluster/service keys of all running drift monitors
+func (m *DriftMonitorManager) List() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	keys := make([]string, 0, len(m.monitors))
+	for key := range m.monitors {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func driftMonitorKey(cluster, service string) string {
+	return fmt.Sprintf("%s/%s", cluster, service)
+}
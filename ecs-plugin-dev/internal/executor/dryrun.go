@@ -0,0 +1,51 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+)
+
+type dryRunKey struct{}
+
+// DryRunPlan collects the AWS operations a deployment would perform, in order,
+// when it runs in dry-run mode
+type DryRunPlan struct {
+	mu    sync.Mutex
+	steps []string
+}
+
+// WithDryRun makes executor calls made with ctx record into plan instead of calling AWS
+func WithDryRun(ctx context.Context, plan *DryRunPlan) context.Context {
+	return context.WithValue(ctx, dryRunKey{}, plan)
+}
+
+// dryRunPlan returns the plan ctx records into, or nil outside dry-run mode
+func dryRunPlan(ctx context.Context) *DryRunPlan {
+	plan, _ := ctx.Value(dryRunKey{}).(*DryRunPlan)
+	return plan
+}
+
+// RecordDryRunStep adds a step to ctx's plan; it is a no-op outside dry-run mode
+func RecordDryRunStep(ctx context.Context, format string, args ...interface{}) {
+	if plan := dryRunPlan(ctx); plan != nil {
+		plan.record(format, args...)
+	}
+}
+
+func (p *DryRunPlan) record(format string, args ...interface{}) {
+	step := fmt.Sprintf(format, args...)
+	log.Printf("[DRY-RUN] %s", step)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.steps = append(p.steps, step)
+}
+
+// Steps returns the recorded steps in order
+func (p *DryRunPlan) Steps() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.steps...)
+}
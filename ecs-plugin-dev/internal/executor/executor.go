@@ -6,41 +6,105 @@ import (
 	"fmt"
 
 	"ecs-plugin-dev/internal/aws"
+	"ecs-plugin-dev/internal/util"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
 )
 
 type Executor struct {
 	ecsClient *aws.ECSClient
 	elbClient *aws.ELBClient
+	cwClient  *aws.CloudWatchClient
+	iamClient *aws.IAMClient
 }
 
 func NewExecutor() *Executor {
+	return NewExecutorWithRetry(util.DefaultRetryConfig())
+}
+
+// NewExecutorWithRetry creates an executor whose AWS clients retry calls with retry
+func NewExecutorWithRetry(retry util.RetryConfig) *Executor {
 	return &Executor{
-		ecsClient: aws.NewECSClient(),
-		elbClient: aws.NewELBClient(),
+		ecsClient: aws.NewECSClientWithConfig(retry),
+		elbClient: aws.NewELBClientWithConfig(retry),
+		cwClient:  aws.NewCloudWatchClient(),
+		iamClient: aws.NewIAMClient(),
 	}
 }
 
+// ValidatePermissions checks the configured credentials are allowed every
+// AWS action deployments use
+func (e *Executor) ValidatePermissions(ctx context.Context) (*aws.ValidatePermissionsResult, error) {
+	return e.iamClient.ValidatePermissions(ctx, e.iamClient.GetRequiredECSPermissions())
+}
+
+// CheckAWS reports whether AWS can be reached with the configured credentials
+func (e *Executor) CheckAWS(ctx context.Context) error {
+	return e.iamClient.CheckConnectivity(ctx)
+}
+
 func (e *Executor) RegisterTaskDefinition(ctx context.Context, taskDefJSON string) error {
 	return e.ecsClient.RegisterTaskDefinition(ctx, taskDefJSON)
 }
 
+// RegisterTaskDefinitionARN registers a task definition and returns the new revision's ARN
+func (e *Executor) RegisterTaskDefinitionARN(ctx context.Context, taskDefJSON string) (string, error) {
+	if plan := dryRunPlan(ctx); plan != nil {
+		plan.record("RegisterTaskDefinition")
+		return "dry-run", nil
+	}
+	return e.ecsClient.RegisterTaskDefinitionARN(ctx, taskDefJSON)
+}
+
 func (e *Executor) UpdateService(ctx context.Context, cluster, service, taskDef string) error {
-	return e.ecsClient.UpdateService(ctx, cluster, service, taskDef)
+	return e.UpdateServiceWithOptions(ctx, cluster, service, taskDef, aws.DeploymentOptions{})
 }
 
-func (e *Executor) CreateTaskSet(ctx context.Context, cluster, service, taskDef string, weight int) error {
-	return e.ecsClient.CreateTaskSet(ctx, cluster, service, taskDef, weight)
+// UpdateServiceWithOptions updates the service, applying ECS-native deployment settings
+func (e *Executor) UpdateServiceWithOptions(ctx context.Context, cluster, service, taskDef string, opts aws.DeploymentOptions) error {
+	if plan := dryRunPlan(ctx); plan != nil {
+		plan.record("UpdateService %s (circuit breaker: %v)", service, opts.CircuitBreaker)
+		return nil
+	}
+	return e.ecsClient.UpdateServiceWithOptions(ctx, cluster, service, taskDef, opts)
+}
+
+func (e *Executor) CreateTaskSet(ctx context.Context, cluster, service, taskDef string, weight int) (string, error) {
+	return e.CreateTaskSetWithScale(ctx, cluster, service, taskDef, float64(weight), nil)
 }
 
 func (e *Executor) UpdateTraffic(ctx context.Context, cluster, service string, canaryWeight, primaryWeight int) error {
-	return e.elbClient.UpdateTargetGroupWeights(ctx, cluster, service, canaryWeight, primaryWeight)
+	return e.UpdateTrafficWithRouting(ctx, cluster, service, aws.TrafficRouting{}, canaryWeight, primaryWeight)
+}
+
+// UpdateTrafficWithRouting shifts traffic on the listener and target groups selected by routing
+func (e *Executor) UpdateTrafficWithRouting(ctx context.Context, cluster, service string, routing aws.TrafficRouting, canaryWeight, primaryWeight int) error {
+	if plan := dryRunPlan(ctx); plan != nil {
+		plan.record("ShiftTraffic new=%d%% primary=%d%%", canaryWeight, primaryWeight)
+		return nil
+	}
+	return e.elbClient.UpdateTargetGroupWeightsWithRouting(ctx, cluster, service, routing, canaryWeight, primaryWeight)
 }
 
 func (e *Executor) DeleteTaskSet(ctx context.Context, cluster, service, taskSetID string) error {
+	// Nothing to delete, e.g. the service had no primary task set
+	if taskSetID == "" {
+		return nil
+	}
+	if plan := dryRunPlan(ctx); plan != nil {
+		plan.record("DeleteTaskSet %s", taskSetID)
+		return nil
+	}
 	return e.ecsClient.DeleteTaskSet(ctx, cluster, service, taskSetID)
 }
 
+// RollbackService updates a service back to its previous task definition.
+// Use PreviousTaskDefinition to only look the previous definition up.
 func (e *Executor) RollbackService(ctx context.Context, cluster, service string) error {
+	if plan := dryRunPlan(ctx); plan != nil {
+		plan.record("UpdateService %s to previous task definition", service)
+		return nil
+	}
 	taskDef, err := e.ecsClient.GetPreviousTaskDefinition(ctx, cluster, service)
 	if err != nil {
 		return fmt.Errorf("rollback failed: %w", err)
@@ -48,7 +112,40 @@ func (e *Executor) RollbackService(ctx context.Context, cluster, service string)
 	return e.UpdateService(ctx, cluster, service, taskDef)
 }
 
+// RestoreServiceSnapshot returns a service to the task definition, desired
+// count and deployment percentages it had when snapshot was taken
+func (e *Executor) RestoreServiceSnapshot(ctx context.Context, cluster, service string, snapshot *ServiceSnapshot) error {
+	if snapshot == nil || snapshot.TaskDefinition == "" {
+		return fmt.Errorf("rollback failed: %w", aws.ErrNoPreviousDeployment)
+	}
+	if plan := dryRunPlan(ctx); plan != nil {
+		plan.record("UpdateService %s to snapshot %s (desired count %d)", service, snapshot.TaskDefinition, snapshot.DesiredCount)
+		return nil
+	}
+	return e.ecsClient.UpdateServiceWithOptions(ctx, cluster, service, snapshot.TaskDefinition, aws.DeploymentOptions{
+		MinimumHealthyPercent: snapshot.MinimumHealthyPercent,
+		MaximumPercent:        snapshot.MaximumPercent,
+		DesiredCount:          snapshot.DesiredCount,
+	})
+}
+
+// RollbackToRevision rolls a service back to a specific task definition
+// revision, verifying the revision exists and is still active first
+func (e *Executor) RollbackToRevision(ctx context.Context, cluster, service, taskDef string) error {
+	td, err := e.ecsClient.DescribeTaskDefinition(ctx, taskDef)
+	if err != nil {
+		return fmt.Errorf("rollback failed: task definition %s: %w", taskDef, err)
+	}
+	if td.Status == types.TaskDefinitionStatusInactive {
+		return fmt.Errorf("rollback failed: task definition %s is inactive", taskDef)
+	}
+	return e.UpdateService(ctx, cluster, service, taskDef)
+}
+
 func (e *Executor) DescribeService(ctx context.Context, cluster, service string) error {
+	if dryRunPlan(ctx) != nil {
+		return nil
+	}
 	_, err := e.ecsClient.DescribeService(ctx, cluster, service)
 	return err
 }
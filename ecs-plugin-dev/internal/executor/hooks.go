@@ -1,11 +1,26 @@
 package executor
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+
+	"ecs-plugin-dev/internal/audit"
+	"ecs-plugin-dev/internal/util"
 )
 
+// ErrDependencyUnhealthy is returned when a dependent service fails its health check
+var ErrDependencyUnhealthy = errors.New("dependency unhealthy")
+
 // HookType defines the type of deployment hook
 type HookType string
 
@@ -14,10 +29,18 @@ const (
 	PostDeployHook HookType = "post-deploy"
 )
 
-// Hook represents a deployment hook
+// Hook represents a deployment hook. Hooks are critical by default: a failure
+// fails the deployment. Failures of best-effort hooks are logged and audited only.
 type Hook struct {
-	Name string
-	Fn   func(ctx context.Context, deploymentID, cluster, service string) error
+	Name       string
+	Fn         func(ctx context.Context, deploymentID, cluster, service string) error
+	BestEffort bool
+
+	// Timeout bounds a single run of the hook; zero means no limit
+	Timeout time.Duration
+	// Priority orders hooks of the same type, lowest first; hooks with equal
+	// priority run in registration order
+	Priority int
 }
 
 // HookRegistry stores registered hooks
@@ -34,57 +57,317 @@ func NewHookRegistry() *HookRegistry {
 	}
 }
 
-// RegisterHook registers a new hook
+// RegisterHook registers a new hook, keeping hooks sorted by priority
 func (h *HookRegistry) RegisterHook(hookType HookType, hook Hook) {
 	switch hookType {
 	case PreDeployHook:
-		h.preDeployHooks = append(h.preDeployHooks, hook)
+		h.preDeployHooks = insertByPriority(h.preDeployHooks, hook)
 	case PostDeployHook:
-		h.postDeployHooks = append(h.postDeployHooks, hook)
+		h.postDeployHooks = insertByPriority(h.postDeployHooks, hook)
 	}
 }
 
-// ExecutePreDeployHooks executes all pre-deployment hooks
-func (h *HookRegistry) ExecutePreDeployHooks(ctx context.Context, deploymentID, cluster, service string) error {
-	log.Printf("[HOOKS] Executing %d pre-deploy hooks", len(h.preDeployHooks))
-	for _, hook := range h.preDeployHooks {
-		log.Printf("[HOOK] Running pre-deploy hook: %s", hook.Name)
-		if err := hook.Fn(ctx, deploymentID, cluster, service); err != nil {
-			return fmt.Errorf("pre-deploy hook %s failed: %w", hook.Name, err)
+func insertByPriority(hooks []Hook, hook Hook) []Hook {
+	hooks = append(hooks, hook)
+	sort.SliceStable(hooks, func(i, j int) bool { return hooks[i].Priority < hooks[j].Priority })
+	return hooks
+}
+
+// MarkBestEffort makes the named registered hooks best-effort
+func (h *HookRegistry) MarkBestEffort(names ...string) {
+	for _, name := range names {
+		found := false
+		for _, hooks := range [][]Hook{h.preDeployHooks, h.postDeployHooks} {
+			for i := range hooks {
+				if hooks[i].Name == name {
+					hooks[i].BestEffort = true
+					found = true
+				}
+			}
+		}
+		if !found {
+			log.Printf("[HOOKS] Cannot mark unknown hook %s as best-effort", name)
 		}
 	}
-	return nil
+}
+
+// ExecutePreDeployHooks executes all pre-deployment hooks
+func (h *HookRegistry) ExecutePreDeployHooks(ctx context.Context, deploymentID, cluster, service string) error {
+	util.Logf(ctx, "[HOOKS] Executing %d pre-deploy hooks", len(h.preDeployHooks))
+	return runHooks(ctx, PreDeployHook, h.preDeployHooks, deploymentID, cluster, service)
 }
 
 // ExecutePostDeployHooks executes all post-deployment hooks
 func (h *HookRegistry) ExecutePostDeployHooks(ctx context.Context, deploymentID, cluster, service string) error {
-	log.Printf("[HOOKS] Executing %d post-deploy hooks", len(h.postDeployHooks))
-	for _, hook := range h.postDeployHooks {
-		log.Printf("[HOOK] Running post-deploy hook: %s", hook.Name)
-		if err := hook.Fn(ctx, deploymentID, cluster, service); err != nil {
-			return fmt.Errorf("post-deploy hook %s failed: %w", hook.Name, err)
+	util.Logf(ctx, "[HOOKS] Executing %d post-deploy hooks", len(h.postDeployHooks))
+	return runHooks(ctx, PostDeployHook, h.postDeployHooks, deploymentID, cluster, service)
+}
+
+// runHooks runs hooks in order, stopping at the first critical failure
+func runHooks(ctx context.Context, hookType HookType, hooks []Hook, deploymentID, cluster, service string) error {
+	for _, hook := range hooks {
+		util.Logf(ctx, "[HOOK] Running %s hook: %s", hookType, hook.Name)
+		err := runHook(ctx, hook, deploymentID, cluster, service)
+		if err == nil {
+			continue
+		}
+
+		auditHookFailure(ctx, hookType, hook, deploymentID, cluster, service, err)
+		if hook.BestEffort {
+			util.Logf(ctx, "[HOOK] Best-effort %s hook %s failed, continuing: %v", hookType, hook.Name, err)
+			continue
 		}
+		return fmt.Errorf("%s hook %s failed: %w", hookType, hook.Name, err)
 	}
 	return nil
 }
 
+// runHook runs a single hook, bounded by its timeout when one is set
+func runHook(ctx context.Context, hook Hook, deploymentID, cluster, service string) error {
+	if hook.Timeout <= 0 {
+		return hook.Fn(ctx, deploymentID, cluster, service)
+	}
+
+	hookCtx, cancel := context.WithTimeout(ctx, hook.Timeout)
+	defer cancel()
+
+	err := hook.Fn(hookCtx, deploymentID, cluster, service)
+	if err != nil && ctx.Err() == nil && errors.Is(hookCtx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("timed out after %s: %w", hook.Timeout, context.DeadlineExceeded)
+	}
+	return err
+}
+
+func auditHookFailure(ctx context.Context, hookType HookType, hook Hook, deploymentID, cluster, service string, err error) {
+	auditLogger := audit.GetGlobalAuditLogger()
+	if auditLogger == nil {
+		return
+	}
+	auditLogger.Log(audit.AuditEvent{
+		EventType:    audit.EventHookFailed,
+		DeploymentID: deploymentID,
+		ClusterARN:   cluster,
+		ServiceName:  service,
+		Status:       "failed",
+		ErrorMessage: err.Error(),
+		Metadata: map[string]interface{}{
+			"hook":        hook.Name,
+			"hook_type":   string(hookType),
+			"best_effort": hook.BestEffort,
+		},
+		CorrelationID: util.CorrelationIDFromContext(ctx),
+	})
+}
+
 // Default hooks
 func ValidationHook(ctx context.Context, deploymentID, cluster, service string) error {
-	log.Printf("[HOOK] Validating deployment: %s", deploymentID)
+	util.Logf(ctx, "[HOOK] Validating deployment: %s", deploymentID)
 	if deploymentID == "" || cluster == "" || service == "" {
 		return fmt.Errorf("invalid deployment parameters")
 	}
 	return nil
 }
 
-func HealthCheckHook(ctx context.Context, deploymentID, cluster, service string) error {
-	log.Printf("[HOOK] Running health check for deployment: %s", deploymentID)
-	// In production, this would check service health metrics
+// HealthCheckHook returns a post-deploy hook that asks ECS whether the service
+// converged, catching deployments the API accepted but that never reached the
+// desired task count or finished rolling out
+func HealthCheckHook(e *Executor) func(ctx context.Context, deploymentID, cluster, service string) error {
+	return func(ctx context.Context, deploymentID, cluster, service string) error {
+		util.Logf(ctx, "[HOOK] Running health check for deployment: %s", deploymentID)
+		if err := e.CheckServiceConverged(ctx, cluster, service); err != nil {
+			return err
+		}
+		util.Logf(ctx, "[HOOK] Service %s converged for deployment %s", service, deploymentID)
+		return nil
+	}
+}
+
+// HTTPHealthCheck configures HTTPHealthCheckHook
+type HTTPHealthCheck struct {
+	// URLTemplate may contain {service} and {cluster} placeholders
+	URLTemplate    string
+	ExpectedStatus int
+	Timeout        time.Duration
+	Attempts       int
+	RetryInterval  time.Duration
+}
+
+// HTTPHealthCheckHook returns a post-deploy hook that GETs the service's health
+// URL until it answers with the expected status, failing the deployment once
+// every attempt has failed
+func HTTPHealthCheckHook(check HTTPHealthCheck) func(ctx context.Context, deploymentID, cluster, service string) error {
+	client := &http.Client{Timeout: check.Timeout}
+	if check.ExpectedStatus == 0 {
+		check.ExpectedStatus = http.StatusOK
+	}
+	if check.Attempts < 1 {
+		check.Attempts = 1
+	}
+
+	return func(ctx context.Context, deploymentID, cluster, service string) error {
+		url := strings.NewReplacer("{service}", service, "{cluster}", cluster).Replace(check.URLTemplate)
+		util.Logf(ctx, "[HOOK] Running health check for deployment %s against %s", deploymentID, url)
+
+		var lastErr error
+		for attempt := 1; attempt <= check.Attempts; attempt++ {
+			if attempt > 1 {
+				select {
+				case <-ctx.Done():
+					return ctx.Err()
+				case <-time.After(check.RetryInterval):
+				}
+			}
+
+			lastErr = probeHealth(ctx, client, url, check.ExpectedStatus)
+			if lastErr == nil {
+				util.Logf(ctx, "[HOOK] Health check passed for deployment %s", deploymentID)
+				return nil
+			}
+			util.Logf(ctx, "[HOOK] Health check attempt %d/%d failed for deployment %s: %v", attempt, check.Attempts, deploymentID, lastErr)
+		}
+		return fmt.Errorf("health check %s failed after %d attempts: %w", url, check.Attempts, lastErr)
+	}
+}
+
+func probeHealth(ctx context.Context, client *http.Client, url string, expectedStatus int) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != expectedStatus {
+		return fmt.Errorf("status %d, expected %d", resp.StatusCode, expectedStatus)
+	}
+	return nil
+}
+
+// DependencyHealthHook returns a pre-deploy hook that requires every endpoint
+// to answer with a 2xx status, so deployments don't land in a degraded environment
+func DependencyHealthHook(endpoints []string, timeout time.Duration) func(ctx context.Context, deploymentID, cluster, service string) error {
+	client := &http.Client{Timeout: timeout}
+
+	return func(ctx context.Context, deploymentID, cluster, service string) error {
+		var unhealthy []string
+		for _, endpoint := range endpoints {
+			if err := checkDependency(ctx, client, endpoint); err != nil {
+				util.Logf(ctx, "[HOOK] Dependency %s unhealthy for deployment %s: %v", endpoint, deploymentID, err)
+				unhealthy = append(unhealthy, fmt.Sprintf("%s (%v)", endpoint, err))
+			}
+		}
+
+		if len(unhealthy) > 0 {
+			return fmt.Errorf("%w: %s", ErrDependencyUnhealthy, strings.Join(unhealthy, ", "))
+		}
+		util.Logf(ctx, "[HOOK] All %d dependencies healthy for deployment %s", len(endpoints), deploymentID)
+		return nil
+	}
+}
+
+func checkDependency(ctx context.Context, client *http.Client, endpoint string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("status %d", resp.StatusCode)
+	}
 	return nil
 }
 
+// ExecCommandHook returns a hook that runs command through sh -c with the
+// deployment passed in DEPLOYMENT_ID, CLUSTER and SERVICE. The process is
+// killed when ctx is cancelled; a non-zero exit fails the hook.
+func ExecCommandHook(command string) func(ctx context.Context, deploymentID, cluster, service string) error {
+	return func(ctx context.Context, deploymentID, cluster, service string) error {
+		cmd := exec.CommandContext(ctx, "sh", "-c", command)
+		cmd.Env = append(os.Environ(),
+			"DEPLOYMENT_ID="+deploymentID,
+			"CLUSTER="+cluster,
+			"SERVICE="+service,
+		)
+		// Children of the shell can keep the output pipe open after it is
+		// killed; stop waiting for them shortly after cancellation
+		cmd.WaitDelay = 2 * time.Second
+
+		output, err := cmd.CombinedOutput()
+		if len(output) > 0 {
+			util.Logf(ctx, "[HOOK] %q output for deployment %s:\n%s", command, deploymentID, strings.TrimRight(string(output), "\n"))
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			return fmt.Errorf("command %q failed: %w", command, err)
+		}
+		return nil
+	}
+}
+
 func NotificationHook(ctx context.Context, deploymentID, cluster, service string) error {
-	log.Printf("[HOOK] Sending notification for deployment: %s", deploymentID)
+	util.Logf(ctx, "[HOOK] Sending notification for deployment: %s", deploymentID)
 	// In production, this would send notifications (Slack, email, etc.)
 	return nil
 }
+
+// NotificationPayload is the JSON body posted to the notification webhook.
+// Text makes the payload usable as-is by Slack incoming webhooks.
+type NotificationPayload struct {
+	DeploymentID string    `json:"deployment_id"`
+	ClusterARN   string    `json:"cluster_arn"`
+	ServiceName  string    `json:"service_name"`
+	Outcome      string    `json:"outcome"`
+	Timestamp    time.Time `json:"timestamp"`
+	Text         string    `json:"text"`
+}
+
+// WebhookNotificationHook returns a post-deploy hook that posts a
+// NotificationPayload to url and fails on any non-2xx response
+func WebhookNotificationHook(url string, timeout time.Duration) func(ctx context.Context, deploymentID, cluster, service string) error {
+	client := &http.Client{Timeout: timeout}
+
+	return func(ctx context.Context, deploymentID, cluster, service string) error {
+		payload := NotificationPayload{
+			DeploymentID: deploymentID,
+			ClusterARN:   cluster,
+			ServiceName:  service,
+			Outcome:      "success",
+			Timestamp:    time.Now(),
+			Text:         fmt.Sprintf("Deployment %s of %s succeeded", deploymentID, service),
+		}
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("failed to encode notification: %w", err)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+		if err != nil {
+			return fmt.Errorf("invalid notification URL: %w", err)
+		}
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return fmt.Errorf("notification failed: %w", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("notification webhook returned status %d", resp.StatusCode)
+		}
+		util.Logf(ctx, "[HOOK] Notification for deployment %s delivered", deploymentID)
+		return nil
+	}
+}
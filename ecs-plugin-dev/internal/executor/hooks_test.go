@@ -0,0 +1,113 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// statusServer answers every request with status
+func statusServer(t *testing.T, status int) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestDependencyHealthHook(t *testing.T) {
+	healthy := statusServer(t, http.StatusOK)
+	noContent := statusServer(t, http.StatusNoContent)
+	unavailable := statusServer(t, http.StatusServiceUnavailable)
+
+	tests := []struct {
+		name          string
+		endpoints     []string
+		wantUnhealthy []string
+	}{
+		{name: "all healthy", endpoints: []string{healthy.URL, noContent.URL}},
+		{name: "one unhealthy", endpoints: []string{healthy.URL, unavailable.URL}, wantUnhealthy: []string{unavailable.URL + " (status 503)"}},
+		{name: "unreachable", endpoints: []string{"http://127.0.0.1:1/health"}, wantUnhealthy: []string{"http://127.0.0.1:1/health"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hook := DependencyHealthHook(tt.endpoints, time.Second)
+			err := hook(context.Background(), "deploy-1", "cluster", "web")
+
+			if len(tt.wantUnhealthy) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+			if !errors.Is(err, ErrDependencyUnhealthy) {
+				t.Fatalf("expected ErrDependencyUnhealthy, got %v", err)
+			}
+			for _, want := range tt.wantUnhealthy {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("expected %q in %v", want, err)
+				}
+			}
+			if strings.Contains(err.Error(), healthy.URL+" ") {
+				t.Errorf("expected the healthy endpoint not to be reported, got %v", err)
+			}
+		})
+	}
+}
+
+func TestWebhookNotificationHook(t *testing.T) {
+	var (
+		method      string
+		contentType string
+		payload     NotificationPayload
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, contentType = r.Method, r.Header.Get("Content-Type")
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("failed to decode payload: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	before := time.Now()
+	hook := WebhookNotificationHook(server.URL, time.Second)
+	if err := hook(context.Background(), "deploy-1", "arn:aws:ecs:us-east-1:123456789012:cluster/prod", "web"); err != nil {
+		t.Fatal(err)
+	}
+
+	if method != http.MethodPost || contentType != "application/json" {
+		t.Errorf("expected a JSON POST, got %s with %q", method, contentType)
+	}
+	want := NotificationPayload{
+		DeploymentID: "deploy-1",
+		ClusterARN:   "arn:aws:ecs:us-east-1:123456789012:cluster/prod",
+		ServiceName:  "web",
+		Outcome:      "success",
+		Text:         "Deployment deploy-1 of web succeeded",
+	}
+	got := payload
+	got.Timestamp = time.Time{}
+	if got != want {
+		t.Errorf("unexpected payload:\n got %+v\nwant %+v", got, want)
+	}
+	if payload.Timestamp.Before(before.Add(-time.Second)) || payload.Timestamp.After(time.Now()) {
+		t.Errorf("unexpected timestamp %v", payload.Timestamp)
+	}
+}
+
+func TestWebhookNotificationHookRejected(t *testing.T) {
+	server := statusServer(t, http.StatusInternalServerError)
+
+	err := WebhookNotificationHook(server.URL, time.Second)(context.Background(), "deploy-1", "cluster", "web")
+	if err == nil || !strings.Contains(err.Error(), "returned status 500") {
+		t.Fatalf("expected the 500 to fail the hook, got %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+
+	"ecs-plugin-dev/internal/aws"
+	"ecs-plugin-dev/internal/util"
+)
+
+// DeregisterOldRevisions deregisters the ACTIVE revisions of deployedARN's
+// family beyond the newest keep. deployedARN and every revision the service
+// still runs, in any deployment or task set, are never deregistered. A
+// revision that fails to deregister is logged and skipped; the returned
+// error reports how many failed.
+func (e *Executor) DeregisterOldRevisions(ctx context.Context, cluster, service, deployedARN string, keep int) ([]string, error) {
+	family := aws.TaskDefinitionFamily(deployedARN)
+	if family == "" {
+		return nil, fmt.Errorf("cannot determine task definition family of %q", deployedARN)
+	}
+
+	svc, err := e.ecsClient.DescribeService(ctx, cluster, service)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the revisions in use: %w", err)
+	}
+	inUse := map[string]bool{deployedARN: true}
+	if svc.TaskDefinition != nil {
+		inUse[*svc.TaskDefinition] = true
+	}
+	for _, d := range svc.Deployments {
+		if d.TaskDefinition != nil {
+			inUse[*d.TaskDefinition] = true
+		}
+	}
+	for _, ts := range svc.TaskSets {
+		if ts.TaskDefinition != nil {
+			inUse[*ts.TaskDefinition] = true
+		}
+	}
+
+	revisions, err := e.ecsClient.ListTaskDefinitionRevisions(ctx, family)
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) <= keep {
+		return nil, nil
+	}
+
+	var deregistered []string
+	failed := 0
+	for _, arn := range revisions[keep:] {
+		if inUse[arn] {
+			util.Logf(ctx, "[REVISIONS] Keeping %s: still in use by %s", arn, service)
+			continue
+		}
+		if err := e.ecsClient.DeregisterTaskDefinition(ctx, arn); err != nil {
+			util.Logf(ctx, "[REVISIONS] Warning: %v", err)
+			failed++
+			continue
+		}
+		deregistered = append(deregistered, arn)
+	}
+	if failed > 0 {
+		return deregistered, fmt.Errorf("%d of %d old revisions could not be deregistered", failed, failed+len(deregistered))
+	}
+	return deregistered, nil
+}
@@ -2,11 +2,23 @@ package executor
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
 	"time"
+
+	"ecs-plugin-dev/internal/util"
+
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
 )
 
+// ErrRolloutFailed is returned when ECS marks the service's primary deployment
+// FAILED, typically because the deployment circuit breaker tripped
+var ErrRolloutFailed = errors.New("ecs rollout failed")
+
+// ErrServiceNotConverged is returned when a service is not running its desired
+// number of tasks, or ECS has not finished rolling out one of its deployments
+var ErrServiceNotConverged = errors.New("service unhealthy: not converged")
+
 // ValidateService checks if service exists and is accessible
 func (e *Executor) ValidateService(ctx context.Context, cluster, service string) error {
 	if cluster == "" || service == "" {
@@ -21,9 +33,14 @@ func (e *Executor) WaitForServiceStable(ctx context.Context, cluster, service st
 		timeout = 5 * time.Minute
 	}
 
+	if plan := dryRunPlan(ctx); plan != nil {
+		plan.record("WaitForServiceStable %s (timeout %v)", service, timeout)
+		return nil
+	}
+
 	// Check if mock mode
 	if e.ecsClient == nil {
-		log.Println("[MOCK] Service stability check skipped in mock mode")
+		util.Logf(ctx, "[MOCK] Service stability check skipped in mock mode")
 		return nil
 	}
 
@@ -31,7 +48,7 @@ func (e *Executor) WaitForServiceStable(ctx context.Context, cluster, service st
 	ticker := time.NewTicker(10 * time.Second)
 	defer ticker.Stop()
 
-	log.Printf("[SERVICE] Waiting for service %s to stabilize (timeout: %v)", service, timeout)
+	util.Logf(ctx, "[SERVICE] Waiting for service %s to stabilize (timeout: %v)", service, timeout)
 
 	for {
 		select {
@@ -45,10 +62,15 @@ func (e *Executor) WaitForServiceStable(ctx context.Context, cluster, service st
 			// Use DescribeService for real AWS check
 			svc, err := e.ecsClient.DescribeService(ctx, cluster, service)
 			if err != nil {
-				log.Printf("[SERVICE] Error describing service: %v", err)
+				util.Logf(ctx, "[SERVICE] Error describing service: %v", err)
 				continue
 			}
 
+			if err := failedRollout(svc); err != nil {
+				util.Logf(ctx, "[SERVICE] Service %s rollout failed: %v", service, err)
+				return err
+			}
+
 			// Check if service is stable:
 			// 1. Only one deployment (PRIMARY)
 			// 2. Running count matches desired count
@@ -62,7 +84,7 @@ func (e *Executor) WaitForServiceStable(ctx context.Context, cluster, service st
 				serviceMatch := svc.RunningCount == svc.DesiredCount
 
 				if isPrimary && isCompleted && tasksMatch && serviceMatch {
-					log.Printf("[SERVICE] Service %s is stable: %d/%d tasks running",
+					util.Logf(ctx, "[SERVICE] Service %s is stable: %d/%d tasks running",
 						service, svc.RunningCount, svc.DesiredCount)
 					return nil
 				}
@@ -71,13 +93,61 @@ func (e *Executor) WaitForServiceStable(ctx context.Context, cluster, service st
 				if deployment.Status != nil {
 					status = *deployment.Status
 				}
-				log.Printf("[SERVICE] Service %s not yet stable: status=%s, rollout=%s, running=%d/%d",
+				util.Logf(ctx, "[SERVICE] Service %s not yet stable: status=%s, rollout=%s, running=%d/%d",
 					service, status, deployment.RolloutState,
 					deployment.RunningCount, deployment.DesiredCount)
 			} else {
-				log.Printf("[SERVICE] Service %s has %d deployments, waiting for convergence",
+				util.Logf(ctx, "[SERVICE] Service %s has %d deployments, waiting for convergence",
 					service, len(svc.Deployments))
 			}
 		}
 	}
 }
+
+// failedRollout reports the primary deployment's RolloutStateReason when ECS has
+// given up on it, so callers can roll back without waiting for the timeout
+func failedRollout(svc *types.Service) error {
+	for _, deployment := range svc.Deployments {
+		if deployment.Status == nil || *deployment.Status != "PRIMARY" {
+			continue
+		}
+		if deployment.RolloutState != types.DeploymentRolloutStateFailed {
+			return nil
+		}
+		reason := "no reason reported"
+		if deployment.RolloutStateReason != nil {
+			reason = *deployment.RolloutStateReason
+		}
+		return fmt.Errorf("%w: %s", ErrRolloutFailed, reason)
+	}
+	return nil
+}
+
+// CheckServiceConverged fails unless the service runs its desired number of
+// tasks and every deployment ECS reports a rollout state for has COMPLETED
+func (e *Executor) CheckServiceConverged(ctx context.Context, cluster, service string) error {
+	if dryRunPlan(ctx) != nil {
+		return nil
+	}
+
+	svc, err := e.ecsClient.DescribeService(ctx, cluster, service)
+	if err != nil {
+		return fmt.Errorf("failed to describe service for health check: %w", err)
+	}
+
+	if svc.RunningCount < svc.DesiredCount {
+		return fmt.Errorf("%w: %d/%d tasks running", ErrServiceNotConverged, svc.RunningCount, svc.DesiredCount)
+	}
+	for _, deployment := range svc.Deployments {
+		// Task set (EXTERNAL controller) services report no rollout state
+		if deployment.RolloutState == "" || deployment.RolloutState == types.DeploymentRolloutStateCompleted {
+			continue
+		}
+		status := "UNKNOWN"
+		if deployment.Status != nil {
+			status = *deployment.Status
+		}
+		return fmt.Errorf("%w: %s deployment rollout is %s", ErrServiceNotConverged, status, deployment.RolloutState)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+)
+
+// ServiceSnapshot is a service's state captured before a deployment changed
+// it, so a rollback can put it back exactly rather than guessing which
+// earlier deployment to return to
+type ServiceSnapshot struct {
+	// TaskDefinition is empty for a service that had never been deployed
+	TaskDefinition        string    `json:"task_definition"`
+	DesiredCount          int32     `json:"desired_count"`
+	MinimumHealthyPercent int32     `json:"minimum_healthy_percent,omitempty"`
+	MaximumPercent        int32     `json:"maximum_percent,omitempty"`
+	CircuitBreaker        bool      `json:"circuit_breaker,omitempty"`
+	CapturedAt            time.Time `json:"captured_at"`
+}
+
+// SnapshotServiceState captures a service's task definition, desired count
+// and deployment configuration without changing anything
+func (e *Executor) SnapshotServiceState(ctx context.Context, cluster, service string) (*ServiceSnapshot, error) {
+	if dryRunPlan(ctx) != nil {
+		return &ServiceSnapshot{TaskDefinition: "dry-run", CapturedAt: time.Now()}, nil
+	}
+
+	svc, err := e.ecsClient.DescribeService(ctx, cluster, service)
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot service %s: %w", service, err)
+	}
+
+	snapshot := &ServiceSnapshot{
+		TaskDefinition: aws.ToString(svc.TaskDefinition),
+		DesiredCount:   svc.DesiredCount,
+		CapturedAt:     time.Now(),
+	}
+	if dc := svc.DeploymentConfiguration; dc != nil {
+		snapshot.MinimumHealthyPercent = aws.ToInt32(dc.MinimumHealthyPercent)
+		snapshot.MaximumPercent = aws.ToInt32(dc.MaximumPercent)
+		snapshot.CircuitBreaker = dc.DeploymentCircuitBreaker != nil && dc.DeploymentCircuitBreaker.Enable
+	}
+	return snapshot, nil
+}
@@ -3,6 +3,8 @@ package executor
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 )
 
 // ValidateTaskDefinition validates task definition JSON format
@@ -23,6 +25,93 @@ func (e *Executor) ValidateTaskDefinition(taskDefJSON string) error {
 	return nil
 }
 
+// ValidateTaskDefinitionStrict checks a full task definition document for the
+// fields ECS requires: a family, at least one container with a name and image,
+// and positive cpu/memory values. It returns every problem found.
+func (e *Executor) ValidateTaskDefinitionStrict(taskDefJSON string) []error {
+	var taskDef map[string]interface{}
+	if err := json.Unmarshal([]byte(taskDefJSON), &taskDef); err != nil {
+		return []error{fmt.Errorf("task definition is not a JSON document: %w", err)}
+	}
+
+	var errs []error
+	if family, _ := taskDef["family"].(string); strings.TrimSpace(family) == "" {
+		errs = append(errs, fmt.Errorf("family is required"))
+	}
+	for _, field := range []string{"cpu", "memory"} {
+		if err := validateResourceValue(taskDef, field, false); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	containers, _ := taskDef["containerDefinitions"].([]interface{})
+	if len(containers) == 0 {
+		errs = append(errs, fmt.Errorf("at least one containerDefinitions entry is required"))
+	}
+	for i, entry := range containers {
+		container, ok := entry.(map[string]interface{})
+		if !ok {
+			errs = append(errs, fmt.Errorf("containerDefinitions[%d] must be an object", i))
+			continue
+		}
+		if name, _ := container["name"].(string); name == "" {
+			errs = append(errs, fmt.Errorf("containerDefinitions[%d].name is required", i))
+		}
+		if image, _ := container["image"].(string); image == "" {
+			errs = append(errs, fmt.Errorf("containerDefinitions[%d].image is required", i))
+		}
+		for _, field := range []string{"cpu", "memory", "memoryReservation"} {
+			// Containers may reserve zero CPU units and share the task's CPU
+			if err := validateResourceValue(container, field, field == "cpu"); err != nil {
+				errs = append(errs, fmt.Errorf("containerDefinitions[%d].%w", i, err))
+			}
+		}
+	}
+
+	return errs
+}
+
+// validateResourceValue checks that an optional cpu/memory field is a positive
+// integer (or zero when allowZero is set), given either as a JSON number or a
+// numeric string. Task-level values may instead use ECS units such as "0.5 vCPU" or "2 GB".
+func validateResourceValue(doc map[string]interface{}, field string, allowZero bool) error {
+	raw, ok := doc[field]
+	if !ok {
+		return nil
+	}
+
+	var value float64
+	switch v := raw.(type) {
+	case float64:
+		value = v
+	case string:
+		number := strings.TrimSpace(v)
+		lower := strings.ToLower(number)
+		unit := strings.HasSuffix(lower, "vcpu") || strings.HasSuffix(lower, "gb")
+		if unit {
+			number = strings.TrimSpace(strings.TrimRight(lower, "vcpugb"))
+		}
+		parsed, err := strconv.ParseFloat(number, 64)
+		if err != nil {
+			return fmt.Errorf("%s %q is not a number", field, v)
+		}
+		if unit {
+			if parsed <= 0 {
+				return fmt.Errorf("%s must be positive, got %q", field, v)
+			}
+			return nil
+		}
+		value = parsed
+	default:
+		return fmt.Errorf("%s must be a number", field)
+	}
+
+	if value < 0 || (value == 0 && !allowZero) || value != float64(int64(value)) {
+		return fmt.Errorf("%s must be a positive integer, got %v", field, raw)
+	}
+	return nil
+}
+
 // GetTaskDefinitionName extracts name from ARN or returns as-is
 func (e *Executor) GetTaskDefinitionName(taskDef string) string {
 	// If it's an ARN like arn:aws:ecs:region:account:task-definition/name:version
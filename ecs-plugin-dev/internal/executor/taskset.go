@@ -0,0 +1,203 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ecs/types"
+)
+
+// CreateTaskSetWithScale creates a task set sized as a percentage of desired
+// count, in network when set (see ResolveTaskSetNetwork)
+func (e *Executor) CreateTaskSetWithScale(ctx context.Context, cluster, service, taskDef string, scalePercent float64, network *types.NetworkConfiguration) (string, error) {
+	if plan := dryRunPlan(ctx); plan != nil {
+		if network != nil && network.AwsvpcConfiguration != nil {
+			plan.record("CreateTaskSet scale=%.2f%% subnets=%v", scalePercent, network.AwsvpcConfiguration.Subnets)
+		} else {
+			plan.record("CreateTaskSet scale=%.2f%%", scalePercent)
+		}
+		return "dry-run", nil
+	}
+	return e.ecsClient.CreateTaskSetWithScale(ctx, cluster, service, taskDef, scalePercent, network)
+}
+
+// PrimaryTaskSetID returns the ID of the service's PRIMARY task set, or "" when it has none
+func (e *Executor) PrimaryTaskSetID(ctx context.Context, cluster, service string) (string, error) {
+	if dryRunPlan(ctx) != nil {
+		return "dry-run-primary", nil
+	}
+	return e.ecsClient.PrimaryTaskSetID(ctx, cluster, service)
+}
+
+// PromoteTaskSet makes taskSetID the service's PRIMARY task set
+func (e *Executor) PromoteTaskSet(ctx context.Context, cluster, service, taskSetID string) error {
+	if plan := dryRunPlan(ctx); plan != nil {
+		plan.record("UpdateServicePrimaryTaskSet %s", taskSetID)
+		return nil
+	}
+	return e.ecsClient.UpdateServicePrimaryTaskSet(ctx, cluster, service, taskSetID)
+}
+
+// ResolveTaskSetScale returns the task set scale percentage for a stage.
+// task_set_scale_unit selects PERCENT (default, using the stage percent) or
+// COUNT, which sizes the task set at task_set_count tasks regardless of stage.
+// ECS only accepts PERCENT, so a count is converted against the service's
+// desired count.
+func (e *Executor) ResolveTaskSetScale(ctx context.Context, cluster, service string, config map[string]string, percent int) (float64, error) {
+	switch strings.ToUpper(config["task_set_scale_unit"]) {
+	case "", "PERCENT":
+		return float64(percent), nil
+
+	case "COUNT":
+		count, err := strconv.Atoi(config["task_set_count"])
+		if err != nil {
+			return 0, fmt.Errorf("invalid task_set_count %q: must be an integer", config["task_set_count"])
+		}
+		if count <= 0 {
+			return 0, fmt.Errorf("invalid task_set_count %d: must be positive", count)
+		}
+
+		if dryRunPlan(ctx) != nil {
+			// The desired count is unknown without calling ECS; plan with the stage percent
+			return float64(percent), nil
+		}
+
+		svc, err := e.ecsClient.DescribeService(ctx, cluster, service)
+		if err != nil {
+			return 0, fmt.Errorf("failed to describe service: %w", err)
+		}
+		if count > int(svc.DesiredCount) {
+			return 0, fmt.Errorf("invalid task_set_count %d: exceeds service desired count %d", count, svc.DesiredCount)
+		}
+
+		return float64(count) / float64(svc.DesiredCount) * 100, nil
+
+	default:
+		return 0, fmt.Errorf("invalid task_set_scale_unit %q: must be PERCENT or COUNT", config["task_set_scale_unit"])
+	}
+}
+
+// ResolveTaskSetNetwork returns the network configuration for new task sets.
+// The subnets, security_groups and assign_public_ip deployment config keys
+// take precedence; otherwise the configuration is copied from the service, or
+// from its primary task set for services using the EXTERNAL deployment
+// controller. Without either, awsvpc task definitions (including every
+// Fargate one) are rejected, since ECS would refuse the task set.
+func (e *Executor) ResolveTaskSetNetwork(ctx context.Context, cluster, service, taskDef string, config map[string]string) (*types.NetworkConfiguration, error) {
+	if network, err := parseTaskSetNetwork(config); network != nil || err != nil {
+		return network, err
+	}
+	if dryRunPlan(ctx) != nil {
+		return nil, nil
+	}
+
+	svc, err := e.ecsClient.DescribeService(ctx, cluster, service)
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe service: %w", err)
+	}
+	if svc.NetworkConfiguration != nil {
+		return svc.NetworkConfiguration, nil
+	}
+	var fallback *types.NetworkConfiguration
+	for _, taskSet := range svc.TaskSets {
+		if taskSet.NetworkConfiguration == nil {
+			continue
+		}
+		if aws.ToString(taskSet.Status) == "PRIMARY" {
+			return taskSet.NetworkConfiguration, nil
+		}
+		if fallback == nil {
+			fallback = taskSet.NetworkConfiguration
+		}
+	}
+	if fallback != nil {
+		return fallback, nil
+	}
+
+	awsvpc, err := e.usesAWSVPC(ctx, taskDef, svc)
+	if err != nil {
+		return nil, err
+	}
+	if awsvpc {
+		return nil, fmt.Errorf("a network configuration is required for awsvpc (Fargate) task sets, but service %s has none to copy; set subnets and security_groups in the deployment config", service)
+	}
+	return nil, nil
+}
+
+// parseTaskSetNetwork builds an awsvpc configuration from the subnets,
+// security_groups and assign_public_ip config keys, or returns nil when none
+// is set
+func parseTaskSetNetwork(config map[string]string) (*types.NetworkConfiguration, error) {
+	subnets := splitList(config["subnets"])
+	securityGroups := splitList(config["security_groups"])
+	publicIP, hasPublicIP := config["assign_public_ip"]
+	if len(subnets) == 0 && len(securityGroups) == 0 && !hasPublicIP {
+		return nil, nil
+	}
+	if len(subnets) == 0 {
+		return nil, fmt.Errorf("invalid network config: subnets is required with security_groups or assign_public_ip")
+	}
+
+	vpc := &types.AwsVpcConfiguration{
+		Subnets:        subnets,
+		SecurityGroups: securityGroups,
+		AssignPublicIp: types.AssignPublicIpDisabled,
+	}
+	if hasPublicIP {
+		enabled, err := strconv.ParseBool(publicIP)
+		if err != nil {
+			return nil, fmt.Errorf("invalid assign_public_ip %q: must be true or false", publicIP)
+		}
+		if enabled {
+			vpc.AssignPublicIp = types.AssignPublicIpEnabled
+		}
+	}
+	return &types.NetworkConfiguration{AwsvpcConfiguration: vpc}, nil
+}
+
+// usesAWSVPC reports whether tasks of taskDef, a task definition document or
+// ARN, need awsvpc networking on svc
+func (e *Executor) usesAWSVPC(ctx context.Context, taskDef string, svc *types.Service) (bool, error) {
+	if svc.LaunchType == types.LaunchTypeFargate {
+		return true, nil
+	}
+	for _, item := range svc.CapacityProviderStrategy {
+		if strings.HasPrefix(aws.ToString(item.CapacityProvider), "FARGATE") {
+			return true, nil
+		}
+	}
+
+	var doc struct {
+		NetworkMode             string   `json:"networkMode"`
+		RequiresCompatibilities []string `json:"requiresCompatibilities"`
+	}
+	if err := json.Unmarshal([]byte(taskDef), &doc); err == nil {
+		for _, compatibility := range doc.RequiresCompatibilities {
+			if compatibility == string(types.CompatibilityFargate) {
+				return true, nil
+			}
+		}
+		return doc.NetworkMode == string(types.NetworkModeAwsvpc), nil
+	}
+
+	td, err := e.ecsClient.DescribeTaskDefinition(ctx, taskDef)
+	if err != nil {
+		return false, err
+	}
+	return td.NetworkMode == types.NetworkModeAwsvpc, nil
+}
+
+// splitList splits a comma-separated config value, dropping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,48 @@
+package executor
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestResolveTaskSetScale(t *testing.T) {
+	t.Setenv("MOCK_MODE", "true")
+	// The mock service runs a desired count of 2
+	e := NewExecutor()
+
+	tests := []struct {
+		name    string
+		config  map[string]string
+		percent int
+		want    float64
+		wantErr string
+	}{
+		{name: "percent by default", config: map[string]string{}, percent: 20, want: 20},
+		{name: "percent", config: map[string]string{"task_set_scale_unit": "percent"}, percent: 50, want: 50},
+		{name: "count", config: map[string]string{"task_set_scale_unit": "COUNT", "task_set_count": "1"}, percent: 20, want: 50},
+		{name: "count of the whole service", config: map[string]string{"task_set_scale_unit": "COUNT", "task_set_count": "2"}, percent: 20, want: 100},
+		{name: "count over desired", config: map[string]string{"task_set_scale_unit": "COUNT", "task_set_count": "3"}, wantErr: "exceeds service desired count 2"},
+		{name: "count not positive", config: map[string]string{"task_set_scale_unit": "COUNT", "task_set_count": "0"}, wantErr: "must be positive"},
+		{name: "count missing", config: map[string]string{"task_set_scale_unit": "COUNT"}, wantErr: "must be an integer"},
+		{name: "unknown unit", config: map[string]string{"task_set_scale_unit": "TASKS"}, wantErr: "must be PERCENT or COUNT"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := e.ResolveTaskSetScale(context.Background(), "cluster", "web", tt.config, tt.percent)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected an error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected scale %v%%, got %v%%", tt.want, got)
+			}
+		})
+	}
+}
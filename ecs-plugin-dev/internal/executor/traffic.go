@@ -3,6 +3,9 @@ package executor
 import (
 	"context"
 	"fmt"
+	"time"
+
+	"ecs-plugin-dev/internal/aws"
 )
 
 // ShiftTraffic gradually shifts traffic between task sets
@@ -25,3 +28,46 @@ func (e *Executor) ValidateTrafficConfig(canaryWeight, primaryWeight int) error
 	}
 	return nil
 }
+
+// CreateCanaryHeaderRule forwards requests carrying header: value on the
+// listener selected by routing straight to the canary target group, taking
+// rule priority or the lowest free one when it is zero. It returns the
+// rule's ARN for DeleteListenerRule.
+func (e *Executor) CreateCanaryHeaderRule(ctx context.Context, cluster, service string, routing aws.TrafficRouting, header, value string, priority int) (string, error) {
+	if plan := dryRunPlan(ctx); plan != nil {
+		plan.record("CreateRule %s: %s -> canary target group (priority %d)", header, value, priority)
+		return "", nil
+	}
+	groups, err := e.elbClient.ResolveTargetGroups(ctx, cluster, service, routing)
+	if err != nil {
+		return "", err
+	}
+	return e.elbClient.CreateHeaderRule(ctx, groups.ListenerARN, header, value, groups.Canary, priority)
+}
+
+// DeleteListenerRule removes a rule created by CreateCanaryHeaderRule
+func (e *Executor) DeleteListenerRule(ctx context.Context, ruleArn string) error {
+	if plan := dryRunPlan(ctx); plan != nil {
+		plan.record("DeleteRule %s", ruleArn)
+		return nil
+	}
+	return e.elbClient.DeleteListenerRule(ctx, ruleArn)
+}
+
+// CanaryTrafficStats reads what the canary and primary target groups selected
+// by routing served over the window ending now. Dry runs report no traffic.
+func (e *Executor) CanaryTrafficStats(ctx context.Context, cluster, service string, routing aws.TrafficRouting, window time.Duration) (canary, baseline aws.TargetGroupStats, err error) {
+	if plan := dryRunPlan(ctx); plan != nil {
+		plan.record("Compare canary and primary target group metrics over %v", window)
+		return canary, baseline, nil
+	}
+	groups, err := e.elbClient.ResolveTargetGroups(ctx, cluster, service, routing)
+	if err != nil {
+		return canary, baseline, err
+	}
+	stats, err := e.cwClient.GetTargetGroupStats(ctx, groups.ListenerARN, []string{groups.Canary, groups.Primary}, window)
+	if err != nil {
+		return canary, baseline, err
+	}
+	return stats[groups.Canary], stats[groups.Primary], nil
+}
@@ -0,0 +1,223 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"ecs-plugin-dev/internal/config"
+	"ecs-plugin-dev/internal/tracing"
+	"ecs-plugin-dev/internal/util"
+	pb "ecs-plugin-dev/proto"
+
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxGatewayBody caps the size of a JSON request body
+const maxGatewayBody = 1 << 20
+
+var (
+	gatewayUnmarshal = protojson.UnmarshalOptions{DiscardUnknown: true}
+	gatewayMarshal   = protojson.MarshalOptions{UseProtoNames: true}
+)
+
+// gateway serves a subset of the deployment service as HTTP/JSON for tools
+// that do not speak gRPC. Bodies are the same proto messages in JSON form.
+type gateway struct {
+	server  *DeploymentServer
+	auth    *authenticator
+	limiter *RateLimiter
+	timeout time.Duration
+}
+
+// NewGateway returns an HTTP handler exposing Deploy, GetStatus, Rollback,
+// Cancel and RetryDeployment as POST /deploy, GET /status/{id},
+// POST /rollback/{id}, POST /cancel/{id} and POST /retry/{id}. Callers authenticate exactly as they would over gRPC
+// when auth tokens are configured, and draw on limiter, when not nil, under
+// the same method names.
+func NewGateway(s *DeploymentServer, cfg config.ServerConfig, limiter *RateLimiter) http.Handler {
+	g := &gateway{
+		server:  s,
+		limiter: limiter,
+		timeout: cfg.RequestTimeout,
+	}
+	if len(cfg.AuthTokens) > 0 {
+		g.auth = newAuthenticator(cfg.AuthTokens, cfg.AuthExemptMethods)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /deploy", g.handle("Deploy", func(ctx context.Context, r *http.Request) (proto.Message, error) {
+		req := &pb.DeployRequest{}
+		if err := decodeBody(r, req); err != nil {
+			return nil, err
+		}
+		return s.Deploy(ctx, req)
+	}))
+	mux.HandleFunc("GET /status/{id}", g.handle("GetStatus", func(ctx context.Context, r *http.Request) (proto.Message, error) {
+		return s.GetStatus(ctx, &pb.StatusRequest{DeploymentId: r.PathValue("id")})
+	}))
+	mux.HandleFunc("POST /rollback/{id}", g.handle("Rollback", func(ctx context.Context, r *http.Request) (proto.Message, error) {
+		req := &pb.RollbackRequest{}
+		if err := decodeBody(r, req); err != nil {
+			return nil, err
+		}
+		req.DeploymentId = r.PathValue("id")
+		return s.Rollback(ctx, req)
+	}))
+	mux.HandleFunc("POST /cancel/{id}", g.handle("Cancel", func(ctx context.Context, r *http.Request) (proto.Message, error) {
+		return s.Cancel(ctx, &pb.CancelRequest{DeploymentId: r.PathValue("id")})
+	}))
+	mux.HandleFunc("POST /retry/{id}", g.handle("RetryDeployment", func(ctx context.Context, r *http.Request) (proto.Message, error) {
+		req := &pb.RetryRequest{}
+		if err := decodeBody(r, req); err != nil {
+			return nil, err
+		}
+		req.DeploymentId = r.PathValue("id")
+		return s.RetryDeployment(ctx, req)
+	}))
+	return mux
+}
+
+// handle authenticates a request as a call to method, runs call and writes
+// its response as JSON with an HTTP status matching the outcome. Correlation
+// IDs are taken from X-Correlation-Id or X-Request-Id as over gRPC.
+func (g *gateway) handle(method string, call func(ctx context.Context, r *http.Request) (proto.Message, error)) http.HandlerFunc {
+	fullMethod := "/" + pb.DeploymentService_ServiceDesc.ServiceName + "/" + method
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get("X-Correlation-Id")
+		if !validCorrelationID(id) {
+			id = r.Header.Get("X-Request-Id")
+		}
+		if !validCorrelationID(id) {
+			id = util.NewCorrelationID()
+		}
+		w.Header().Set("X-Correlation-Id", id)
+
+		ctx, span := tracing.StartServer(util.WithCorrelationID(r.Context(), id), propagation.HeaderCarrier(r.Header), fullMethod,
+			attribute.String("http.method", r.Method),
+			attribute.String("http.route", r.Pattern),
+			attribute.String("correlation_id", id),
+		)
+		defer span.End()
+
+		if g.timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, g.timeout)
+			defer cancel()
+		}
+
+		if g.auth != nil {
+			md := metadata.Pairs("authorization", r.Header.Get("Authorization"), "x-api-key", r.Header.Get("X-Api-Key"))
+			authed, err := g.auth.check(metadata.NewIncomingContext(ctx, md), fullMethod)
+			if err != nil {
+				span.SetStatus(otelcodes.Error, err.Error())
+				writeGatewayError(w, err)
+				return
+			}
+			ctx = authed
+		}
+
+		// Limited after authentication, as over gRPC
+		if g.limiter != nil {
+			if err := g.limiter.allow(fullMethod); err != nil {
+				span.SetStatus(otelcodes.Error, err.Error())
+				writeGatewayError(w, err)
+				return
+			}
+		}
+
+		util.Logf(ctx, "[GATEWAY] %s %s", r.Method, r.URL.Path)
+		resp, err := call(ctx, r)
+		if err != nil {
+			span.SetStatus(otelcodes.Error, err.Error())
+			writeGatewayError(w, err)
+			return
+		}
+		code := httpStatus(resp)
+		span.SetAttributes(attribute.Int("http.status_code", code))
+		if code >= http.StatusInternalServerError {
+			span.SetStatus(otelcodes.Error, http.StatusText(code))
+		}
+		writeGatewayResponse(w, code, resp)
+	}
+}
+
+// decodeBody reads a JSON request body into msg; an empty body leaves msg unset
+func decodeBody(r *http.Request, msg proto.Message) error {
+	body, err := io.ReadAll(http.MaxBytesReader(nil, r.Body, maxGatewayBody))
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "failed to read request body: %v", err)
+	}
+	if len(body) == 0 {
+		return nil
+	}
+	if err := gatewayUnmarshal.Unmarshal(body, msg); err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid JSON request: %v", err)
+	}
+	return nil
+}
+
+// httpStatus picks the HTTP status for a response: 200 unless it reports a
+// failed call, in which case its error code decides
+func httpStatus(resp proto.Message) int {
+	errorCode, _, _, failed := responseError(resp)
+	if !failed {
+		return http.StatusOK
+	}
+	return httpStatusFromCode(grpcCode(errorCode))
+}
+
+// httpStatusFromCode maps a gRPC status code to the closest HTTP status
+func httpStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.Aborted, codes.FailedPrecondition:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.Canceled:
+		return 499 // Client closed request
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeGatewayError(w http.ResponseWriter, err error) {
+	st := status.Convert(err)
+	writeGatewayResponse(w, httpStatusFromCode(st.Code()), st.Proto())
+}
+
+func writeGatewayResponse(w http.ResponseWriter, code int, msg proto.Message) {
+	body, err := gatewayMarshal.Marshal(msg)
+	if err != nil {
+		log.Printf("[GATEWAY] Failed to encode response: %v", err)
+		http.Error(w, "failed to encode response", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	w.Write(body)
+}
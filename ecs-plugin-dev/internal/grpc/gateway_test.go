@@ -0,0 +1,36 @@
+package grpc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"ecs-plugin-dev/internal/config"
+)
+
+func TestGatewayRateLimit(t *testing.T) {
+	cfg := config.DefaultConfig()
+	limiter := NewRateLimiter(0.001, 1, true)
+	handler := NewGateway(NewDeploymentServerWithConfig(cfg), cfg.Server, limiter)
+
+	call := func(method, path string) int {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(method, path, nil))
+		return rec.Code
+	}
+
+	if code := call(http.MethodGet, "/status/unknown"); code == http.StatusTooManyRequests {
+		t.Fatal("first status call was rate limited")
+	}
+	if code := call(http.MethodGet, "/status/unknown"); code != http.StatusTooManyRequests {
+		t.Fatalf("expected the second status call to get 429, got %d", code)
+	}
+	// Per-method buckets leave other methods their own budget
+	if code := call(http.MethodPost, "/cancel/unknown"); code == http.StatusTooManyRequests {
+		t.Fatal("cancel was limited by the status bucket")
+	}
+	// The gRPC interceptor draws on the same buckets
+	if err := limiter.allow("/deployment.DeploymentService/Cancel"); err == nil {
+		t.Fatal("expected the gateway's cancel call to have used the shared bucket")
+	}
+}
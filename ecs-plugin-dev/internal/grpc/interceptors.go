@@ -2,13 +2,24 @@ package grpc
 
 import (
 	"context"
-	"log"
+	"crypto/subtle"
+	"errors"
+	"strings"
+	"sync"
 	"time"
 
 	"ecs-plugin-dev/internal/metrics"
+	"ecs-plugin-dev/internal/tracing"
+	"ecs-plugin-dev/internal/util"
+	pb "ecs-plugin-dev/proto"
 
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/time/rate"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 )
 
@@ -17,15 +28,15 @@ func LoggingInterceptor() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 		start := time.Now()
 
-		log.Printf("[gRPC] %s started", info.FullMethod)
+		util.Logf(ctx, "[gRPC] %s started", info.FullMethod)
 
 		resp, err := handler(ctx, req)
 
 		duration := time.Since(start)
 		if err != nil {
-			log.Printf("[gRPC] %s failed: %v (duration: %v)", info.FullMethod, err, duration)
+			util.Logf(ctx, "[gRPC] %s failed: %v (duration: %v)", info.FullMethod, err, duration)
 		} else {
-			log.Printf("[gRPC] %s completed (duration: %v)", info.FullMethod, duration)
+			util.Logf(ctx, "[gRPC] %s completed (duration: %v)", info.FullMethod, duration)
 		}
 
 		return resp, err
@@ -58,7 +69,7 @@ func RecoveryInterceptor() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
 		defer func() {
 			if r := recover(); r != nil {
-				log.Printf("[PANIC] %s: %v", info.FullMethod, r)
+				util.Logf(ctx, "[PANIC] %s: %v", info.FullMethod, r)
 				metrics.RecordError("grpc_server", "panic")
 				err = status.Errorf(codes.Internal, "internal server error")
 			}
@@ -67,3 +78,385 @@ func RecoveryInterceptor() grpc.UnaryServerInterceptor {
 		return handler(ctx, req)
 	}
 }
+
+// AuthInterceptor rejects unary calls that do not carry one of tokens as a
+// bearer token ("authorization: Bearer <token>") or API key ("x-api-key"),
+// except for exempt methods. Entries in exempt ending in "/" match a service.
+func AuthInterceptor(tokens map[string]string, exempt []string) grpc.UnaryServerInterceptor {
+	auth := newAuthenticator(tokens, exempt)
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := auth.check(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// AuthStreamInterceptor applies AuthInterceptor's checks to streaming calls
+func AuthStreamInterceptor(tokens map[string]string, exempt []string) grpc.StreamServerInterceptor {
+	auth := newAuthenticator(tokens, exempt)
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := auth.check(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// contextStream replaces a stream's context with one an interceptor extended
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+// correlationHeaders are the metadata keys a caller may set its own
+// correlation ID under, in order of preference
+var correlationHeaders = []string{"x-correlation-id", "x-request-id"}
+
+// maxCorrelationIDLen bounds a caller-supplied correlation ID
+const maxCorrelationIDLen = 128
+
+// CorrelationInterceptor attaches a correlation ID to every unary call,
+// taken from the caller's x-correlation-id (or x-request-id) metadata or
+// newly minted, and echoes it back in the x-correlation-id response header
+func CorrelationInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		id := incomingCorrelationID(ctx)
+		grpc.SetHeader(ctx, metadata.Pairs(correlationHeaders[0], id))
+		return handler(util.WithCorrelationID(ctx, id), req)
+	}
+}
+
+// CorrelationStreamInterceptor applies CorrelationInterceptor to streaming calls
+func CorrelationStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		id := incomingCorrelationID(ss.Context())
+		ss.SetHeader(metadata.Pairs(correlationHeaders[0], id))
+		return handler(srv, &contextStream{ServerStream: ss, ctx: util.WithCorrelationID(ss.Context(), id)})
+	}
+}
+
+// TracingInterceptor opens a server span around every unary call, continuing
+// the caller's trace when its metadata carries a traceparent. Calls answered
+// with a success=false response are marked failed like returned errors.
+func TracingInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, span := startRPCSpan(ctx, info.FullMethod)
+		resp, err := handler(ctx, req)
+
+		spanErr := err
+		if errorCode, message, _, failed := responseError(resp); err == nil && failed {
+			span.SetAttributes(attribute.String("error_code", errorCode))
+			spanErr = errors.New(message)
+		}
+		tracing.End(span, spanErr)
+		return resp, err
+	}
+}
+
+// TracingStreamInterceptor applies TracingInterceptor to streaming calls
+func TracingStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, span := startRPCSpan(ss.Context(), info.FullMethod)
+		err := handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+		tracing.End(span, err)
+		return err
+	}
+}
+
+// startRPCSpan opens the server span for a call to fullMethod
+func startRPCSpan(ctx context.Context, fullMethod string) (context.Context, trace.Span) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	service, method, _ := strings.Cut(strings.TrimPrefix(fullMethod, "/"), "/")
+	return tracing.StartServer(ctx, metadataCarrier(md), fullMethod,
+		attribute.String("rpc.system", "grpc"),
+		attribute.String("rpc.service", service),
+		attribute.String("rpc.method", method),
+		attribute.String("correlation_id", util.CorrelationIDFromContext(ctx)),
+	)
+}
+
+// metadataCarrier lets trace context propagators read gRPC metadata
+type metadataCarrier metadata.MD
+
+func (c metadataCarrier) Get(key string) string {
+	if values := metadata.MD(c).Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+func (c metadataCarrier) Set(key, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+func (c metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// incomingCorrelationID returns the caller's correlation ID if it sent a
+// usable one, or a new one otherwise
+func incomingCorrelationID(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		for _, key := range correlationHeaders {
+			if values := md.Get(key); len(values) > 0 && validCorrelationID(values[0]) {
+				return values[0]
+			}
+		}
+	}
+	return util.NewCorrelationID()
+}
+
+// validCorrelationID accepts short IDs of printable ASCII without spaces, so
+// a caller cannot break up log lines with its ID
+func validCorrelationID(id string) bool {
+	if id == "" || len(id) > maxCorrelationIDLen {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+type userContextKey struct{}
+
+// ContextWithUser records the authenticated principal making a call
+func ContextWithUser(ctx context.Context, user string) context.Context {
+	return context.WithValue(ctx, userContextKey{}, user)
+}
+
+// UserFromContext returns the authenticated principal making a call, if any
+func UserFromContext(ctx context.Context) (string, bool) {
+	user, ok := ctx.Value(userContextKey{}).(string)
+	return user, ok && user != ""
+}
+
+type authenticator struct {
+	tokens map[string]string // Principal -> token
+	exempt []string
+}
+
+func newAuthenticator(tokens map[string]string, exempt []string) *authenticator {
+	return &authenticator{tokens: tokens, exempt: exempt}
+}
+
+// check returns codes.Unauthenticated unless the call is exempt or carries a
+// configured token, in which case the returned context names its principal
+func (a *authenticator) check(ctx context.Context, method string) (context.Context, error) {
+	if a.isExempt(method) {
+		return ctx, nil
+	}
+
+	token := tokenFromMetadata(ctx)
+	if token == "" {
+		return nil, status.Error(codes.Unauthenticated, "missing bearer token or API key")
+	}
+	user, ok := a.principal(token)
+	if !ok {
+		util.Logf(ctx, "[AUTH] Rejected invalid credentials for %s", method)
+		metrics.RecordError("grpc_server", "unauthenticated")
+		return nil, status.Error(codes.Unauthenticated, "invalid bearer token or API key")
+	}
+	return ContextWithUser(ctx, user), nil
+}
+
+func (a *authenticator) isExempt(method string) bool {
+	for _, entry := range a.exempt {
+		if method == entry || (strings.HasSuffix(entry, "/") && strings.HasPrefix(method, entry)) {
+			return true
+		}
+	}
+	return false
+}
+
+// principal finds the principal owning token, comparing in constant time
+func (a *authenticator) principal(token string) (string, bool) {
+	var found string
+	for name, candidate := range a.tokens {
+		if subtle.ConstantTimeCompare([]byte(token), []byte(candidate)) == 1 {
+			found = name
+		}
+	}
+	return found, found != ""
+}
+
+// tokenFromMetadata extracts a bearer token or API key from incoming metadata
+func tokenFromMetadata(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	for _, value := range md.Get("authorization") {
+		if scheme, token, ok := strings.Cut(value, " "); ok && strings.EqualFold(scheme, "bearer") {
+			return strings.TrimSpace(token)
+		}
+	}
+	if keys := md.Get("x-api-key"); len(keys) > 0 {
+		return strings.TrimSpace(keys[0])
+	}
+	return ""
+}
+
+// RateLimiter is a token bucket allowing rps calls a second with bursts of
+// up to burst. With perMethod every method gets its own bucket; otherwise
+// all methods share one. The gRPC server and the HTTP gateway share a
+// limiter, so a method's budget covers calls over both.
+type RateLimiter struct {
+	rps       float64
+	burst     int
+	perMethod bool
+
+	mu      sync.Mutex
+	shared  *rate.Limiter
+	methods map[string]*rate.Limiter
+}
+
+// NewRateLimiter creates a limiter for RateLimitInterceptor and NewGateway
+func NewRateLimiter(rps float64, burst int, perMethod bool) *RateLimiter {
+	return &RateLimiter{
+		rps:       rps,
+		burst:     burst,
+		perMethod: perMethod,
+		shared:    rate.NewLimiter(rate.Limit(rps), burst),
+		methods:   make(map[string]*rate.Limiter),
+	}
+}
+
+// allow takes a token for a call to fullMethod, returning a
+// ResourceExhausted error when none is left
+func (l *RateLimiter) allow(fullMethod string) error {
+	limiter := l.shared
+	if l.perMethod {
+		l.mu.Lock()
+		var ok bool
+		if limiter, ok = l.methods[fullMethod]; !ok {
+			limiter = rate.NewLimiter(rate.Limit(l.rps), l.burst)
+			l.methods[fullMethod] = limiter
+		}
+		l.mu.Unlock()
+	}
+	if !limiter.Allow() {
+		metrics.RecordError("grpc_server", "rate_limited")
+		return status.Errorf(codes.ResourceExhausted, "rate limit exceeded for %s", fullMethod)
+	}
+	return nil
+}
+
+// RateLimitInterceptor rejects unary calls limiter has no token for with
+// codes.ResourceExhausted. Health checks are never limited.
+func RateLimitInterceptor(limiter *RateLimiter) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if strings.HasPrefix(info.FullMethod, "/grpc.health.v1.Health/") {
+			return handler(ctx, req)
+		}
+		if err := limiter.allow(info.FullMethod); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// TimeoutInterceptor bounds each unary call to max, replacing a missing or
+// later client deadline. Only the handler is bounded: deployments started by
+// Deploy run on a context detached from the call and are unaffected.
+func TimeoutInterceptor(max time.Duration) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) > max {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, max)
+			defer cancel()
+		}
+		return handler(ctx, req)
+	}
+}
+
+// errorDomain identifies this server in the ErrorInfo details of status errors
+const errorDomain = "ecs-plugin"
+
+// failedResponse is implemented by responses that report failure with
+// success=false and a classifyError code
+type failedResponse interface {
+	GetSuccess() bool
+	GetErrorCode() string
+	GetMessage() string
+}
+
+// StatusErrorInterceptor gives failed responses a gRPC status code derived
+// from their error_code. With asErrors the call fails with that status, whose
+// ErrorInfo detail carries the error code, so clients can use status.FromError;
+// otherwise the success=false response is returned unchanged and the codes are
+// sent in the x-error-code and x-grpc-code trailers.
+func StatusErrorInterceptor(asErrors bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+
+		errorCode, message, details, ok := responseError(resp)
+		if !ok {
+			return resp, nil
+		}
+		code := grpcCode(errorCode)
+
+		if !asErrors {
+			trailer := metadata.Pairs("x-error-code", errorCode, "x-grpc-code", code.String())
+			if err := grpc.SetTrailer(ctx, trailer); err != nil {
+				util.Logf(ctx, "[gRPC] Could not set error trailer for %s: %v", info.FullMethod, err)
+			}
+			return resp, nil
+		}
+
+		st := status.New(code, message)
+		errorInfo := &errdetails.ErrorInfo{Reason: errorCode, Domain: errorDomain}
+		if details != "" {
+			errorInfo.Metadata = map[string]string{"details": details}
+		}
+		if withDetails, detailErr := st.WithDetails(errorInfo); detailErr == nil {
+			st = withDetails
+		} else {
+			util.Logf(ctx, "[gRPC] Could not attach error details for %s: %v", info.FullMethod, detailErr)
+		}
+		return nil, st.Err()
+	}
+}
+
+// responseError reports the error code, message and details of a response
+// describing a failed call. Status lookups succeed even for failed
+// deployments, so they only count as failed when the lookup itself did.
+func responseError(resp interface{}) (errorCode, message, details string, failed bool) {
+	switch r := resp.(type) {
+	case *pb.StatusResponse:
+		if r.GetStatus() != "UNKNOWN" || r.GetErrorCode() == "" {
+			return "", "", "", false
+		}
+		return r.GetErrorCode(), r.GetMessage(), r.GetErrorDetails(), true
+	case *pb.BatchStatusResponse:
+		if r.GetErrorCode() == "" {
+			return "", "", "", false
+		}
+		return r.GetErrorCode(), r.GetMessage(), "", true
+	case failedResponse:
+		if r.GetSuccess() || r.GetErrorCode() == "" {
+			return "", "", "", false
+		}
+		if d, ok := resp.(interface{ GetErrorDetails() string }); ok {
+			details = d.GetErrorDetails()
+		}
+		return r.GetErrorCode(), r.GetMessage(), details, true
+	}
+	return "", "", "", false
+}
@@ -0,0 +1,273 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	pb "ecs-plugin-dev/proto"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// trailerStream captures the trailers an interceptor sets
+type trailerStream struct {
+	trailer metadata.MD
+}
+
+func (s *trailerStream) Method() string                  { return "/deployment.DeploymentService/Test" }
+func (s *trailerStream) SetHeader(md metadata.MD) error  { return nil }
+func (s *trailerStream) SendHeader(md metadata.MD) error { return nil }
+func (s *trailerStream) SetTrailer(md metadata.MD) error {
+	s.trailer = metadata.Join(s.trailer, md)
+	return nil
+}
+
+// callWithResponse runs interceptor around a handler returning resp
+func callWithResponse(interceptor grpc.UnaryServerInterceptor, resp interface{}) (interface{}, metadata.MD, error) {
+	stream := &trailerStream{}
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+	info := &grpc.UnaryServerInfo{FullMethod: stream.Method()}
+	got, err := interceptor(ctx, nil, info, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return resp, nil
+	})
+	return got, stream.trailer, err
+}
+
+func TestStatusErrorInterceptorAsErrors(t *testing.T) {
+	errorCode, details := classifyError(errors.New("deployment not found: abc"))
+	tests := []struct {
+		name        string
+		resp        interface{}
+		wantCode    codes.Code
+		wantReason  string
+		wantDetails string
+	}{
+		{name: "failed deploy", resp: &pb.DeployResponse{Success: false, Message: "nope", ErrorCode: errorCode, ErrorDetails: details}, wantCode: codes.NotFound, wantReason: "NOT_FOUND", wantDetails: details},
+		{name: "unknown status lookup", resp: &pb.StatusResponse{Status: "UNKNOWN", Message: "nope", ErrorCode: errorCode, ErrorDetails: details}, wantCode: codes.NotFound, wantReason: "NOT_FOUND", wantDetails: details},
+		{name: "failed batch lookup", resp: &pb.BatchStatusResponse{Message: "nope", ErrorCode: "VALIDATION_ERROR"}, wantCode: codes.InvalidArgument, wantReason: "VALIDATION_ERROR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, trailer, err := callWithResponse(StatusErrorInterceptor(true), tt.resp)
+			if resp != nil {
+				t.Errorf("expected the response body to be dropped, got %v", resp)
+			}
+			st, ok := status.FromError(err)
+			if !ok || st.Code() != tt.wantCode || st.Message() != "nope" {
+				t.Fatalf("expected %v status with the response message, got %v", tt.wantCode, err)
+			}
+			if len(trailer) != 0 {
+				t.Errorf("expected no trailers, got %v", trailer)
+			}
+
+			var info *errdetails.ErrorInfo
+			for _, detail := range st.Details() {
+				if d, ok := detail.(*errdetails.ErrorInfo); ok {
+					info = d
+				}
+			}
+			if info == nil || info.Reason != tt.wantReason || info.Domain != errorDomain || info.Metadata["details"] != tt.wantDetails {
+				t.Errorf("expected ErrorInfo reason %s with details %q, got %v", tt.wantReason, tt.wantDetails, info)
+			}
+		})
+	}
+}
+
+func TestStatusErrorInterceptorTrailers(t *testing.T) {
+	failed := &pb.RollbackResponse{Success: false, Message: "nope", ErrorCode: "NO_PREVIOUS_DEPLOYMENT", PartialSuccess: true}
+	resp, trailer, err := callWithResponse(StatusErrorInterceptor(false), failed)
+	if err != nil {
+		t.Fatalf("expected the call to complete, got %v", err)
+	}
+	// status.FromError sees an OK call; only the trailer carries the code
+	if st := status.Convert(err); st.Code() != codes.OK {
+		t.Errorf("expected codes.OK, got %v", st.Code())
+	}
+	if resp != failed {
+		t.Errorf("expected the success=false response unchanged, got %v", resp)
+	}
+	if got := trailer.Get("x-error-code"); len(got) != 1 || got[0] != "NO_PREVIOUS_DEPLOYMENT" {
+		t.Errorf("unexpected x-error-code trailer %v", got)
+	}
+	if got := trailer.Get("x-grpc-code"); len(got) != 1 || got[0] != codes.FailedPrecondition.String() {
+		t.Errorf("unexpected x-grpc-code trailer %v", got)
+	}
+}
+
+func TestStatusErrorInterceptorPassesSuccesses(t *testing.T) {
+	// Status lookups of failed deployments succeeded, as did batch lookups
+	// without an error code
+	for _, resp := range []interface{}{
+		&pb.DeployResponse{Success: true, Message: "ok"},
+		&pb.DeployResponse{Success: false, Message: "no code"},
+		&pb.StatusResponse{Status: "FAILED", ErrorCode: "TIMEOUT_ERROR"},
+		&pb.BatchStatusResponse{Message: "ok"},
+	} {
+		for _, asErrors := range []bool{true, false} {
+			got, trailer, err := callWithResponse(StatusErrorInterceptor(asErrors), resp)
+			if err != nil || got != resp || len(trailer) != 0 {
+				t.Errorf("asErrors=%v: expected %v passed through untouched, got %v, %v, trailers %v", asErrors, resp, got, err, trailer)
+			}
+		}
+	}
+}
+
+func TestTimeoutInterceptor(t *testing.T) {
+	const max = time.Minute
+	tests := []struct {
+		name       string
+		timeout    time.Duration
+		wantCapped bool
+	}{
+		{name: "no client deadline", wantCapped: true},
+		{name: "client deadline too far out", timeout: time.Hour, wantCapped: true},
+		{name: "shorter client deadline", timeout: 5 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, tt.timeout)
+				defer cancel()
+			}
+
+			var remaining time.Duration
+			var hasDeadline bool
+			_, err := TimeoutInterceptor(max)(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/deployment.DeploymentService/Deploy"},
+				func(ctx context.Context, req interface{}) (interface{}, error) {
+					var deadline time.Time
+					deadline, hasDeadline = ctx.Deadline()
+					remaining = time.Until(deadline)
+					return nil, nil
+				})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !hasDeadline {
+				t.Fatal("expected the handler to get a deadline")
+			}
+
+			want := tt.timeout
+			if tt.wantCapped {
+				want = max
+			}
+			if remaining > want || remaining < want-time.Second {
+				t.Errorf("expected about %v left, got %v", want, remaining)
+			}
+		})
+	}
+}
+
+func TestAuthInterceptor(t *testing.T) {
+	interceptor := AuthInterceptor(
+		map[string]string{"ci": "ci-token", "ops": "ops-key"},
+		[]string{"/grpc.health.v1.Health/", "/deployment.DeploymentService/GetMetrics", "/deployment.DeploymentService/Get"},
+	)
+	tests := []struct {
+		name     string
+		method   string
+		md       metadata.MD
+		wantCode codes.Code
+		wantUser string
+	}{
+		{name: "missing token", method: "/deployment.DeploymentService/Deploy", wantCode: codes.Unauthenticated},
+		{name: "invalid token", method: "/deployment.DeploymentService/Deploy", md: metadata.Pairs("authorization", "Bearer wrong"), wantCode: codes.Unauthenticated},
+		{name: "non-bearer scheme", method: "/deployment.DeploymentService/Deploy", md: metadata.Pairs("authorization", "Basic ci-token"), wantCode: codes.Unauthenticated},
+		{name: "bearer header", method: "/deployment.DeploymentService/Deploy", md: metadata.Pairs("authorization", "bearer ci-token"), wantUser: "ci"},
+		{name: "api key header", method: "/deployment.DeploymentService/Deploy", md: metadata.Pairs("x-api-key", "ops-key"), wantUser: "ops"},
+		{name: "exempt prefix", method: "/grpc.health.v1.Health/Check"},
+		{name: "exempt exact method", method: "/deployment.DeploymentService/GetMetrics"},
+		{name: "entry without a trailing slash is not a prefix", method: "/deployment.DeploymentService/GetStatus", wantCode: codes.Unauthenticated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.md != nil {
+				ctx = metadata.NewIncomingContext(ctx, tt.md)
+			}
+
+			called := false
+			var user string
+			_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				user, _ = UserFromContext(ctx)
+				return nil, nil
+			})
+
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("expected %v, got %v", tt.wantCode, err)
+			}
+			if called != (tt.wantCode == codes.OK) {
+				t.Errorf("expected the handler called=%v, got %v", tt.wantCode == codes.OK, called)
+			}
+			if user != tt.wantUser {
+				t.Errorf("expected principal %q, got %q", tt.wantUser, user)
+			}
+		})
+	}
+}
+
+func TestRateLimitInterceptor(t *testing.T) {
+	const (
+		deploy    = "/deployment.DeploymentService/Deploy"
+		getStatus = "/deployment.DeploymentService/GetStatus"
+		health    = "/grpc.health.v1.Health/Check"
+	)
+	call := func(interceptor grpc.UnaryServerInterceptor, method string) codes.Code {
+		_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		})
+		return status.Code(err)
+	}
+
+	t.Run("exhausted after the burst", func(t *testing.T) {
+		interceptor := RateLimitInterceptor(NewRateLimiter(0.001, 2, false))
+		for i := 0; i < 2; i++ {
+			if code := call(interceptor, deploy); code != codes.OK {
+				t.Fatalf("call %d within the burst got %v", i+1, code)
+			}
+		}
+		if code := call(interceptor, deploy); code != codes.ResourceExhausted {
+			t.Fatalf("expected ResourceExhausted after the burst, got %v", code)
+		}
+	})
+
+	t.Run("shared bucket", func(t *testing.T) {
+		interceptor := RateLimitInterceptor(NewRateLimiter(0.001, 1, false))
+		call(interceptor, deploy)
+		if code := call(interceptor, getStatus); code != codes.ResourceExhausted {
+			t.Fatalf("expected the shared bucket to be empty for another method, got %v", code)
+		}
+	})
+
+	t.Run("per-method buckets", func(t *testing.T) {
+		interceptor := RateLimitInterceptor(NewRateLimiter(0.001, 1, true))
+		call(interceptor, deploy)
+		if code := call(interceptor, getStatus); code != codes.OK {
+			t.Fatalf("expected another method to have its own bucket, got %v", code)
+		}
+		if code := call(interceptor, deploy); code != codes.ResourceExhausted {
+			t.Fatalf("expected the method's own bucket to be empty, got %v", code)
+		}
+	})
+
+	t.Run("health checks exempt", func(t *testing.T) {
+		interceptor := RateLimitInterceptor(NewRateLimiter(0.001, 1, false))
+		call(interceptor, deploy)
+		for i := 0; i < 5; i++ {
+			if code := call(interceptor, health); code != codes.OK {
+				t.Fatalf("health check %d was limited: %v", i+1, code)
+			}
+		}
+	})
+}
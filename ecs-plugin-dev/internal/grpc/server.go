@@ -2,12 +2,25 @@ package grpc
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"strings"
+	"time"
 
 	"ecs-plugin-dev/internal/audit"
+	"ecs-plugin-dev/internal/config"
+	"ecs-plugin-dev/internal/executor"
+	"ecs-plugin-dev/internal/metrics"
 	"ecs-plugin-dev/internal/plugin"
+	"ecs-plugin-dev/internal/strategy"
+	"ecs-plugin-dev/internal/util"
 	pb "ecs-plugin-dev/proto"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type DeploymentServer struct {
@@ -16,20 +29,61 @@ type DeploymentServer struct {
 }
 
 func NewDeploymentServer() *DeploymentServer {
+	return NewDeploymentServerWithConfig(config.DefaultConfig())
+}
+
+// NewDeploymentServerWithConfig creates a deployment server using the given configuration
+func NewDeploymentServerWithConfig(cfg *config.Config) *DeploymentServer {
+	return NewDeploymentServerWithStore(cfg, plugin.NewMemoryStatusStore())
+}
+
+// NewDeploymentServerWithStore creates a deployment server whose statuses are persisted to store
+func NewDeploymentServerWithStore(cfg *config.Config, store plugin.StatusStore) *DeploymentServer {
 	return &DeploymentServer{
-		router: plugin.NewRouter(),
+		router: plugin.NewRouterWithStore(cfg, store),
 	}
 }
 
+// RegisterStrategy adds a custom deployment strategy; call it before serving
+func (s *DeploymentServer) RegisterStrategy(name string, strat strategy.Strategy) error {
+	return s.router.RegisterStrategy(name, strat)
+}
+
+// SetHealthListener reports changes in the router's ability to serve
+// deployments, such as losing access to AWS, to fn
+func (s *DeploymentServer) SetHealthListener(fn plugin.HealthListener) {
+	s.router.SetHealthListener(fn)
+}
+
+// ValidatePermissions checks the server's AWS credentials are allowed every
+// action deployments use
+func (s *DeploymentServer) ValidatePermissions(ctx context.Context) error {
+	return s.router.ValidatePermissions(ctx)
+}
+
+// Drain stops accepting deployments and waits for in-flight ones to finish
+// until ctx is done
+func (s *DeploymentServer) Drain(ctx context.Context) error {
+	return s.router.Drain(ctx)
+}
+
 func (s *DeploymentServer) Deploy(ctx context.Context, req *pb.DeployRequest) (*pb.DeployResponse, error) {
 	// Validate request
 	if err := s.validateDeployRequest(req); err != nil {
+		code, details := classifyError(err)
 		return &pb.DeployResponse{
-			Success: false,
-			Message: fmt.Sprintf("invalid request: %v", err),
+			Success:      false,
+			Message:      fmt.Sprintf("invalid request: %v", err),
+			ErrorCode:    code,
+			ErrorDetails: details,
 		}, nil
 	}
 
+	var scheduledAt time.Time
+	if req.ScheduledAtUnix != 0 {
+		scheduledAt = time.Unix(req.ScheduledAtUnix, 0)
+	}
+
 	result, err := s.router.RouteDeployment(ctx, &plugin.DeploymentRequest{
 		DeploymentID:   req.DeploymentId,
 		ClusterARN:     req.ClusterArn,
@@ -37,53 +91,811 @@ func (s *DeploymentServer) Deploy(ctx context.Context, req *pb.DeployRequest) (*
 		TaskDefinition: req.TaskDefinition,
 		Strategy:       req.Strategy,
 		Config:         req.Config,
+		DependsOn:      req.DependsOn,
+		DryRun:         req.DryRun,
+
+		RequireApproval: req.RequireApproval,
+		User:            requestUser(ctx, ""),
+		Force:           req.Force,
+		ScheduledAt:     scheduledAt,
 	})
 
 	if err != nil {
-		return &pb.DeployResponse{
-			Success: false,
-			Message: fmt.Sprintf("deployment failed: %v", err),
-		}, nil
+		code, details := classifyError(err)
+		resp := &pb.DeployResponse{
+			Success:      false,
+			Message:      fmt.Sprintf("deployment failed: %v", err),
+			ErrorCode:    code,
+			ErrorDetails: details,
+		}
+		if result != nil {
+			resp.PlannedSteps = result.PlannedSteps
+		}
+		return resp, nil
 	}
 
 	return &pb.DeployResponse{
-		Success:      result.Success,
-		Message:      result.Message,
-		DeploymentId: result.DeploymentID,
+		Success:         result.Success,
+		Message:         result.Message,
+		DeploymentId:    result.DeploymentID,
+		PendingApproval: result.PendingApproval,
+		PlannedSteps:    result.PlannedSteps,
+		QueuePosition:   int32(result.QueuePosition),
+		ExistingStatus:  result.ExistingStatus,
 	}, nil
 }
 
 func (s *DeploymentServer) GetStatus(ctx context.Context, req *pb.StatusRequest) (*pb.StatusResponse, error) {
 	status, err := s.router.GetDeploymentStatus(ctx, req.DeploymentId)
 	if err != nil {
+		code, details := classifyError(err)
 		return &pb.StatusResponse{
-			Status:  "UNKNOWN",
-			Message: err.Error(),
+			Status:       "UNKNOWN",
+			Message:      err.Error(),
+			ErrorCode:    code,
+			ErrorDetails: details,
 		}, nil
 	}
 
-	return &pb.StatusResponse{
-		Status:   status.Status,
-		Message:  status.Message,
-		Progress: status.Progress,
-	}, nil
+	return s.withETA(req.DeploymentId, toStatusResponse(status)), nil
+}
+
+// StreamStatus pushes status updates for a deployment until it reaches a
+// terminal state or the client disconnects
+func (s *DeploymentServer) StreamStatus(req *pb.StatusRequest, stream pb.DeploymentService_StreamStatusServer) error {
+	ctx := stream.Context()
+
+	// Watch before reading the current status so no transition is missed
+	updates, unsubscribe := s.router.WatchStatus(req.DeploymentId)
+	defer unsubscribe()
+
+	status, err := s.router.GetDeploymentStatus(ctx, req.DeploymentId)
+	if err != nil {
+		code, details := classifyError(err)
+		return stream.Send(&pb.StatusResponse{
+			Status:       "UNKNOWN",
+			Message:      err.Error(),
+			ErrorCode:    code,
+			ErrorDetails: details,
+		})
+	}
+
+	if err := stream.Send(s.withETA(req.DeploymentId, toStatusResponse(status))); err != nil {
+		return err
+	}
+	if plugin.IsTerminalStatus(status.Status) {
+		return nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case update := <-updates:
+			if err := stream.Send(s.withETA(req.DeploymentId, toStatusResponse(update))); err != nil {
+				return err
+			}
+			if plugin.IsTerminalStatus(update.Status) {
+				return nil
+			}
+		}
+	}
+}
+
+// withETA adds the estimated time remaining for deployments that are still running
+func (s *DeploymentServer) withETA(deploymentID string, resp *pb.StatusResponse) *pb.StatusResponse {
+	if plugin.IsTerminalStatus(resp.Status) {
+		return resp
+	}
+	if eta, ok := s.router.EstimateRemaining(deploymentID); ok {
+		resp.EtaSeconds = int64(math.Ceil(eta.Seconds()))
+	}
+	return resp
+}
+
+// toStatusResponse converts a router status into its protobuf form, classifying
+// the message of deployments that did not succeed
+func toStatusResponse(status *plugin.DeploymentStatus) *pb.StatusResponse {
+	resp := &pb.StatusResponse{
+		Status:      status.Status,
+		Message:     status.Message,
+		Progress:    status.Progress,
+		Strategy:    status.Strategy,
+		ClusterArn:  status.ClusterARN,
+		ServiceName: status.ServiceName,
+	}
+	if !status.StartTime.IsZero() {
+		resp.StartTimeUnix = status.StartTime.Unix()
+		end := time.Now()
+		if !status.EndTime.IsZero() {
+			end = status.EndTime
+			resp.EndTimeUnix = status.EndTime.Unix()
+		}
+		resp.DurationSeconds = end.Sub(status.StartTime).Seconds()
+	}
+	if plugin.IsTerminalStatus(status.Status) && status.Status != "SUCCESS" {
+		resp.ErrorCode, resp.ErrorDetails = classifyError(errors.New(status.Message))
+	}
+	return resp
 }
 
 func (s *DeploymentServer) Rollback(ctx context.Context, req *pb.RollbackRequest) (*pb.RollbackResponse, error) {
-	err := s.router.Rollback(ctx, req.DeploymentId, req.ClusterArn, req.ServiceName)
+	targets := []plugin.RollbackTarget{{
+		ClusterARN:     req.ClusterArn,
+		ServiceName:    req.ServiceName,
+		TaskDefinition: req.TaskDefinition,
+	}}
+	if len(req.Targets) > 0 {
+		targets = make([]plugin.RollbackTarget, 0, len(req.Targets))
+		for _, t := range req.Targets {
+			targets = append(targets, plugin.RollbackTarget{
+				ClusterARN:     t.ClusterArn,
+				ServiceName:    t.ServiceName,
+				TaskDefinition: t.TaskDefinition,
+			})
+		}
+	}
+
+	results := s.router.RollbackTargets(ctx, req.DeploymentId, targets)
+	auditRollback(ctx, req.DeploymentId, requestUser(ctx, ""), results)
+
+	resp := &pb.RollbackResponse{
+		Results: make([]*pb.RollbackTargetResult, 0, len(results)),
+	}
+	var failed []string
+	var firstErr error
+	for _, result := range results {
+		resp.Results = append(resp.Results, &pb.RollbackTargetResult{
+			ClusterArn:  result.ClusterARN,
+			ServiceName: result.ServiceName,
+			Success:     result.Success,
+			Message:     result.Message,
+		})
+		if !result.Success {
+			failed = append(failed, fmt.Sprintf("%s: %s", result.ServiceName, result.Message))
+			if firstErr == nil {
+				firstErr = errors.New(result.Message)
+			}
+		}
+	}
+
+	switch {
+	case len(failed) == 0:
+		resp.Success = true
+		resp.Message = "rollback initiated successfully"
+	case len(failed) == len(results):
+		resp.Message = fmt.Sprintf("rollback failed: %s", strings.Join(failed, "; "))
+	default:
+		resp.PartialSuccess = true
+		resp.Message = fmt.Sprintf("rollback partially succeeded (%d/%d targets): %s",
+			len(results)-len(failed), len(results), strings.Join(failed, "; "))
+	}
+	if firstErr != nil {
+		resp.ErrorCode, resp.ErrorDetails = classifyError(firstErr)
+	}
+
+	return resp, nil
+}
+
+// RetryDeployment runs a FAILED or CANCELLED deployment again from the
+// request it was originally accepted with
+func (s *DeploymentServer) RetryDeployment(ctx context.Context, req *pb.RetryRequest) (*pb.RetryResponse, error) {
+	if req.DeploymentId == "" {
+		code, details := classifyError(fmt.Errorf("deployment_id is required"))
+		return &pb.RetryResponse{
+			Success:      false,
+			Message:      "invalid request: deployment_id is required",
+			ErrorCode:    code,
+			ErrorDetails: details,
+		}, nil
+	}
+
+	result, err := s.router.RetryDeployment(ctx, req.DeploymentId, req.NewDeploymentId, requestUser(ctx, ""))
 	if err != nil {
-		return &pb.RollbackResponse{
-			Success: false,
-			Message: fmt.Sprintf("rollback failed: %v", err),
+		code, details := classifyError(err)
+		return &pb.RetryResponse{
+			Success:      false,
+			Message:      fmt.Sprintf("retry failed: %v", err),
+			RetriedFrom:  req.DeploymentId,
+			ErrorCode:    code,
+			ErrorDetails: details,
 		}, nil
 	}
 
-	return &pb.RollbackResponse{
+	return &pb.RetryResponse{
+		Success:       result.Success,
+		Message:       result.Message,
+		DeploymentId:  result.DeploymentID,
+		RetriedFrom:   req.DeploymentId,
+		QueuePosition: int32(result.QueuePosition),
+	}, nil
+}
+
+// Cancel requests cancellation of an in-progress deployment
+func (s *DeploymentServer) Cancel(ctx context.Context, req *pb.CancelRequest) (*pb.CancelResponse, error) {
+	if req.DeploymentId == "" {
+		code, details := classifyError(fmt.Errorf("deployment_id is required"))
+		return &pb.CancelResponse{
+			Success:      false,
+			Message:      "deployment_id is required",
+			ErrorCode:    code,
+			ErrorDetails: details,
+		}, nil
+	}
+
+	if err := s.router.CancelDeployment(req.DeploymentId); err != nil {
+		code, details := classifyError(err)
+		resp := &pb.CancelResponse{
+			Success:      false,
+			Message:      err.Error(),
+			ErrorCode:    code,
+			ErrorDetails: details,
+		}
+		if status, statusErr := s.router.GetDeploymentStatus(ctx, req.DeploymentId); statusErr == nil {
+			resp.Status = status.Status
+		}
+		return resp, nil
+	}
+
+	resp := &pb.CancelResponse{
 		Success: true,
-		Message: "rollback initiated successfully",
+		Message: "cancellation requested",
+	}
+	if status, err := s.router.GetDeploymentStatus(ctx, req.DeploymentId); err == nil {
+		resp.Status = status.Status
+	}
+	return resp, nil
+}
+
+// Pause holds a running canary at its next stage boundary until resumed
+func (s *DeploymentServer) Pause(ctx context.Context, req *pb.PauseRequest) (*pb.PauseResponse, error) {
+	if req.DeploymentId == "" {
+		code, details := classifyError(fmt.Errorf("deployment_id is required"))
+		return &pb.PauseResponse{
+			Success:      false,
+			Message:      "deployment_id is required",
+			ErrorCode:    code,
+			ErrorDetails: details,
+		}, nil
+	}
+
+	resp := &pb.PauseResponse{
+		Success: true,
+		Message: "pause requested; the deployment holds after its current stage",
+	}
+	if err := s.router.PauseDeployment(req.DeploymentId); err != nil {
+		resp.Success = false
+		resp.Message = err.Error()
+		resp.ErrorCode, resp.ErrorDetails = classifyError(err)
+	}
+	if status, err := s.router.GetDeploymentStatus(ctx, req.DeploymentId); err == nil {
+		resp.Status = status.Status
+	}
+	return resp, nil
+}
+
+// Resume releases a paused deployment
+func (s *DeploymentServer) Resume(ctx context.Context, req *pb.ResumeRequest) (*pb.ResumeResponse, error) {
+	if req.DeploymentId == "" {
+		code, details := classifyError(fmt.Errorf("deployment_id is required"))
+		return &pb.ResumeResponse{
+			Success:      false,
+			Message:      "deployment_id is required",
+			ErrorCode:    code,
+			ErrorDetails: details,
+		}, nil
+	}
+
+	resp := &pb.ResumeResponse{
+		Success: true,
+		Message: "deployment resumed",
+	}
+	if err := s.router.ResumeDeployment(req.DeploymentId); err != nil {
+		resp.Success = false
+		resp.Message = err.Error()
+		resp.ErrorCode, resp.ErrorDetails = classifyError(err)
+	}
+	if status, err := s.router.GetDeploymentStatus(ctx, req.DeploymentId); err == nil {
+		resp.Status = status.Status
+	}
+	return resp, nil
+}
+
+// ForceRelease frees a service left locked by a stuck deployment
+func (s *DeploymentServer) ForceRelease(ctx context.Context, req *pb.ForceReleaseRequest) (*pb.ForceReleaseResponse, error) {
+	if req.ClusterArn == "" || req.ServiceName == "" {
+		code, details := classifyError(fmt.Errorf("cluster_arn and service_name are required"))
+		return &pb.ForceReleaseResponse{
+			Success:      false,
+			Message:      "cluster_arn and service_name are required",
+			ErrorCode:    code,
+			ErrorDetails: details,
+		}, nil
+	}
+
+	deploymentID, err := s.router.ForceRelease(req.ClusterArn, req.ServiceName)
+	if err != nil {
+		code, details := classifyError(err)
+		return &pb.ForceReleaseResponse{
+			Success:      false,
+			Message:      err.Error(),
+			ErrorCode:    code,
+			ErrorDetails: details,
+		}, nil
+	}
+
+	if auditLogger := audit.GetGlobalAuditLogger(); auditLogger != nil {
+		auditLogger.Log(audit.AuditEvent{
+			EventType:    audit.EventServiceReleased,
+			DeploymentID: deploymentID,
+			User:         requestUser(ctx, req.Operator),
+			ClusterARN:   req.ClusterArn,
+			ServiceName:  req.ServiceName,
+			Status:       "released",
+			Metadata: map[string]interface{}{
+				"reason": req.Reason,
+			},
+			CorrelationID: util.CorrelationIDFromContext(ctx),
+		})
+	}
+
+	return &pb.ForceReleaseResponse{
+		Success:              true,
+		Message:              fmt.Sprintf("service released from deployment %s", deploymentID),
+		ReleasedDeploymentId: deploymentID,
 	}, nil
 }
 
+// GetManifest returns the JSON manifest of a finished deployment
+func (s *DeploymentServer) GetManifest(ctx context.Context, req *pb.ManifestRequest) (*pb.ManifestResponse, error) {
+	manifest, err := s.router.GetManifest(req.DeploymentId)
+	if err != nil {
+		code, details := classifyError(err)
+		return &pb.ManifestResponse{
+			Success:      false,
+			Message:      err.Error(),
+			ErrorCode:    code,
+			ErrorDetails: details,
+		}, nil
+	}
+
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return &pb.ManifestResponse{
+			Success:      false,
+			Message:      fmt.Sprintf("failed to encode manifest: %v", err),
+			ErrorCode:    "INTERNAL_ERROR",
+			ErrorDetails: "Internal server error",
+		}, nil
+	}
+
+	return &pb.ManifestResponse{
+		Success:      true,
+		Message:      "manifest retrieved",
+		ManifestJson: string(data),
+	}, nil
+}
+
+// GetAPICalls returns the sequence of AWS API calls a deployment made
+func (s *DeploymentServer) GetAPICalls(ctx context.Context, req *pb.APICallsRequest) (*pb.APICallsResponse, error) {
+	calls, err := s.router.GetAPICalls(req.DeploymentId)
+	if err != nil {
+		code, details := classifyError(err)
+		return &pb.APICallsResponse{
+			Success:      false,
+			Message:      err.Error(),
+			ErrorCode:    code,
+			ErrorDetails: details,
+		}, nil
+	}
+
+	resp := &pb.APICallsResponse{
+		Success: true,
+		Message: fmt.Sprintf("%d API calls recorded", len(calls)),
+		Calls:   make([]*pb.APICall, 0, len(calls)),
+	}
+	for _, call := range calls {
+		resp.Calls = append(resp.Calls, &pb.APICall{
+			Service:         call.Service,
+			Operation:       call.Operation,
+			Status:          call.Status,
+			DurationMs:      call.Duration.Milliseconds(),
+			TimestampUnixMs: call.Timestamp.UnixMilli(),
+		})
+	}
+	return resp, nil
+}
+
+// ListDeployments returns known deployments, most recent first
+func (s *DeploymentServer) ListDeployments(ctx context.Context, req *pb.ListRequest) (*pb.ListResponse, error) {
+	entries := s.router.ListDeployments(strings.ToUpper(req.Status), int(req.Limit))
+
+	resp := &pb.ListResponse{
+		Deployments: make([]*pb.DeploymentSummary, 0, len(entries)),
+	}
+	for _, entry := range entries {
+		resp.Deployments = append(resp.Deployments, toDeploymentSummary(entry))
+	}
+	return resp, nil
+}
+
+func toDeploymentSummary(entry plugin.DeploymentEntry) *pb.DeploymentSummary {
+	summary := &pb.DeploymentSummary{
+		DeploymentId:  entry.DeploymentID,
+		Status:        entry.Status.Status,
+		Strategy:      entry.Status.Strategy,
+		StartTimeUnix: entry.Status.StartTime.Unix(),
+		Progress:      entry.Status.Progress,
+		ClusterArn:    entry.Status.ClusterARN,
+		ServiceName:   entry.Status.ServiceName,
+		Message:       entry.Status.Message,
+	}
+	if !entry.Status.EndTime.IsZero() {
+		summary.EndTimeUnix = entry.Status.EndTime.Unix()
+	}
+	return summary
+}
+
+// DeployBatch deploys several services under one batch ID, reporting how
+// each deployment was routed
+func (s *DeploymentServer) DeployBatch(ctx context.Context, req *pb.BatchDeployRequest) (*pb.BatchDeployResponse, error) {
+	user := requestUser(ctx, "")
+	batch := &plugin.BatchRequest{
+		BatchID:      req.BatchId,
+		Deployments:  make([]*plugin.DeploymentRequest, 0, len(req.Deployments)),
+		AllOrNothing: req.AllOrNothing,
+	}
+	for _, d := range req.Deployments {
+		err := s.validateDeployRequest(d)
+		if err == nil && d.ScheduledAtUnix != 0 {
+			err = fmt.Errorf("invalid scheduled_at_unix: batch deployments cannot be scheduled")
+		}
+		if err != nil {
+			code, details := classifyError(err)
+			return &pb.BatchDeployResponse{
+				Success:      false,
+				Message:      fmt.Sprintf("invalid request: deployment %q: %v", d.DeploymentId, err),
+				BatchId:      req.BatchId,
+				ErrorCode:    code,
+				ErrorDetails: details,
+			}, nil
+		}
+		batch.Deployments = append(batch.Deployments, &plugin.DeploymentRequest{
+			DeploymentID:   d.DeploymentId,
+			ClusterARN:     d.ClusterArn,
+			ServiceName:    d.ServiceName,
+			TaskDefinition: d.TaskDefinition,
+			Strategy:       d.Strategy,
+			Config:         d.Config,
+			DependsOn:      d.DependsOn,
+			DryRun:         d.DryRun,
+
+			RequireApproval: d.RequireApproval,
+			User:            user,
+			Force:           d.Force,
+		})
+	}
+
+	result, err := s.router.RouteBatch(ctx, batch)
+	if err != nil {
+		code, details := classifyError(err)
+		return &pb.BatchDeployResponse{
+			Success:      false,
+			Message:      fmt.Sprintf("batch failed: %v", err),
+			BatchId:      req.BatchId,
+			ErrorCode:    code,
+			ErrorDetails: details,
+		}, nil
+	}
+
+	resp := &pb.BatchDeployResponse{
+		Success: result.Success,
+		Message: result.Message,
+		BatchId: result.BatchID,
+		Results: make([]*pb.BatchDeployResult, 0, len(result.Results)),
+	}
+	for _, item := range result.Results {
+		r := &pb.BatchDeployResult{
+			DeploymentId: item.DeploymentID,
+			ClusterArn:   item.ClusterARN,
+			ServiceName:  item.ServiceName,
+			Success:      item.Success,
+			Message:      item.Message,
+		}
+		if item.Err != nil {
+			r.ErrorCode, _ = classifyError(item.Err)
+		}
+		resp.Results = append(resp.Results, r)
+	}
+	return resp, nil
+}
+
+// GetBatchStatus reports a batch's outcome and the status of each of its deployments
+func (s *DeploymentServer) GetBatchStatus(ctx context.Context, req *pb.BatchStatusRequest) (*pb.BatchStatusResponse, error) {
+	status, err := s.router.GetBatchStatus(req.BatchId)
+	if err != nil {
+		code, _ := classifyError(err)
+		return &pb.BatchStatusResponse{
+			BatchId:   req.BatchId,
+			Status:    "UNKNOWN",
+			Message:   err.Error(),
+			ErrorCode: code,
+		}, nil
+	}
+
+	resp := &pb.BatchStatusResponse{
+		BatchId:      status.BatchID,
+		Status:       status.Status,
+		Message:      status.Message,
+		AllOrNothing: status.AllOrNothing,
+		Deployments:  make([]*pb.DeploymentSummary, 0, len(status.Deployments)),
+	}
+	for _, entry := range status.Deployments {
+		resp.Deployments = append(resp.Deployments, toDeploymentSummary(entry))
+	}
+	return resp, nil
+}
+
+// ListStrategies describes the deployment strategies this server supports
+func (s *DeploymentServer) ListStrategies(ctx context.Context, req *pb.Empty) (*pb.StrategiesResponse, error) {
+	infos := s.router.ListStrategies()
+
+	resp := &pb.StrategiesResponse{
+		Strategies: make([]*pb.StrategyInfo, 0, len(infos)),
+	}
+	for _, info := range infos {
+		resp.Strategies = append(resp.Strategies, &pb.StrategyInfo{
+			Name:          info.Name,
+			Description:   info.Description,
+			ConfigKeys:    info.ConfigKeys,
+			SupportsPause: info.SupportsPause,
+		})
+	}
+	return resp, nil
+}
+
+// Diff reports the field-level changes between a service's running task
+// definition and the one in the request
+func (s *DeploymentServer) Diff(ctx context.Context, req *pb.DiffRequest) (*pb.DiffResponse, error) {
+	err := validateDiffRequest(req)
+	var diff *executor.TaskDefinitionDiff
+	if err == nil {
+		diff, err = s.router.DiffTaskDefinition(ctx, req.ClusterArn, req.ServiceName, req.TaskDefinition)
+	}
+	if err != nil {
+		code, details := classifyError(err)
+		return &pb.DiffResponse{
+			Success:      false,
+			Message:      fmt.Sprintf("diff failed: %v", err),
+			ErrorCode:    code,
+			ErrorDetails: details,
+		}, nil
+	}
+
+	resp := &pb.DiffResponse{
+		Success:               true,
+		Message:               fmt.Sprintf("%d changes", len(diff.Changes)),
+		CurrentTaskDefinition: diff.CurrentTaskDefinition,
+		Changes:               make([]*pb.FieldChange, 0, len(diff.Changes)),
+	}
+	if len(diff.Changes) == 0 {
+		resp.Message = "no changes"
+	}
+	for _, change := range diff.Changes {
+		resp.Changes = append(resp.Changes, &pb.FieldChange{
+			Field:     change.Field,
+			Container: change.Container,
+			Change:    change.Change,
+			OldValue:  change.Old,
+			NewValue:  change.New,
+		})
+	}
+	return resp, nil
+}
+
+func validateDiffRequest(req *pb.DiffRequest) error {
+	if req.ClusterArn == "" {
+		return fmt.Errorf("cluster_arn is required")
+	}
+	if req.ServiceName == "" {
+		return fmt.Errorf("service_name is required")
+	}
+	if req.TaskDefinition == "" {
+		return fmt.Errorf("task_definition is required")
+	}
+	return nil
+}
+
+// DetectDrift reports how a service differs from the task definition it
+// should run, without changing it
+func (s *DeploymentServer) DetectDrift(ctx context.Context, req *pb.DriftRequest) (*pb.DriftResponse, error) {
+	err := validateDriftRequest(req)
+	var drift *executor.DriftResult
+	if err == nil {
+		drift, err = s.router.DetectDrift(ctx, req.ClusterArn, req.ServiceName, req.ExpectedTaskDefinition)
+	}
+	if err != nil {
+		return driftFailure("drift detection failed", drift, err), nil
+	}
+	return driftResponse(drift), nil
+}
+
+// ReconcileDrift updates a drifted service back to the task definition it
+// should run and waits for it to stabilize
+func (s *DeploymentServer) ReconcileDrift(ctx context.Context, req *pb.DriftRequest) (*pb.DriftResponse, error) {
+	err := validateDriftRequest(req)
+	var drift *executor.DriftResult
+	if err == nil {
+		drift, err = s.router.ReconcileDrift(ctx, req.ClusterArn, req.ServiceName, req.ExpectedTaskDefinition, requestUser(ctx, req.Operator))
+	}
+	if err != nil {
+		return driftFailure("drift reconciliation failed", drift, err), nil
+	}
+	return driftResponse(drift), nil
+}
+
+func validateDriftRequest(req *pb.DriftRequest) error {
+	if req.ClusterArn == "" {
+		return fmt.Errorf("cluster_arn is required")
+	}
+	if req.ServiceName == "" {
+		return fmt.Errorf("service_name is required")
+	}
+	if req.ExpectedTaskDefinition == "" {
+		return fmt.Errorf("expected_task_definition is required")
+	}
+	return nil
+}
+
+// driftResponse converts a drift result to its protobuf form
+func driftResponse(drift *executor.DriftResult) *pb.DriftResponse {
+	resp := &pb.DriftResponse{
+		Success:         true,
+		DriftStatus:     string(drift.Status),
+		Drifts:          make([]*pb.DriftDetail, 0, len(drift.Drifts)),
+		DetectedAtUnix:  drift.DetectedAt.Unix(),
+		ReconcileAction: drift.ReconcileAction,
+	}
+	if !drift.ReconciledAt.IsZero() {
+		resp.ReconciledAtUnix = drift.ReconciledAt.Unix()
+	}
+	for _, d := range drift.Drifts {
+		resp.Drifts = append(resp.Drifts, &pb.DriftDetail{
+			Field:     d.Field,
+			Container: d.Container,
+			Expected:  d.Expected,
+			Actual:    d.Actual,
+		})
+	}
+
+	switch drift.Status {
+	case executor.DriftNone:
+		resp.Message = "no drift"
+	case executor.DriftFixed:
+		resp.Message = fmt.Sprintf("%d drifts reconciled", len(drift.Drifts))
+	default:
+		resp.Message = fmt.Sprintf("%d drifts detected", len(drift.Drifts))
+	}
+	return resp
+}
+
+// driftFailure reports a failed drift call, keeping any drift found before
+// it failed
+func driftFailure(action string, drift *executor.DriftResult, err error) *pb.DriftResponse {
+	resp := &pb.DriftResponse{}
+	if drift != nil {
+		resp = driftResponse(drift)
+	}
+	resp.Success = false
+	resp.Message = fmt.Sprintf("%s: %v", action, err)
+	resp.ErrorCode, resp.ErrorDetails = classifyError(err)
+	return resp
+}
+
+// GetAnalytics returns aggregate deployment statistics, optionally for one strategy
+func (s *DeploymentServer) GetAnalytics(ctx context.Context, req *pb.AnalyticsRequest) (*pb.AnalyticsResponse, error) {
+	engine := metrics.GetGlobalAnalysisEngine()
+
+	var analysis *metrics.DeploymentAnalysis
+	if req.Strategy != "" {
+		analysis = engine.GetAnalysisFiltered(func(insight metrics.DeploymentInsight) bool {
+			return insight.Strategy == req.Strategy
+		})
+	} else {
+		analysis = engine.GetAnalysis()
+	}
+
+	resp := &pb.AnalyticsResponse{
+		TotalDeployments:              analysis.TotalDeployments,
+		SuccessfulDeployments:         analysis.SuccessfulDeploys,
+		FailedDeployments:             analysis.FailedDeploys,
+		CancelledDeployments:          analysis.CancelledDeploys,
+		SuccessRate:                   analysis.SuccessRate,
+		AverageDurationSeconds:        analysis.AverageDuration.Seconds(),
+		TrimmedAverageDurationSeconds: analysis.TrimmedAverageDuration.Seconds(),
+		FastestDurationSeconds:        analysis.FastestDeployment.Seconds(),
+		SlowestDurationSeconds:        analysis.SlowestDeployment.Seconds(),
+		RawSlowestDurationSeconds:     analysis.RawSlowestDeployment.Seconds(),
+		StrategyBreakdown:             analysis.StrategyBreakdown,
+		ErrorBreakdown:                analysis.ErrorBreakdown,
+	}
+	if !analysis.LastDeploymentTime.IsZero() {
+		resp.LastDeploymentUnix = analysis.LastDeploymentTime.Unix()
+	}
+	return resp, nil
+}
+
+// GetMetrics returns the key deployment and AWS counters read from the
+// registered Prometheus collectors
+func (s *DeploymentServer) GetMetrics(ctx context.Context, req *pb.Empty) (*pb.MetricsResponse, error) {
+	snapshot, err := metrics.TakeSnapshot(prometheus.DefaultGatherer)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%v", err)
+	}
+
+	resp := &pb.MetricsResponse{
+		DeploymentsByStatus:   snapshot.DeploymentsByStatus,
+		DeploymentsInProgress: snapshot.DeploymentsInProgress,
+		AwsApiCalls:           snapshot.AWSAPICalls,
+		AwsApiErrors:          snapshot.AWSAPIErrors,
+		ErrorsByComponent:     snapshot.ErrorsByComponent,
+	}
+	for _, n := range snapshot.DeploymentsByStatus {
+		resp.DeploymentsTotal += n
+	}
+	return resp, nil
+}
+
+// SummarizeServices returns the deployment state of every known service
+func (s *DeploymentServer) SummarizeServices(ctx context.Context, req *pb.SummaryRequest) (*pb.SummaryResponse, error) {
+	summaries := s.router.SummarizeServices()
+
+	resp := &pb.SummaryResponse{
+		Services: make([]*pb.ServiceSummary, 0, len(summaries)),
+	}
+	for _, summary := range summaries {
+		resp.Services = append(resp.Services, &pb.ServiceSummary{
+			ClusterArn:           summary.ClusterARN,
+			ServiceName:          summary.ServiceName,
+			LastDeploymentId:     summary.LastDeploymentID,
+			LastStatus:           summary.LastStatus,
+			LastMessage:          summary.LastMessage,
+			InFlightDeploymentId: summary.InFlightDeploymentID,
+			DriftMonitored:       summary.DriftMonitored,
+			TotalDeployments:     summary.TotalDeployments,
+			SuccessRate:          summary.SuccessRate,
+		})
+	}
+
+	return resp, nil
+}
+
+// grpcCode maps a classifyError code to the gRPC status code clients should
+// act on: Unavailable and ResourceExhausted are worth retrying, while
+// InvalidArgument and FailedPrecondition need the request or service fixed
+func grpcCode(errorCode string) codes.Code {
+	switch errorCode {
+	case "VALIDATION_ERROR":
+		return codes.InvalidArgument
+	case "NOT_FOUND":
+		return codes.NotFound
+	case "DEPLOYMENT_IN_PROGRESS", "INTERRUPTED":
+		return codes.Aborted
+	case "QUEUE_FULL", "CAPACITY_EXCEEDED":
+		return codes.ResourceExhausted
+	case "AWS_API_ERROR", "AWS_UNAVAILABLE", "DEPENDENCY_UNHEALTHY", "SHUTTING_DOWN":
+		return codes.Unavailable
+	case "NO_PREVIOUS_DEPLOYMENT", "NOT_RUNNING", "NOT_RETRYABLE", "INVALID_STATE", "UNSUPPORTED_LOAD_BALANCER", "ROLLOUT_FAILED", "HEALTH_CHECK_ERROR":
+		return codes.FailedPrecondition
+	case "TIMEOUT_ERROR", "APPROVAL_TIMEOUT":
+		return codes.DeadlineExceeded
+	case "CANCELLED_ERROR":
+		return codes.Canceled
+	default:
+		return codes.Internal
+	}
+}
+
 func classifyError(err error) (string, string) {
 	if err == nil {
 		return "", ""
@@ -91,16 +903,104 @@ func classifyError(err error) (string, string) {
 
 	errMsg := err.Error()
 
+	// Dependent services must be healthy before deploying
+	if strings.Contains(errMsg, "dependency unhealthy") {
+		return "DEPENDENCY_UNHEALTHY", "A dependent service failed its health check"
+	}
+
+	// AWS calls failing fast while its circuit breaker is open
+	if strings.Contains(errMsg, "circuit breaker open") {
+		return "AWS_UNAVAILABLE", "AWS is failing; calls are paused for the circuit breaker cooldown"
+	}
+
 	// Validation errors
-	if strings.Contains(errMsg, "cannot be empty") || strings.Contains(errMsg, "invalid") {
+	if strings.Contains(errMsg, "cannot be empty") || strings.Contains(errMsg, "invalid") ||
+		strings.Contains(errMsg, "is required") || strings.Contains(errMsg, "unknown strategy") ||
+		strings.Contains(errMsg, "validation failed") {
 		return "VALIDATION_ERROR", "Request validation failed"
 	}
 
+	// Another deployment already holds the service
+	if strings.Contains(errMsg, "concurrent deployment") || strings.Contains(errMsg, "already in progress") {
+		return "DEPLOYMENT_IN_PROGRESS", "Another deployment is already running for this service"
+	}
+
+	// The service's deployment queue has no room left
+	if strings.Contains(errMsg, "queue is full") {
+		return "QUEUE_FULL", "Too many deployments are already queued for this service; retry later"
+	}
+
+	// Too many deployments already running
+	if strings.Contains(errMsg, "capacity exceeded") {
+		return "CAPACITY_EXCEEDED", "Too many deployments are already running; retry later"
+	}
+
+	// Nothing to roll back to on a service deployed only once
+	if strings.Contains(errMsg, "no previous deployment") {
+		return "NO_PREVIOUS_DEPLOYMENT", "The service has no earlier task definition to roll back to"
+	}
+
+	// Unknown deployment IDs
+	if strings.Contains(errMsg, "deployment not found") || strings.Contains(errMsg, "batch not found") {
+		return "NOT_FOUND", "Deployment not found"
+	}
+
+	// Deployments without an approval request or manifest, and services no
+	// deployment holds
+	if strings.Contains(errMsg, "approval request not found") || strings.Contains(errMsg, "manifest not found") ||
+		strings.Contains(errMsg, "no deployment holds service") {
+		return "NOT_FOUND", "Nothing found for the given deployment or service"
+	}
+
+	// Retrying a deployment that did not fail or was not cancelled
+	if strings.Contains(errMsg, "cannot be retried") {
+		return "NOT_RETRYABLE", "Only FAILED or CANCELLED deployments accepted since the last restart can be retried"
+	}
+
+	// Cancelling or pausing a deployment that has already finished
+	if strings.Contains(errMsg, "is not running") || strings.Contains(errMsg, "already finished") {
+		return "NOT_RUNNING", "The deployment has already finished"
+	}
+
+	// Pausing, resuming or deciding on a deployment whose state does not
+	// allow it
+	if strings.Contains(errMsg, "already paused") || strings.Contains(errMsg, "is not paused") ||
+		strings.Contains(errMsg, "cannot be paused") || strings.Contains(errMsg, "has not finished") ||
+		strings.Contains(errMsg, "already approved") || strings.Contains(errMsg, "already rejected") ||
+		strings.Contains(errMsg, "already expired") {
+		return "INVALID_STATE", "The deployment's current state does not allow this operation"
+	}
+
+	// Deployments orphaned by a server restart or shutdown
+	if strings.Contains(errMsg, "interrupted by server") {
+		return "INTERRUPTED", "Deployment was interrupted by a server restart or shutdown"
+	}
+
+	// New deployments are refused while the server drains
+	if strings.Contains(errMsg, "shutting down") {
+		return "SHUTTING_DOWN", "The server is shutting down; retry against another instance"
+	}
+
+	// ECS gave up on the rollout (deployment circuit breaker)
+	if strings.Contains(errMsg, "ecs rollout failed") {
+		return "ROLLOUT_FAILED", "ECS marked the service rollout as failed"
+	}
+
 	// AWS errors
 	if strings.Contains(errMsg, "failed to") && (strings.Contains(errMsg, "describe") || strings.Contains(errMsg, "update") || strings.Contains(errMsg, "register")) {
 		return "AWS_API_ERROR", "AWS API call failed"
 	}
 
+	// Load balancer capability errors
+	if strings.Contains(errMsg, "weighted traffic shifting is not supported") {
+		return "UNSUPPORTED_LOAD_BALANCER", "Load balancer does not support weighted traffic shifting"
+	}
+
+	// Nobody approved the deployment within its approval window
+	if strings.Contains(errMsg, "approval timeout") {
+		return "APPROVAL_TIMEOUT", "Deployment was not approved before its approval_timeout"
+	}
+
 	// Timeout errors
 	if strings.Contains(errMsg, "timeout") || strings.Contains(errMsg, "deadline") {
 		return "TIMEOUT_ERROR", "Operation timed out"
@@ -122,31 +1022,42 @@ func classifyError(err error) (string, string) {
 
 func (s *DeploymentServer) ApproveDeployment(ctx context.Context, req *pb.ApprovalRequest) (*pb.ApprovalResponse, error) {
 	if req.DeploymentId == "" {
+		code, details := classifyError(fmt.Errorf("deployment_id is required"))
 		return &pb.ApprovalResponse{
-			Success: false,
-			Message: "deployment_id is required",
+			Success:      false,
+			Message:      "deployment_id is required",
+			ErrorCode:    code,
+			ErrorDetails: details,
 		}, nil
 	}
 
-	err := s.router.ApproveDeployment(ctx, req.DeploymentId, req.Approved, req.Approver, req.Reason)
+	approver := requestUser(ctx, req.Approver)
+	err := s.router.ApproveDeployment(ctx, req.DeploymentId, req.Approved, approver, req.Reason)
 	if err != nil {
+		code, details := classifyError(err)
 		return &pb.ApprovalResponse{
-			Success: false,
-			Message: err.Error(),
+			Success:      false,
+			Message:      err.Error(),
+			ErrorCode:    code,
+			ErrorDetails: details,
 		}, nil
 	}
 
 	if auditLogger := audit.GetGlobalAuditLogger(); auditLogger != nil {
+		event := audit.AuditEvent{
+			EventType:    audit.EventApprovalRejected,
+			DeploymentID: req.DeploymentId,
+			User:         approver,
+			Status:       "rejected",
+
+			CorrelationID: util.CorrelationIDFromContext(ctx),
+		}
 		if req.Approved {
-			auditLogger.LogApprovalGranted(req.DeploymentId, req.Approver, req.Reason)
-		} else {
-			auditLogger.Log(audit.AuditEvent{
-				EventType:    audit.EventApprovalRejected,
-				DeploymentID: req.DeploymentId,
-				User:         req.Approver,
-				Status:       "rejected",
-			})
+			event.EventType = audit.EventApprovalGranted
+			event.Status = "approved"
+			event.Metadata = map[string]interface{}{"reason": req.Reason}
 		}
+		auditLogger.Log(event)
 	}
 
 	return &pb.ApprovalResponse{
@@ -155,6 +1066,65 @@ func (s *DeploymentServer) ApproveDeployment(ctx context.Context, req *pb.Approv
 	}, nil
 }
 
+// GetApprovalStatus reports the approval request gating a deployment
+func (s *DeploymentServer) GetApprovalStatus(ctx context.Context, req *pb.ApprovalStatusRequest) (*pb.ApprovalStatusResponse, error) {
+	if req.DeploymentId == "" {
+		code, details := classifyError(fmt.Errorf("deployment_id is required"))
+		return &pb.ApprovalStatusResponse{
+			Success:      false,
+			Message:      "deployment_id is required",
+			ErrorCode:    code,
+			ErrorDetails: details,
+		}, nil
+	}
+
+	approval, err := s.router.GetApprovalStatus(req.DeploymentId)
+	if err != nil {
+		code, details := classifyError(err)
+		return &pb.ApprovalStatusResponse{
+			Success:      false,
+			Message:      err.Error(),
+			ErrorCode:    code,
+			ErrorDetails: details,
+		}, nil
+	}
+
+	return &pb.ApprovalStatusResponse{
+		Success:  true,
+		Message:  string(approval.Status),
+		Approval: toApprovalInfo(approval),
+	}, nil
+}
+
+// ListPendingApprovals lists every deployment or group awaiting approval
+func (s *DeploymentServer) ListPendingApprovals(ctx context.Context, req *pb.PendingApprovalsRequest) (*pb.PendingApprovalsResponse, error) {
+	pending := s.router.ListPendingApprovals()
+
+	resp := &pb.PendingApprovalsResponse{
+		Approvals: make([]*pb.ApprovalInfo, 0, len(pending)),
+	}
+	for _, approval := range pending {
+		resp.Approvals = append(resp.Approvals, toApprovalInfo(approval))
+	}
+	return resp, nil
+}
+
+func toApprovalInfo(req executor.ApprovalRequest) *pb.ApprovalInfo {
+	return &pb.ApprovalInfo{
+		DeploymentId:    req.DeploymentID,
+		ClusterArn:      req.ClusterARN,
+		ServiceName:     req.ServiceName,
+		Strategy:        req.Strategy,
+		RequestedAtUnix: req.RequestedAt.Unix(),
+		Status:          string(req.Status),
+		Approver:        req.Approver,
+		Reason:          req.Reason,
+		GroupId:         req.GroupID,
+		Members:         req.Members,
+		ExpiresAtUnix:   req.ExpiresAt.Unix(),
+	}
+}
+
 // validateDeployRequest validates deploy request fields
 func (s *DeploymentServer) validateDeployRequest(req *pb.DeployRequest) error {
 	if req.DeploymentId == "" {
@@ -174,3 +1144,37 @@ func (s *DeploymentServer) validateDeployRequest(req *pb.DeployRequest) error {
 	}
 	return nil
 }
+
+// requestUser returns the authenticated principal making the call. Without
+// authentication it falls back to the name the client supplied, if any.
+func requestUser(ctx context.Context, supplied string) string {
+	if user, ok := UserFromContext(ctx); ok {
+		return user
+	}
+	return supplied
+}
+
+// auditRollback records the outcome of a rollback for each of its targets
+func auditRollback(ctx context.Context, deploymentID, user string, results []plugin.RollbackTargetResult) {
+	auditLogger := audit.GetGlobalAuditLogger()
+	if auditLogger == nil {
+		return
+	}
+	for _, result := range results {
+		event := audit.AuditEvent{
+			EventType:    audit.EventDeploymentRollback,
+			DeploymentID: deploymentID,
+			User:         user,
+			ClusterARN:   result.ClusterARN,
+			ServiceName:  result.ServiceName,
+			Status:       "succeeded",
+
+			CorrelationID: util.CorrelationIDFromContext(ctx),
+		}
+		if !result.Success {
+			event.Status = "failed"
+			event.ErrorMessage = result.Message
+		}
+		auditLogger.Log(event)
+	}
+}
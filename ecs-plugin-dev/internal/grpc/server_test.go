@@ -0,0 +1,207 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"ecs-plugin-dev/internal/config"
+	"ecs-plugin-dev/internal/strategy"
+	pb "ecs-plugin-dev/proto"
+
+	"google.golang.org/grpc/codes"
+)
+
+const testTaskDefinition = `{"family":"web","containerDefinitions":[{"name":"web","image":"nginx"}]}`
+
+func TestMain(m *testing.M) {
+	// Clients read MOCK_MODE when the server builds them
+	os.Setenv("MOCK_MODE", "true")
+	os.Exit(m.Run())
+}
+
+// deployAndWait starts a quicksync deployment and waits for it to finish
+func deployAndWait(t *testing.T, s *DeploymentServer, deploymentID string) string {
+	t.Helper()
+	return deployStrategyAndWait(t, s, deploymentID, "quicksync")
+}
+
+// deployStrategyAndWait starts a deployment with the named strategy and
+// waits for it to finish
+func deployStrategyAndWait(t *testing.T, s *DeploymentServer, deploymentID, strategyName string) string {
+	t.Helper()
+	ctx := context.Background()
+	resp, err := s.Deploy(ctx, &pb.DeployRequest{
+		DeploymentId:   deploymentID,
+		ClusterArn:     "arn:aws:ecs:us-east-1:123456789012:cluster/test",
+		ServiceName:    "svc-" + deploymentID,
+		TaskDefinition: testTaskDefinition,
+		Strategy:       strategyName,
+	})
+	if err != nil || !resp.Success {
+		t.Fatalf("deploy failed: %v %v", err, resp)
+	}
+
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		status, err := s.GetStatus(ctx, &pb.StatusRequest{DeploymentId: deploymentID})
+		if err != nil {
+			t.Fatalf("status failed: %v", err)
+		}
+		switch status.Status {
+		case "SUCCESS", "FAILED", "CANCELLED":
+			return status.Status
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	t.Fatalf("deployment %s did not finish", deploymentID)
+	return ""
+}
+
+func TestCancelErrorCodes(t *testing.T) {
+	s := NewDeploymentServerWithConfig(config.DefaultConfig())
+	if status := deployAndWait(t, s, "cancel-finished"); status != "SUCCESS" {
+		t.Fatalf("expected SUCCESS, got %s", status)
+	}
+
+	tests := []struct {
+		name         string
+		deploymentID string
+		wantCode     string
+		wantGRPC     codes.Code
+	}{
+		{name: "missing ID", deploymentID: "", wantCode: "VALIDATION_ERROR", wantGRPC: codes.InvalidArgument},
+		{name: "unknown deployment", deploymentID: "cancel-unknown", wantCode: "NOT_FOUND", wantGRPC: codes.NotFound},
+		{name: "finished deployment", deploymentID: "cancel-finished", wantCode: "NOT_RUNNING", wantGRPC: codes.FailedPrecondition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := s.Cancel(context.Background(), &pb.CancelRequest{DeploymentId: tt.deploymentID})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if resp.Success {
+				t.Fatal("expected cancel to fail")
+			}
+			if resp.ErrorCode != tt.wantCode {
+				t.Errorf("expected error code %s, got %s (%s)", tt.wantCode, resp.ErrorCode, resp.Message)
+			}
+			if got := grpcCode(resp.ErrorCode); got != tt.wantGRPC {
+				t.Errorf("expected gRPC code %v, got %v", tt.wantGRPC, got)
+			}
+		})
+	}
+}
+
+func TestRollbackPartialSuccess(t *testing.T) {
+	// Without a previous deployment only targets naming a revision can roll back
+	scenario := filepath.Join(t.TempDir(), "scenario.json")
+	if err := os.WriteFile(scenario, []byte(`{"previous_task_definition": ""}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("MOCK_SCENARIO_FILE", scenario)
+	s := NewDeploymentServerWithConfig(config.DefaultConfig())
+
+	cluster := "arn:aws:ecs:us-east-1:123456789012:cluster/test"
+	resp, err := s.Rollback(context.Background(), &pb.RollbackRequest{
+		DeploymentId: "rollback-partial",
+		Targets: []*pb.RollbackTarget{
+			{ClusterArn: cluster, ServiceName: "api", TaskDefinition: "api:3"},
+			{ClusterArn: cluster, ServiceName: "worker"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if resp.Success || !resp.PartialSuccess {
+		t.Fatalf("expected partial success, got success=%v partial=%v", resp.Success, resp.PartialSuccess)
+	}
+	if !strings.Contains(resp.Message, "(1/2 targets)") || !strings.Contains(resp.Message, "worker:") {
+		t.Errorf("expected the message to count targets and name the failed one, got %q", resp.Message)
+	}
+	if resp.ErrorCode != "NO_PREVIOUS_DEPLOYMENT" {
+		t.Errorf("expected NO_PREVIOUS_DEPLOYMENT, got %s", resp.ErrorCode)
+	}
+
+	if len(resp.Results) != 2 {
+		t.Fatalf("expected a result per target, got %+v", resp.Results)
+	}
+	if api := resp.Results[0]; api.ServiceName != "api" || !api.Success {
+		t.Errorf("expected api to roll back, got %+v", api)
+	}
+	if worker := resp.Results[1]; worker.ServiceName != "worker" || worker.Success || !strings.Contains(worker.Message, "no previous deployment") {
+		t.Errorf("expected worker to fail for lack of a previous deployment, got %+v", worker)
+	}
+}
+
+// strategyFunc adapts a function to a strategy for registering test strategies
+type strategyFunc func(ctx context.Context, dctx *strategy.DeploymentContext) error
+
+func (f strategyFunc) Execute(ctx context.Context, dctx *strategy.DeploymentContext) error {
+	return f(ctx, dctx)
+}
+
+func TestGetAnalytics(t *testing.T) {
+	s := NewDeploymentServerWithConfig(config.DefaultConfig())
+	// Strategies of their own keep deployments from other tests, which share
+	// the global analysis engine, out of the filtered figures
+	succeeding := strategyFunc(func(ctx context.Context, dctx *strategy.DeploymentContext) error { return nil })
+	failing := strategyFunc(func(ctx context.Context, dctx *strategy.DeploymentContext) error {
+		return errors.New("scripted failure")
+	})
+	if err := s.RegisterStrategy("analytics-ok", succeeding); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.RegisterStrategy("analytics-failing", failing); err != nil {
+		t.Fatal(err)
+	}
+
+	before, err := s.GetAnalytics(context.Background(), &pb.AnalyticsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 3; i++ {
+		deployStrategyAndWait(t, s, fmt.Sprintf("analytics-ok-%d", i), "analytics-ok")
+	}
+	deployStrategyAndWait(t, s, "analytics-failing-0", "analytics-failing")
+
+	ok, err := s.GetAnalytics(context.Background(), &pb.AnalyticsRequest{Strategy: "analytics-ok"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok.TotalDeployments != 3 || ok.SuccessfulDeployments != 3 || ok.SuccessRate != 100 {
+		t.Errorf("expected 3 successful deployments, got %+v", ok)
+	}
+	if ok.LastDeploymentUnix == 0 {
+		t.Error("expected the last deployment time")
+	}
+
+	failed, err := s.GetAnalytics(context.Background(), &pb.AnalyticsRequest{Strategy: "analytics-failing"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if failed.TotalDeployments != 1 || failed.FailedDeployments != 1 || failed.SuccessRate != 0 {
+		t.Errorf("expected 1 failed deployment, got %+v", failed)
+	}
+	if failed.ErrorBreakdown["scripted failure"] != 1 {
+		t.Errorf("expected the failure in the error breakdown, got %v", failed.ErrorBreakdown)
+	}
+
+	all, err := s.GetAnalytics(context.Background(), &pb.AnalyticsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if all.TotalDeployments != before.TotalDeployments+4 {
+		t.Errorf("expected 4 more deployments overall, went from %d to %d", before.TotalDeployments, all.TotalDeployments)
+	}
+	if all.StrategyBreakdown["analytics-ok"] != 3 || all.StrategyBreakdown["analytics-failing"] != 1 {
+		t.Errorf("unexpected strategy breakdown: %v", all.StrategyBreakdown)
+	}
+}
@@ -1,10 +1,16 @@
 package metrics
 
 import (
+	"math"
+	"sort"
 	"sync"
 	"time"
 )
 
+// defaultTrimFraction is the share of durations dropped from each end when
+// computing the trimmed average
+const defaultTrimFraction = 0.1
+
 type DeploymentAnalysis struct {
 	TotalDeployments   int64
 	SuccessfulDeploys  int64
@@ -17,6 +23,12 @@ type DeploymentAnalysis struct {
 	LastDeploymentTime time.Time
 	FastestDeployment  time.Duration
 	SlowestDeployment  time.Duration
+
+	// TrimmedAverageDuration ignores the fastest and slowest durations so a
+	// single stuck deployment does not dominate the average
+	TrimmedAverageDuration time.Duration
+	// RawSlowestDeployment is the slowest duration before the slowest cap is applied
+	RawSlowestDeployment time.Duration
 }
 
 type DeploymentInsight struct {
@@ -30,16 +42,33 @@ type DeploymentInsight struct {
 }
 
 type AnalysisEngine struct {
-	mu          sync.RWMutex
-	insights    []DeploymentInsight
-	maxInsights int
+	mu           sync.RWMutex
+	insights     []DeploymentInsight
+	maxInsights  int
+	trimFraction float64
+	slowestCap   time.Duration
 }
 
 func NewAnalysisEngine() *AnalysisEngine {
 	return &AnalysisEngine{
-		insights:    []DeploymentInsight{},
-		maxInsights: 1000,
+		insights:     []DeploymentInsight{},
+		maxInsights:  1000,
+		trimFraction: defaultTrimFraction,
+	}
+}
+
+// SetOutlierHandling configures the fraction of durations trimmed from each end
+// for TrimmedAverageDuration and the longest duration that still counts toward
+// SlowestDeployment (no cap when zero)
+func (ae *AnalysisEngine) SetOutlierHandling(trimFraction float64, slowestCap time.Duration) {
+	ae.mu.Lock()
+	defer ae.mu.Unlock()
+
+	if trimFraction < 0 || trimFraction >= 0.5 {
+		trimFraction = defaultTrimFraction
 	}
+	ae.trimFraction = trimFraction
+	ae.slowestCap = slowestCap
 }
 
 func (ae *AnalysisEngine) RecordDeployment(deploymentID, strategy, status, errorMsg string, duration time.Duration, startTime time.Time) {
@@ -65,6 +94,11 @@ func (ae *AnalysisEngine) RecordDeployment(deploymentID, strategy, status, error
 }
 
 func (ae *AnalysisEngine) GetAnalysis() *DeploymentAnalysis {
+	return ae.GetAnalysisFiltered(nil)
+}
+
+// GetAnalysisFiltered aggregates only the insights accepted by filter (all when nil)
+func (ae *AnalysisEngine) GetAnalysisFiltered(filter func(DeploymentInsight) bool) *DeploymentAnalysis {
 	ae.mu.RLock()
 	defer ae.mu.RUnlock()
 
@@ -73,15 +107,16 @@ func (ae *AnalysisEngine) GetAnalysis() *DeploymentAnalysis {
 		ErrorBreakdown:    make(map[string]int64),
 	}
 
-	if len(ae.insights) == 0 {
-		return analysis
-	}
-
 	var totalDuration time.Duration
+	var durations []time.Duration
 	analysis.FastestDeployment = time.Hour * 24
 	analysis.SlowestDeployment = 0
 
 	for _, insight := range ae.insights {
+		if filter != nil && !filter(insight) {
+			continue
+		}
+
 		analysis.TotalDeployments++
 
 		// Status breakdown
@@ -104,10 +139,14 @@ func (ae *AnalysisEngine) GetAnalysis() *DeploymentAnalysis {
 
 		// Duration stats
 		totalDuration += insight.Duration
+		durations = append(durations, insight.Duration)
 		if insight.Duration < analysis.FastestDeployment {
 			analysis.FastestDeployment = insight.Duration
 		}
-		if insight.Duration > analysis.SlowestDeployment {
+		if insight.Duration > analysis.RawSlowestDeployment {
+			analysis.RawSlowestDeployment = insight.Duration
+		}
+		if insight.Duration > analysis.SlowestDeployment && (ae.slowestCap <= 0 || insight.Duration <= ae.slowestCap) {
 			analysis.SlowestDeployment = insight.Duration
 		}
 
@@ -117,15 +156,70 @@ func (ae *AnalysisEngine) GetAnalysis() *DeploymentAnalysis {
 		}
 	}
 
-	// Calculate success rate
-	if analysis.TotalDeployments > 0 {
-		analysis.SuccessRate = float64(analysis.SuccessfulDeploys) / float64(analysis.TotalDeployments) * 100
-		analysis.AverageDuration = totalDuration / time.Duration(analysis.TotalDeployments)
+	if analysis.TotalDeployments == 0 {
+		analysis.FastestDeployment = 0
+		return analysis
 	}
 
+	// Calculate success rate
+	analysis.SuccessRate = float64(analysis.SuccessfulDeploys) / float64(analysis.TotalDeployments) * 100
+	analysis.AverageDuration = totalDuration / time.Duration(analysis.TotalDeployments)
+	analysis.TrimmedAverageDuration = trimmedMean(durations, ae.trimFraction)
+
 	return analysis
 }
 
+// SuccessRatesByStrategy returns the share of recorded deployments (0-1)
+// that succeeded, per strategy. Strategies with no deployments are absent.
+func (ae *AnalysisEngine) SuccessRatesByStrategy() map[string]float64 {
+	ae.mu.RLock()
+	defer ae.mu.RUnlock()
+
+	totals := make(map[string]int)
+	successes := make(map[string]int)
+	for _, insight := range ae.insights {
+		totals[insight.Strategy]++
+		if insight.Status == "success" {
+			successes[insight.Strategy]++
+		}
+	}
+
+	rates := make(map[string]float64, len(totals))
+	for strategy, total := range totals {
+		rates[strategy] = float64(successes[strategy]) / float64(total)
+	}
+	return rates
+}
+
+// trimmedMean averages durations after dropping the given fraction from each
+// end, rounding up so a single outlier is dropped even from small samples.
+// It falls back to the plain mean when trimming would leave nothing.
+func trimmedMean(durations []time.Duration, fraction float64) time.Duration {
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	trim := int(math.Ceil(float64(len(sorted)) * fraction))
+	if 2*trim >= len(sorted) {
+		trim = 0
+	}
+	kept := sorted[trim : len(sorted)-trim]
+
+	var total time.Duration
+	for _, d := range kept {
+		total += d
+	}
+	return total / time.Duration(len(kept))
+}
+
+// GetAnalysisWindow aggregates only deployments that finished within the last window,
+// so recent reliability can be reported separately from all-time figures
+func (ae *AnalysisEngine) GetAnalysisWindow(window time.Duration) *DeploymentAnalysis {
+	cutoff := time.Now().Add(-window)
+	return ae.GetAnalysisFiltered(func(insight DeploymentInsight) bool {
+		return !insight.EndTime.Before(cutoff)
+	})
+}
+
 func (ae *AnalysisEngine) GetRecentInsights(limit int) []DeploymentInsight {
 	ae.mu.RLock()
 	defer ae.mu.RUnlock()
@@ -0,0 +1,87 @@
+package metrics
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGetAnalysisWindow(t *testing.T) {
+	ae := NewAnalysisEngine()
+	now := time.Now()
+
+	// Two old failures and a recent success and failure
+	ae.RecordDeployment("old-1", "canary", "failed", "timeout", time.Minute, now.Add(-48*time.Hour))
+	ae.RecordDeployment("old-2", "canary", "failed", "timeout", time.Minute, now.Add(-30*time.Hour))
+	ae.RecordDeployment("recent-1", "canary", "success", "", time.Minute, now.Add(-2*time.Hour))
+	ae.RecordDeployment("recent-2", "bluegreen", "failed", "health check", 3*time.Minute, now.Add(-time.Hour))
+
+	all := ae.GetAnalysis()
+	if all.TotalDeployments != 4 || all.SuccessRate != 25 {
+		t.Errorf("expected 4 deployments at 25%% success all-time, got %d at %v", all.TotalDeployments, all.SuccessRate)
+	}
+	if all.ErrorBreakdown["timeout"] != 2 {
+		t.Errorf("expected the old timeouts all-time, got %v", all.ErrorBreakdown)
+	}
+
+	recent := ae.GetAnalysisWindow(24 * time.Hour)
+	if recent.TotalDeployments != 2 || recent.SuccessRate != 50 {
+		t.Errorf("expected 2 deployments at 50%% success in the window, got %d at %v", recent.TotalDeployments, recent.SuccessRate)
+	}
+	if _, ok := recent.ErrorBreakdown["timeout"]; ok {
+		t.Errorf("expected the old timeouts outside the window, got %v", recent.ErrorBreakdown)
+	}
+	if recent.StrategyBreakdown["canary"] != 1 || recent.StrategyBreakdown["bluegreen"] != 1 {
+		t.Errorf("unexpected strategy breakdown in the window: %v", recent.StrategyBreakdown)
+	}
+	if recent.AverageDuration != 2*time.Minute {
+		t.Errorf("expected a 2m average in the window, got %v", recent.AverageDuration)
+	}
+
+	if empty := ae.GetAnalysisWindow(time.Minute); empty.TotalDeployments != 0 || empty.FastestDeployment != 0 {
+		t.Errorf("expected nothing in a window before the latest deployment ended, got %+v", empty)
+	}
+}
+
+func TestTrimmedAverageIgnoresOutlier(t *testing.T) {
+	ae := NewAnalysisEngine()
+	ae.SetOutlierHandling(0.1, time.Hour)
+	start := time.Now().Add(-24 * time.Hour)
+
+	// Nine two-minute deployments and one stuck for three days
+	for i := 0; i < 9; i++ {
+		ae.RecordDeployment("normal", "canary", "success", "", 2*time.Minute, start)
+	}
+	ae.RecordDeployment("stuck", "canary", "failed", "timeout", 72*time.Hour, start)
+
+	analysis := ae.GetAnalysis()
+	if analysis.AverageDuration < 7*time.Hour {
+		t.Errorf("expected the plain average to include the outlier, got %v", analysis.AverageDuration)
+	}
+	if analysis.TrimmedAverageDuration != 2*time.Minute {
+		t.Errorf("expected a 2m trimmed average, got %v", analysis.TrimmedAverageDuration)
+	}
+	if analysis.SlowestDeployment != 2*time.Minute || analysis.RawSlowestDeployment != 72*time.Hour {
+		t.Errorf("expected the slowest cap to skip the outlier, got slowest %v raw %v", analysis.SlowestDeployment, analysis.RawSlowestDeployment)
+	}
+}
+
+func TestTrimmedMean(t *testing.T) {
+	tests := []struct {
+		name      string
+		durations []time.Duration
+		fraction  float64
+		want      time.Duration
+	}{
+		{name: "single outlier dropped from a small sample", durations: []time.Duration{time.Second, 2 * time.Second, 3 * time.Second, time.Hour}, fraction: 0.1, want: 2500 * time.Millisecond},
+		{name: "too few to trim", durations: []time.Duration{time.Second, 3 * time.Second}, fraction: 0.1, want: 2 * time.Second},
+		{name: "no trimming", durations: []time.Duration{time.Second, 2 * time.Second, 6 * time.Second}, fraction: 0, want: 3 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := trimmedMean(tt.durations, tt.fraction); got != tt.want {
+				t.Errorf("trimmedMean(%v, %v) = %v, want %v", tt.durations, tt.fraction, got, tt.want)
+			}
+		})
+	}
+}
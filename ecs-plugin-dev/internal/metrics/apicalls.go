@@ -0,0 +1,108 @@
+package metrics
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type deploymentIDKey struct{}
+
+// WithDeploymentID tags ctx so AWS calls made with it are attributed to the deployment
+func WithDeploymentID(ctx context.Context, deploymentID string) context.Context {
+	return context.WithValue(ctx, deploymentIDKey{}, deploymentID)
+}
+
+// DeploymentIDFromContext returns the deployment ID ctx was tagged with, if any
+func DeploymentIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(deploymentIDKey{}).(string)
+	return id
+}
+
+// APICall is a single AWS API call made on behalf of a deployment
+type APICall struct {
+	Service   string
+	Operation string
+	Status    string
+	Duration  time.Duration
+	Timestamp time.Time
+}
+
+// APICallLog keeps the sequence of AWS API calls made by recent deployments
+type APICallLog struct {
+	mu             sync.Mutex
+	calls          map[string][]APICall
+	order          []string
+	maxDeployments int
+	maxCalls       int
+}
+
+func NewAPICallLog() *APICallLog {
+	return &APICallLog{
+		calls:          make(map[string][]APICall),
+		maxDeployments: 1000,
+		maxCalls:       500,
+	}
+}
+
+// Record appends a call to the deployment's sequence, evicting the oldest
+// deployment once the log is full
+func (l *APICallLog) Record(deploymentID string, call APICall) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	calls, ok := l.calls[deploymentID]
+	if !ok {
+		l.order = append(l.order, deploymentID)
+		if len(l.order) > l.maxDeployments {
+			delete(l.calls, l.order[0])
+			l.order = l.order[1:]
+		}
+	}
+	if len(calls) >= l.maxCalls {
+		return
+	}
+	l.calls[deploymentID] = append(calls, call)
+}
+
+// Calls returns a copy of the calls recorded for a deployment
+func (l *APICallLog) Calls(deploymentID string) ([]APICall, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	calls, ok := l.calls[deploymentID]
+	if !ok {
+		return nil, false
+	}
+	return append([]APICall(nil), calls...), true
+}
+
+var globalAPICallLog = NewAPICallLog()
+
+func GetGlobalAPICallLog() *APICallLog {
+	return globalAPICallLog
+}
+
+// recordDeploymentAPICall adds the call to the API call log when ctx carries a deployment ID
+func recordDeploymentAPICall(ctx context.Context, service, operation, status string, duration time.Duration) {
+	deploymentID := DeploymentIDFromContext(ctx)
+	if deploymentID == "" {
+		return
+	}
+	globalAPICallLog.Record(deploymentID, APICall{
+		Service:   service,
+		Operation: operation,
+		Status:    status,
+		Duration:  duration,
+		Timestamp: time.Now(),
+	})
+}
+
+// RecordMockAWSCall records a mock-mode AWS call in the deployment's API call
+// log without touching the Prometheus AWS call metrics
+func RecordMockAWSCall(ctx context.Context, service, operation string) {
+	recordDeploymentAPICall(ctx, service, operation, "mock", 0)
+}
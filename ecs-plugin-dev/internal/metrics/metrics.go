@@ -1,10 +1,13 @@
 package metrics
 
 import (
+	"context"
+	"sync"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var (
@@ -33,6 +36,22 @@ var (
 		},
 	)
 
+	ClusterDeploymentsInProgress = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ecs_cluster_deployments_in_progress",
+			Help: "Number of deployments currently in progress per cluster",
+		},
+		[]string{"cluster"},
+	)
+
+	DeploymentsRejectedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ecs_deployments_rejected_total",
+			Help: "Deployments rejected because a concurrency limit was reached",
+		},
+		[]string{"limit"},
+	)
+
 	// AWS API metrics
 	AWSAPICallsTotal = promauto.NewCounterVec(
 		prometheus.CounterOpts{
@@ -51,6 +70,22 @@ var (
 		[]string{"service", "operation"},
 	)
 
+	AWSCircuitBreakerState = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "ecs_aws_circuit_breaker_state",
+			Help: "State of each AWS client's circuit breaker: 0 closed, 1 half-open, 2 open",
+		},
+		[]string{"service"},
+	)
+
+	AWSCircuitBreakerRejectionsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "ecs_aws_circuit_breaker_rejections_total",
+			Help: "AWS API calls failed fast by an open circuit breaker",
+		},
+		[]string{"service"},
+	)
+
 	// Strategy-specific metrics
 	CanaryStagesTotal = promauto.NewCounterVec(
 		prometheus.CounterOpts{
@@ -68,6 +103,15 @@ var (
 		[]string{"strategy", "status"},
 	)
 
+	TrafficShiftDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "ecs_traffic_shift_duration_seconds",
+			Help:    "Time taken to update load balancer target group weights",
+			Buckets: prometheus.ExponentialBuckets(0.05, 2, 10),
+		},
+		[]string{"strategy"},
+	)
+
 	// Error metrics
 	ErrorsTotal = promauto.NewCounterVec(
 		prometheus.CounterOpts{
@@ -78,16 +122,137 @@ var (
 	)
 )
 
+func init() {
+	prometheus.MustRegister(newSuccessRateCollector(globalAnalysisEngine))
+}
+
+// successRateCollector exposes each strategy's deployment success rate,
+// computed from the analysis engine on every scrape
+type successRateCollector struct {
+	engine *AnalysisEngine
+	desc   *prometheus.Desc
+}
+
+func newSuccessRateCollector(engine *AnalysisEngine) *successRateCollector {
+	return &successRateCollector{
+		engine: engine,
+		desc: prometheus.NewDesc(
+			"ecs_deployment_success_rate",
+			"Share of recent deployments (0-1) that succeeded, per strategy",
+			[]string{"strategy"}, nil,
+		),
+	}
+}
+
+func (c *successRateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.desc
+}
+
+// Collect reports only strategies that have deployments, so a rate is never
+// computed from zero samples
+func (c *successRateCollector) Collect(ch chan<- prometheus.Metric) {
+	for strategy, rate := range c.engine.SuccessRatesByStrategy() {
+		ch <- prometheus.MustNewConstMetric(c.desc, prometheus.GaugeValue, rate, strategy)
+	}
+}
+
+// exemplarConfig controls whether duration histograms carry trace exemplars.
+// Trace IDs are read from the OpenTelemetry span in the context unless
+// SetTraceIDExtractor replaces the extractor.
+var exemplarConfig = struct {
+	mu             sync.RWMutex
+	tracingEnabled bool
+	traceIDFn      func(ctx context.Context) string
+}{traceIDFn: spanTraceID}
+
+// spanTraceID returns the trace ID of the sampled span in ctx, or ""
+func spanTraceID(ctx context.Context) string {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() || !sc.IsSampled() {
+		return ""
+	}
+	return sc.TraceID().String()
+}
+
+// SetTracingEnabled toggles trace exemplars on duration histograms
+func SetTracingEnabled(enabled bool) {
+	exemplarConfig.mu.Lock()
+	defer exemplarConfig.mu.Unlock()
+	exemplarConfig.tracingEnabled = enabled
+}
+
+// SetTraceIDExtractor registers the function used to read the active trace ID from a context
+func SetTraceIDExtractor(fn func(ctx context.Context) string) {
+	exemplarConfig.mu.Lock()
+	defer exemplarConfig.mu.Unlock()
+	exemplarConfig.traceIDFn = fn
+}
+
+// activeTraceID returns the trace ID for ctx, or "" when tracing is disabled
+func activeTraceID(ctx context.Context) string {
+	exemplarConfig.mu.RLock()
+	defer exemplarConfig.mu.RUnlock()
+
+	if !exemplarConfig.tracingEnabled || exemplarConfig.traceIDFn == nil || ctx == nil {
+		return ""
+	}
+	return exemplarConfig.traceIDFn(ctx)
+}
+
+// observeWithTrace records value, attaching the active trace ID as an exemplar when available
+func observeWithTrace(ctx context.Context, observer prometheus.Observer, value float64) {
+	if traceID := activeTraceID(ctx); traceID != "" {
+		if eo, ok := observer.(prometheus.ExemplarObserver); ok {
+			eo.ObserveWithExemplar(value, prometheus.Labels{"trace_id": traceID})
+			return
+		}
+	}
+	observer.Observe(value)
+}
+
 // RecordDeployment records a deployment attempt
 func RecordDeployment(strategy, status string, duration time.Duration) {
+	RecordDeploymentContext(context.Background(), strategy, status, duration)
+}
+
+// RecordDeploymentContext records a deployment attempt with a trace exemplar from ctx
+func RecordDeploymentContext(ctx context.Context, strategy, status string, duration time.Duration) {
 	DeploymentsTotal.WithLabelValues(strategy, status).Inc()
-	DeploymentDuration.WithLabelValues(strategy).Observe(duration.Seconds())
+	observeWithTrace(ctx, DeploymentDuration.WithLabelValues(strategy), duration.Seconds())
 }
 
 // RecordAWSCall records an AWS API call
 func RecordAWSCall(service, operation, status string, duration time.Duration) {
+	RecordAWSCallContext(context.Background(), service, operation, status, duration)
+}
+
+// RecordAWSCallContext records an AWS API call with a trace exemplar from ctx
+func RecordAWSCallContext(ctx context.Context, service, operation, status string, duration time.Duration) {
 	AWSAPICallsTotal.WithLabelValues(service, operation, status).Inc()
-	AWSAPICallDuration.WithLabelValues(service, operation).Observe(duration.Seconds())
+	observeWithTrace(ctx, AWSAPICallDuration.WithLabelValues(service, operation), duration.Seconds())
+	recordDeploymentAPICall(ctx, service, operation, status, duration)
+}
+
+// SetCircuitBreakerState records the state of service's circuit breaker:
+// 0 closed, 1 half-open, 2 open
+func SetCircuitBreakerState(service string, state int) {
+	AWSCircuitBreakerState.WithLabelValues(service).Set(float64(state))
+}
+
+// RecordCircuitBreakerRejection records a call an open breaker refused
+func RecordCircuitBreakerRejection(service string) {
+	AWSCircuitBreakerRejectionsTotal.WithLabelValues(service).Inc()
+}
+
+// RecordTrafficShift records a traffic shift and how long it took
+func RecordTrafficShift(strategy, status string, duration time.Duration) {
+	RecordTrafficShiftContext(context.Background(), strategy, status, duration)
+}
+
+// RecordTrafficShiftContext records a traffic shift with a trace exemplar from ctx
+func RecordTrafficShiftContext(ctx context.Context, strategy, status string, duration time.Duration) {
+	TrafficShiftsTotal.WithLabelValues(strategy, status).Inc()
+	observeWithTrace(ctx, TrafficShiftDuration.WithLabelValues(strategy), duration.Seconds())
 }
 
 // RecordError records an error
@@ -104,3 +269,14 @@ func IncrementInProgress() {
 func DecrementInProgress() {
 	DeploymentsInProgress.Dec()
 }
+
+// SetClusterDeployments records the number of deployments running on cluster
+func SetClusterDeployments(cluster string, n int) {
+	ClusterDeploymentsInProgress.WithLabelValues(cluster).Set(float64(n))
+}
+
+// RecordCapacityRejection records a deployment turned away by the global or
+// per-cluster concurrency limit
+func RecordCapacityRejection(limit string) {
+	DeploymentsRejectedTotal.WithLabelValues(limit).Inc()
+}
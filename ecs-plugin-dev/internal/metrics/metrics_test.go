@@ -0,0 +1,114 @@
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// sampledContext returns a context carrying a sampled span of traceID
+func sampledContext(t *testing.T, traceID string) context.Context {
+	t.Helper()
+	tid, err := trace.TraceIDFromHex(traceID)
+	if err != nil {
+		t.Fatalf("invalid trace ID: %v", err)
+	}
+	sid, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	sc := trace.NewSpanContext(trace.SpanContextConfig{TraceID: tid, SpanID: sid, TraceFlags: trace.FlagsSampled})
+	return trace.ContextWithSpanContext(context.Background(), sc)
+}
+
+// exemplarTraceIDs returns the trace_id label of every exemplar on a histogram
+func exemplarTraceIDs(t *testing.T, observer prometheus.Observer) []string {
+	t.Helper()
+	var m dto.Metric
+	if err := observer.(prometheus.Metric).Write(&m); err != nil {
+		t.Fatalf("failed to read histogram: %v", err)
+	}
+
+	var ids []string
+	for _, bucket := range m.GetHistogram().GetBucket() {
+		for _, label := range bucket.GetExemplar().GetLabel() {
+			if label.GetName() == "trace_id" {
+				ids = append(ids, label.GetValue())
+			}
+		}
+	}
+	return ids
+}
+
+func TestDurationExemplarsCarryTraceID(t *testing.T) {
+	const traceID = "4bf92f3577b34da6a3ce929d0e0e4736"
+	ctx := sampledContext(t, traceID)
+
+	tests := []struct {
+		name     string
+		tracing  bool
+		record   func(ctx context.Context, label string)
+		observer func(label string) prometheus.Observer
+		want     bool
+	}{
+		{
+			name:    "deployment duration with tracing",
+			tracing: true,
+			record: func(ctx context.Context, label string) {
+				RecordDeploymentContext(ctx, label, "success", 3*time.Second)
+			},
+			observer: func(label string) prometheus.Observer { return DeploymentDuration.WithLabelValues(label) },
+			want:     true,
+		},
+		{
+			name:    "aws call duration with tracing",
+			tracing: true,
+			record: func(ctx context.Context, label string) {
+				RecordAWSCallContext(ctx, "ecs", label, "success", 50*time.Millisecond)
+			},
+			observer: func(label string) prometheus.Observer { return AWSAPICallDuration.WithLabelValues("ecs", label) },
+			want:     true,
+		},
+		{
+			name:    "deployment duration without tracing",
+			tracing: false,
+			record: func(ctx context.Context, label string) {
+				RecordDeploymentContext(ctx, label, "success", 3*time.Second)
+			},
+			observer: func(label string) prometheus.Observer { return DeploymentDuration.WithLabelValues(label) },
+			want:     false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			SetTracingEnabled(tt.tracing)
+			defer SetTracingEnabled(false)
+
+			label := "exemplar-" + t.Name()
+			tt.record(ctx, label)
+			ids := exemplarTraceIDs(t, tt.observer(label))
+
+			if !tt.want {
+				if len(ids) != 0 {
+					t.Fatalf("expected no exemplars, got %v", ids)
+				}
+				return
+			}
+			if len(ids) != 1 || ids[0] != traceID {
+				t.Fatalf("expected one exemplar with trace ID %s, got %v", traceID, ids)
+			}
+		})
+	}
+}
+
+func TestDurationExemplarSkippedOutsideTrace(t *testing.T) {
+	SetTracingEnabled(true)
+	defer SetTracingEnabled(false)
+
+	RecordDeploymentContext(context.Background(), "exemplar-untraced", "success", time.Second)
+	if ids := exemplarTraceIDs(t, DeploymentDuration.WithLabelValues("exemplar-untraced")); len(ids) != 0 {
+		t.Fatalf("expected no exemplars outside a trace, got %v", ids)
+	}
+}
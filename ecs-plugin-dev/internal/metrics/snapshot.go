@@ -0,0 +1,70 @@
+package metrics
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+// grpcService is the service label MetricsInterceptor records RPCs under in
+// the AWS call metrics; those calls are not AWS calls
+const grpcService = "grpc"
+
+// Snapshot holds the key counters as plain numbers, for callers that cannot
+// scrape the Prometheus endpoint
+type Snapshot struct {
+	// DeploymentsByStatus counts finished deployments by outcome: success,
+	// failed or cancelled
+	DeploymentsByStatus   map[string]int64
+	DeploymentsInProgress int64
+	AWSAPICalls           int64
+	AWSAPIErrors          int64
+	// ErrorsByComponent counts errors recorded by RecordError per component
+	ErrorsByComponent map[string]int64
+}
+
+// TakeSnapshot reads the key counters from gatherer, normally
+// prometheus.DefaultGatherer where this package registers its metrics
+func TakeSnapshot(gatherer prometheus.Gatherer) (*Snapshot, error) {
+	families, err := gatherer.Gather()
+	if err != nil {
+		return nil, fmt.Errorf("failed to gather metrics: %w", err)
+	}
+
+	snapshot := &Snapshot{
+		DeploymentsByStatus: make(map[string]int64),
+		ErrorsByComponent:   make(map[string]int64),
+	}
+	for _, family := range families {
+		for _, m := range family.GetMetric() {
+			labels := labelMap(m)
+			switch family.GetName() {
+			case "ecs_deployments_total":
+				snapshot.DeploymentsByStatus[labels["status"]] += int64(m.GetCounter().GetValue())
+			case "ecs_deployments_in_progress":
+				snapshot.DeploymentsInProgress = int64(m.GetGauge().GetValue())
+			case "ecs_aws_api_calls_total":
+				if labels["service"] == grpcService {
+					continue
+				}
+				calls := int64(m.GetCounter().GetValue())
+				snapshot.AWSAPICalls += calls
+				if labels["status"] != "success" {
+					snapshot.AWSAPIErrors += calls
+				}
+			case "ecs_errors_total":
+				snapshot.ErrorsByComponent[labels["component"]] += int64(m.GetCounter().GetValue())
+			}
+		}
+	}
+	return snapshot, nil
+}
+
+func labelMap(m *dto.Metric) map[string]string {
+	labels := make(map[string]string, len(m.GetLabel()))
+	for _, pair := range m.GetLabel() {
+		labels[pair.GetName()] = pair.GetValue()
+	}
+	return labels
+}
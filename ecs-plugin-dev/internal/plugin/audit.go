@@ -0,0 +1,75 @@
+package plugin
+
+import (
+	"log"
+
+	"ecs-plugin-dev/internal/audit"
+)
+
+// auditStarted records that a deployment was accepted, and which
+// deployment it retries if any
+func (r *Router) auditStarted(req *DeploymentRequest) {
+	event := audit.AuditEvent{
+		EventType:    audit.EventDeploymentStarted,
+		DeploymentID: req.DeploymentID,
+		ClusterARN:   req.ClusterARN,
+		ServiceName:  req.ServiceName,
+		Strategy:     req.Strategy,
+		User:         req.User,
+		Status:       "started",
+
+		CorrelationID: req.CorrelationID,
+	}
+	if req.RetriedFrom != "" {
+		event.Metadata = map[string]interface{}{"retried_from": req.RetriedFrom}
+	}
+	r.audit(event)
+}
+
+// auditOutcome records how a finished deployment ended, based on its final status
+func (r *Router) auditOutcome(req *DeploymentRequest) {
+	val, ok := r.statuses.Load(req.DeploymentID)
+	if !ok {
+		return
+	}
+	status := val.(*DeploymentStatus)
+
+	event := audit.AuditEvent{
+		DeploymentID: req.DeploymentID,
+		ClusterARN:   req.ClusterARN,
+		ServiceName:  req.ServiceName,
+		Strategy:     req.Strategy,
+		User:         req.User,
+		Metadata: map[string]interface{}{
+			"duration_seconds": status.EndTime.Sub(status.StartTime).Seconds(),
+		},
+		CorrelationID: req.CorrelationID,
+	}
+	switch status.Status {
+	case "SUCCESS":
+		event.EventType = audit.EventDeploymentCompleted
+		event.Status = "completed"
+	case "CANCELLED":
+		event.EventType = audit.EventDeploymentCancelled
+		event.Status = "cancelled"
+		event.ErrorMessage = status.Message
+	case "APPROVAL_TIMEOUT":
+		event.EventType = audit.EventApprovalTimeout
+		event.Status = "timeout"
+		event.ErrorMessage = status.Message
+	default:
+		event.EventType = audit.EventDeploymentFailed
+		event.Status = "failed"
+		event.ErrorMessage = status.Message
+	}
+	r.audit(event)
+}
+
+func (r *Router) audit(event audit.AuditEvent) {
+	if r.auditLogger == nil {
+		return
+	}
+	if err := r.auditLogger.Log(event); err != nil {
+		log.Printf("[ROUTER] Failed to write audit event %s for %s: %v", event.EventType, event.DeploymentID, err)
+	}
+}
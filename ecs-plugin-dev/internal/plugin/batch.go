@@ -0,0 +1,317 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"ecs-plugin-dev/internal/audit"
+	"ecs-plugin-dev/internal/util"
+)
+
+// BatchRequest deploys several services together under one batch ID
+type BatchRequest struct {
+	BatchID     string
+	Deployments []*DeploymentRequest
+
+	// AllOrNothing rolls back the services that already deployed, and cancels
+	// the ones still running, as soon as any deployment in the batch fails
+	AllOrNothing bool
+}
+
+// BatchItemResult reports how a single deployment of a batch was routed
+type BatchItemResult struct {
+	DeploymentID string
+	ClusterARN   string
+	ServiceName  string
+	Success      bool
+	Message      string
+	Err          error // Set when routing failed
+}
+
+// BatchResult reports how each deployment of a batch was routed
+type BatchResult struct {
+	BatchID string
+	Success bool
+	Message string
+	Results []BatchItemResult
+}
+
+// BatchStatus reports a batch's progress along with each deployment it started
+type BatchStatus struct {
+	BatchID      string
+	Status       string // RUNNING, SUCCESS, FAILED or ROLLED_BACK
+	Message      string
+	AllOrNothing bool
+	Deployments  []DeploymentEntry
+}
+
+// batchState tracks the deployments a batch started. mu guards
+// deploymentIDs while the batch is being routed, and the outcome fields.
+type batchState struct {
+	req *BatchRequest
+	// ctx carries the batch request's values, such as its correlation ID,
+	// into coordination after the request returns
+	ctx context.Context
+
+	mu            sync.Mutex
+	deploymentIDs []string
+	status        string
+	message       string
+}
+
+// RouteBatch routes every deployment of a batch and coordinates them under the
+// batch ID until all finish. In all-or-nothing mode the whole batch is
+// validated before anything starts, and routing stops at the first failure.
+func (r *Router) RouteBatch(ctx context.Context, req *BatchRequest) (*BatchResult, error) {
+	if req.BatchID == "" {
+		return nil, fmt.Errorf("batch_id is required")
+	}
+	if len(req.Deployments) == 0 {
+		return nil, fmt.Errorf("at least one deployment is required in batch %s", req.BatchID)
+	}
+
+	seenIDs := make(map[string]bool, len(req.Deployments))
+	seenServices := make(map[string]bool, len(req.Deployments))
+	for _, d := range req.Deployments {
+		serviceKey := fmt.Sprintf("%s/%s", d.ClusterARN, d.ServiceName)
+		if seenIDs[d.DeploymentID] {
+			return nil, fmt.Errorf("invalid batch: deployment %s appears more than once", d.DeploymentID)
+		}
+		if seenServices[serviceKey] {
+			return nil, fmt.Errorf("invalid batch: service %s appears more than once", serviceKey)
+		}
+		seenIDs[d.DeploymentID] = true
+		seenServices[serviceKey] = true
+
+		if req.AllOrNothing {
+			if err := r.ValidateRequest(d); err != nil {
+				return nil, fmt.Errorf("deployment %s: %w", d.DeploymentID, err)
+			}
+		}
+	}
+
+	batch := &batchState{
+		req:     req,
+		ctx:     context.WithoutCancel(ctx),
+		status:  "RUNNING",
+		message: "batch in progress",
+	}
+	if _, loaded := r.batches.LoadOrStore(req.BatchID, batch); loaded {
+		return nil, fmt.Errorf("invalid batch: batch %s already exists", req.BatchID)
+	}
+
+	result := &BatchResult{BatchID: req.BatchID, Success: true}
+	failed := 0
+	for i, d := range req.Deployments {
+		item := BatchItemResult{
+			DeploymentID: d.DeploymentID,
+			ClusterARN:   d.ClusterARN,
+			ServiceName:  d.ServiceName,
+		}
+
+		routed, err := r.RouteDeployment(ctx, d)
+		switch {
+		case err != nil:
+			item.Message = err.Error()
+			item.Err = err
+		case !routed.Success:
+			item.Message = routed.Message
+			item.Err = fmt.Errorf("%s", routed.Message)
+		default:
+			item.Success = true
+			item.Message = routed.Message
+			if routed.DeploymentID != "" {
+				item.DeploymentID = routed.DeploymentID
+			}
+			// Dry runs leave nothing behind to coordinate
+			if !d.DryRun {
+				batch.mu.Lock()
+				batch.deploymentIDs = append(batch.deploymentIDs, item.DeploymentID)
+				batch.mu.Unlock()
+			}
+		}
+		result.Results = append(result.Results, item)
+
+		if item.Err == nil {
+			continue
+		}
+		failed++
+		result.Success = false
+		if req.AllOrNothing {
+			for _, skipped := range req.Deployments[i+1:] {
+				result.Results = append(result.Results, BatchItemResult{
+					DeploymentID: skipped.DeploymentID,
+					ClusterARN:   skipped.ClusterARN,
+					ServiceName:  skipped.ServiceName,
+					Message:      fmt.Sprintf("not started: deployment %s in the batch failed", d.DeploymentID),
+				})
+			}
+			break
+		}
+	}
+
+	switch {
+	case failed == 0:
+		result.Message = fmt.Sprintf("batch initiated with %d deployments", len(req.Deployments))
+	case req.AllOrNothing:
+		result.Message = fmt.Sprintf("batch aborted: %d of %d deployments failed to start", failed, len(req.Deployments))
+	default:
+		result.Message = fmt.Sprintf("batch initiated with %d of %d deployments; %d failed to start",
+			len(req.Deployments)-failed, len(req.Deployments), failed)
+	}
+
+	util.Logf(ctx, "[BATCH] Batch %s: %s", req.BatchID, result.Message)
+	go r.coordinateBatch(batch, failed > 0)
+
+	return result, nil
+}
+
+// coordinateBatch waits for every deployment of a batch to finish. In
+// all-or-nothing mode the first failure cancels the deployments still
+// running and rolls back the ones that already succeeded.
+func (r *Router) coordinateBatch(batch *batchState, startFailed bool) {
+	batchID := batch.req.BatchID
+	abort := startFailed && batch.req.AllOrNothing
+	aborted := false
+	abortReason := "a deployment failed to start"
+	var failures []string
+
+	ticker := time.NewTicker(dependencyPollInterval)
+	defer ticker.Stop()
+
+	for {
+		failures = failures[:0]
+		running := 0
+		for _, id := range batch.deploymentIDs {
+			status, ok := r.loadStatus(id)
+			switch {
+			case !ok:
+				failures = append(failures, fmt.Sprintf("%s: not found", id))
+			case status.Status == "SUCCESS":
+			case IsTerminalStatus(status.Status):
+				failures = append(failures, fmt.Sprintf("%s: %s", id, status.Status))
+			default:
+				running++
+			}
+		}
+
+		if len(failures) > 0 && batch.req.AllOrNothing {
+			abort = true
+		}
+		if abort && !aborted {
+			aborted = true
+			if len(failures) > 0 {
+				abortReason = strings.Join(failures, ", ")
+			}
+			util.Logf(batch.ctx, "[BATCH] Batch %s failed, cancelling its running deployments", batchID)
+			for _, id := range batch.deploymentIDs {
+				if status, ok := r.loadStatus(id); ok && !IsTerminalStatus(status.Status) {
+					if err := r.CancelDeployment(id); err != nil {
+						util.Logf(batch.ctx, "[BATCH] Could not cancel deployment %s: %v", id, err)
+					}
+				}
+			}
+		}
+
+		if running == 0 {
+			break
+		}
+		<-ticker.C
+	}
+
+	status, message := "SUCCESS", "all deployments succeeded"
+	switch {
+	case aborted:
+		status = "ROLLED_BACK"
+		message = r.rollBackBatch(batch, abortReason)
+	case len(failures) > 0:
+		status = "FAILED"
+		message = fmt.Sprintf("some deployments failed: %s", strings.Join(failures, ", "))
+	case startFailed:
+		status = "FAILED"
+		message = "some deployments failed to start"
+	}
+
+	batch.mu.Lock()
+	batch.status, batch.message = status, message
+	batch.mu.Unlock()
+	util.Logf(batch.ctx, "[BATCH] Batch %s finished with %s: %s", batchID, status, message)
+}
+
+// rollBackBatch rolls back every deployment of an aborted batch that had
+// already succeeded and returns a summary of what happened
+func (r *Router) rollBackBatch(batch *batchState, reason string) string {
+	var rolledBack, rollbackFailed []string
+	for _, id := range batch.deploymentIDs {
+		status, ok := r.loadStatus(id)
+		if !ok || status.Status != "SUCCESS" {
+			continue
+		}
+
+		err := r.Rollback(batch.ctx, id, status.ClusterARN, status.ServiceName)
+		event := audit.AuditEvent{
+			EventType:    audit.EventDeploymentRollback,
+			DeploymentID: id,
+			ClusterARN:   status.ClusterARN,
+			ServiceName:  status.ServiceName,
+			Strategy:     status.Strategy,
+			Status:       "success",
+			Metadata:     map[string]interface{}{"batch_id": batch.req.BatchID},
+
+			CorrelationID: util.CorrelationIDFromContext(batch.ctx),
+		}
+		if err != nil {
+			util.Logf(batch.ctx, "[BATCH] Rollback of %s in batch %s failed: %v", id, batch.req.BatchID, err)
+			event.Status = "failed"
+			event.ErrorMessage = err.Error()
+			rollbackFailed = append(rollbackFailed, id)
+			r.audit(event)
+			continue
+		}
+		r.audit(event)
+		rolledBack = append(rolledBack, id)
+
+		updated := *status
+		updated.Status = "FAILED"
+		updated.Message = fmt.Sprintf("rolled back: batch %s failed (%s)", batch.req.BatchID, reason)
+		r.setStatus(id, &updated)
+	}
+
+	message := fmt.Sprintf("batch failed (%s); rolled back %d deployments", reason, len(rolledBack))
+	if len(rollbackFailed) > 0 {
+		message += fmt.Sprintf("; rollback failed for %s", strings.Join(rollbackFailed, ", "))
+	}
+	return message
+}
+
+// GetBatchStatus returns a batch's status and the current status of each
+// deployment it started
+func (r *Router) GetBatchStatus(batchID string) (*BatchStatus, error) {
+	val, ok := r.batches.Load(batchID)
+	if !ok {
+		return nil, fmt.Errorf("batch not found: %s", batchID)
+	}
+	batch := val.(*batchState)
+
+	batch.mu.Lock()
+	status := &BatchStatus{
+		BatchID:      batchID,
+		Status:       batch.status,
+		Message:      batch.message,
+		AllOrNothing: batch.req.AllOrNothing,
+	}
+	ids := append([]string(nil), batch.deploymentIDs...)
+	batch.mu.Unlock()
+
+	for _, id := range ids {
+		entry := DeploymentEntry{DeploymentID: id, Status: DeploymentStatus{Status: "UNKNOWN"}}
+		if current, ok := r.loadStatus(id); ok {
+			entry.Status = *current
+		}
+		status.Deployments = append(status.Deployments, entry)
+	}
+	return status, nil
+}
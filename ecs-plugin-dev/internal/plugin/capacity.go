@@ -0,0 +1,61 @@
+package plugin
+
+import (
+	"fmt"
+	"sync"
+
+	"ecs-plugin-dev/internal/metrics"
+)
+
+// deploymentLimiter caps how many deployments run at once, in total and per
+// cluster, so a burst of requests cannot pile onto one ECS cluster and get
+// throttled. A limit of 0 leaves that dimension unbounded.
+type deploymentLimiter struct {
+	mu            sync.Mutex
+	maxTotal      int
+	maxPerCluster int
+	total         int
+	perCluster    map[string]int
+}
+
+func newDeploymentLimiter(maxTotal, maxPerCluster int) *deploymentLimiter {
+	return &deploymentLimiter{
+		maxTotal:      maxTotal,
+		maxPerCluster: maxPerCluster,
+		perCluster:    make(map[string]int),
+	}
+}
+
+// acquire takes a slot for a deployment to cluster, failing with a
+// "capacity exceeded" error when either limit is reached
+func (l *deploymentLimiter) acquire(cluster string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.maxTotal > 0 && l.total >= l.maxTotal {
+		metrics.RecordCapacityRejection("global")
+		return fmt.Errorf("capacity exceeded: %d deployments already running (max %d)", l.total, l.maxTotal)
+	}
+	if l.maxPerCluster > 0 && l.perCluster[cluster] >= l.maxPerCluster {
+		metrics.RecordCapacityRejection("cluster")
+		return fmt.Errorf("capacity exceeded: %d deployments already running on cluster %s (max %d)", l.perCluster[cluster], cluster, l.maxPerCluster)
+	}
+
+	l.total++
+	l.perCluster[cluster]++
+	metrics.SetClusterDeployments(cluster, l.perCluster[cluster])
+	return nil
+}
+
+// release frees a slot taken by acquire
+func (l *deploymentLimiter) release(cluster string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.total--
+	l.perCluster[cluster]--
+	metrics.SetClusterDeployments(cluster, l.perCluster[cluster])
+	if l.perCluster[cluster] <= 0 {
+		delete(l.perCluster, cluster)
+	}
+}
@@ -0,0 +1,95 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"ecs-plugin-dev/internal/util"
+)
+
+// errShuttingDown rejects deployments that arrive once the router is draining
+var errShuttingDown = errors.New("server is shutting down, not accepting new deployments")
+
+// isDraining reports whether Drain has been called
+func (r *Router) isDraining() bool {
+	r.drainMu.Lock()
+	defer r.drainMu.Unlock()
+	return r.draining
+}
+
+// trackDeployment registers a deployment goroutine about to start with
+// inflight, or reports false once the router is draining
+func (r *Router) trackDeployment() bool {
+	r.drainMu.Lock()
+	defer r.drainMu.Unlock()
+	if r.draining {
+		return false
+	}
+	r.inflight.Add(1)
+	return true
+}
+
+// Drain stops the router accepting deployments and waits until every running
+// deployment reaches a terminal state or ctx is done. Queued deployments never
+// start; they and any deployments still running when ctx ends are recorded as
+// INTERRUPTED so they do not look active after a restart.
+func (r *Router) Drain(ctx context.Context) error {
+	r.drainMu.Lock()
+	r.draining = true
+	r.drainMu.Unlock()
+
+	r.interruptQueued()
+
+	done := make(chan struct{})
+	go func() {
+		r.inflight.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		util.Logf(ctx, "[ROUTER] All in-flight deployments finished")
+		return nil
+	case <-ctx.Done():
+		interrupted := 0
+		r.cancelFuncs.Range(func(key, _ interface{}) bool {
+			if r.markInterrupted(key.(string), "deployment interrupted by server shutdown") {
+				interrupted++
+			}
+			return true
+		})
+		util.Logf(ctx, "[ROUTER] Drain ended before deployments finished, %d marked interrupted", interrupted)
+		return ctx.Err()
+	}
+}
+
+// interruptQueued empties every service queue, marking the deployments in
+// them INTERRUPTED
+func (r *Router) interruptQueued() {
+	r.queueMu.Lock()
+	defer r.queueMu.Unlock()
+
+	for serviceKey, queue := range r.queues {
+		for _, queued := range queue {
+			r.markInterrupted(queued.req.DeploymentID, "deployment interrupted by server shutdown while queued")
+		}
+		delete(r.queues, serviceKey)
+	}
+}
+
+// markInterrupted records a deployment that has not finished as INTERRUPTED,
+// reporting false if it had already reached a terminal state
+func (r *Router) markInterrupted(deploymentID, message string) bool {
+	status, ok := r.loadStatus(deploymentID)
+	if !ok || IsTerminalStatus(status.Status) {
+		return false
+	}
+
+	interrupted := *status
+	interrupted.Status = "INTERRUPTED"
+	interrupted.Message = message
+	interrupted.EndTime = time.Now()
+	r.setStatus(deploymentID, &interrupted)
+	return true
+}
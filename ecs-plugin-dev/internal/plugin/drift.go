@@ -0,0 +1,66 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"ecs-plugin-dev/internal/audit"
+	"ecs-plugin-dev/internal/executor"
+	"ecs-plugin-dev/internal/util"
+)
+
+// driftReconcilePrefix starts the serviceQueue holder of a service whose
+// drift is being reconciled
+const driftReconcilePrefix = "drift-reconcile:"
+
+// DetectDrift compares a service with the task definition it should run
+func (r *Router) DetectDrift(ctx context.Context, cluster, service, expectedTaskDef string) (*executor.DriftResult, error) {
+	return r.executor.DetectDrift(ctx, cluster, service, expectedTaskDef)
+}
+
+// ReconcileDrift returns a drifted service to expectedTaskDef on demand. It
+// holds the service like a deployment meanwhile, so it is refused while one
+// runs, and deployments queued behind it start once it is done.
+func (r *Router) ReconcileDrift(ctx context.Context, cluster, service, expectedTaskDef, user string) (*executor.DriftResult, error) {
+	serviceKey := fmt.Sprintf("%s/%s", cluster, service)
+	holder := driftReconcilePrefix + serviceKey
+
+	r.expireServiceLock(serviceKey)
+	r.queueMu.Lock()
+	if _, loaded := r.serviceQueue.LoadOrStore(serviceKey, holder); loaded {
+		r.queueMu.Unlock()
+		return nil, fmt.Errorf("deployment already in progress for service %s", serviceKey)
+	}
+	r.touchServiceLock(holder)
+	r.queueMu.Unlock()
+
+	stopHeartbeat := r.heartbeatServiceLock(holder)
+	defer func() {
+		stopHeartbeat()
+		r.releaseService(serviceKey, holder)
+	}()
+
+	drift, err := r.executor.ReconcileDrift(ctx, cluster, service, expectedTaskDef)
+	if err != nil {
+		return drift, err
+	}
+
+	if drift.Status == executor.DriftFixed {
+		log.Printf("[ROUTER] Reconciled %d drift(s) on service %s", len(drift.Drifts), serviceKey)
+		r.audit(audit.AuditEvent{
+			EventType:   audit.EventDriftReconciled,
+			User:        user,
+			ClusterARN:  cluster,
+			ServiceName: service,
+			Status:      string(audit.EventDriftReconciled),
+			Metadata: map[string]interface{}{
+				"expected_task_definition": expectedTaskDef,
+				"drifts":                   drift.Drifts,
+				"trigger":                  "on_demand",
+			},
+			CorrelationID: util.CorrelationIDFromContext(ctx),
+		})
+	}
+	return drift, nil
+}
@@ -0,0 +1,66 @@
+package plugin
+
+import (
+	"sync"
+	"time"
+
+	"ecs-plugin-dev/internal/strategy"
+)
+
+// etaTracker estimates the time left in a deployment from its strategy plan
+// and the stages recorded so far
+type etaTracker struct {
+	plan     []time.Duration
+	recorder *strategy.DeploymentRecorder
+
+	mu        sync.Mutex
+	execStart time.Time
+}
+
+// started marks the point where the strategy began executing
+func (t *etaTracker) started(now time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.execStart = now
+}
+
+// remaining sums the planned durations of stages not yet recorded, less the
+// time already spent on the current one
+func (t *etaTracker) remaining(now time.Time) time.Duration {
+	stages := t.recorder.Stages()
+	if len(stages) >= len(t.plan) {
+		return 0
+	}
+
+	var remaining time.Duration
+	for _, d := range t.plan[len(stages):] {
+		remaining += d
+	}
+
+	t.mu.Lock()
+	since := t.execStart
+	t.mu.Unlock()
+	if since.IsZero() {
+		return remaining
+	}
+	if len(stages) > 0 {
+		since = stages[len(stages)-1].Timestamp
+	}
+
+	remaining -= now.Sub(since)
+	if remaining < 0 {
+		return 0
+	}
+	return remaining
+}
+
+// EstimateRemaining returns the estimated time left for a running deployment.
+// It reports false when the deployment is unknown, finished, or its strategy
+// has no plan to estimate from.
+func (r *Router) EstimateRemaining(deploymentID string) (time.Duration, bool) {
+	val, ok := r.etas.Load(deploymentID)
+	if !ok {
+		return 0, false
+	}
+	return val.(*etaTracker).remaining(time.Now()), true
+}
@@ -0,0 +1,58 @@
+package plugin
+
+import (
+	"testing"
+	"time"
+
+	"ecs-plugin-dev/internal/strategy"
+)
+
+func TestETADecreasesAcrossCanaryStages(t *testing.T) {
+	planner, ok := strategy.NewCanaryStrategy(nil).(strategy.Planner)
+	if !ok {
+		t.Fatal("expected the canary strategy to plan its stages")
+	}
+	recorder := strategy.NewDeploymentRecorder()
+	eta := &etaTracker{
+		plan:     planner.PlanStages(map[string]string{"canary_stages": "20,50,100", "stage_timeout": "10m"}),
+		recorder: recorder,
+	}
+
+	start := time.Now()
+	if got := eta.remaining(start); got != 30*time.Minute {
+		t.Fatalf("expected the full 30m plan before execution, got %v", got)
+	}
+	eta.started(start)
+
+	// Each step is a later point in the deployment, and the estimate must fall
+	last := eta.remaining(start)
+	check := func(step string, now time.Time, want time.Duration) {
+		t.Helper()
+		got := eta.remaining(now)
+		if got != want {
+			t.Errorf("%s: expected %v remaining, got %v", step, want, got)
+		}
+		if got > last {
+			t.Errorf("%s: estimate rose from %v to %v", step, last, got)
+		}
+		last = got
+	}
+
+	check("into the first stage", start.Add(4*time.Minute), 26*time.Minute)
+	for i, stage := range []string{"20%", "50%"} {
+		recorder.RecordStage(stage, nil)
+		completed := recorder.Stages()[i].Timestamp
+		check(stage+" complete", completed, time.Duration(2-i)*10*time.Minute)
+		check("into the next stage", completed.Add(3*time.Minute), time.Duration(2-i)*10*time.Minute-3*time.Minute)
+	}
+	check("overrunning the last stage", time.Now().Add(time.Hour), 0)
+	recorder.RecordStage("100%", nil)
+	check("all stages complete", time.Now(), 0)
+}
+
+func TestEstimateRemainingUnknownDeployment(t *testing.T) {
+	r := newTestRouter(t, nil)
+	if _, ok := r.EstimateRemaining("eta-unknown"); ok {
+		t.Error("expected no estimate for an unknown deployment")
+	}
+}
@@ -0,0 +1,220 @@
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"ecs-plugin-dev/internal/audit"
+)
+
+// TransitionEvent reports a deployment moving from one status to another.
+// From is empty for a deployment's first status.
+type TransitionEvent struct {
+	DeploymentID string    `json:"deployment_id"`
+	ClusterARN   string    `json:"cluster_arn"`
+	ServiceName  string    `json:"service_name"`
+	Strategy     string    `json:"strategy"`
+	From         string    `json:"from"`
+	To           string    `json:"to"`
+	Message      string    `json:"message"`
+	Progress     int32     `json:"progress"`
+	Timestamp    time.Time `json:"timestamp"`
+}
+
+// Terminal reports whether the transition finished the deployment
+func (e TransitionEvent) Terminal() bool {
+	return IsTerminalStatus(e.To)
+}
+
+// EventSubscriber handles deployment status transitions. Each subscriber
+// receives events in order on its own goroutine, so a slow subscriber never
+// holds up a deployment; errors are logged and the event is not retried.
+type EventSubscriber interface {
+	Name() string
+	HandleEvent(ctx context.Context, event TransitionEvent) error
+}
+
+// eventBus fans transition events out to subscribers
+type eventBus struct {
+	mu          sync.Mutex
+	subscribers map[*eventSubscription]struct{}
+	buffer      int
+}
+
+// eventSubscription is one subscriber's queue of undelivered events
+type eventSubscription struct {
+	subscriber EventSubscriber
+	events     chan TransitionEvent
+}
+
+func newEventBus(buffer int) *eventBus {
+	if buffer <= 0 {
+		buffer = 1
+	}
+	return &eventBus{subscribers: make(map[*eventSubscription]struct{}), buffer: buffer}
+}
+
+// subscribe starts delivering events to subscriber and returns a function
+// that stops it; events already queued are still delivered
+func (b *eventBus) subscribe(subscriber EventSubscriber) func() {
+	sub := &eventSubscription{subscriber: subscriber, events: make(chan TransitionEvent, b.buffer)}
+	b.mu.Lock()
+	b.subscribers[sub] = struct{}{}
+	b.mu.Unlock()
+
+	go sub.deliver()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			b.mu.Lock()
+			delete(b.subscribers, sub)
+			close(sub.events)
+			b.mu.Unlock()
+		})
+	}
+}
+
+// publish queues event for every subscriber, dropping it for those that
+// have fallen a full buffer behind
+func (b *eventBus) publish(event TransitionEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for sub := range b.subscribers {
+		select {
+		case sub.events <- event:
+		default:
+			log.Printf("[EVENTS] Subscriber %s is %d events behind, dropping %s %s -> %s",
+				sub.subscriber.Name(), b.buffer, event.DeploymentID, event.From, event.To)
+		}
+	}
+}
+
+func (s *eventSubscription) deliver() {
+	for event := range s.events {
+		if err := s.subscriber.HandleEvent(context.Background(), event); err != nil {
+			log.Printf("[EVENTS] Subscriber %s failed to handle %s %s -> %s: %v",
+				s.subscriber.Name(), event.DeploymentID, event.From, event.To, err)
+		}
+	}
+}
+
+// Subscribe registers subscriber for every deployment's status transitions
+// and returns a function that unregisters it
+func (r *Router) Subscribe(subscriber EventSubscriber) func() {
+	return r.events.subscribe(subscriber)
+}
+
+// publishTransition emits an event when status changes a deployment's
+// status; progress and message updates within a status are not transitions
+func (r *Router) publishTransition(deploymentID string, previous, status *DeploymentStatus) {
+	from := ""
+	if previous != nil {
+		if previous.Status == status.Status {
+			return
+		}
+		from = previous.Status
+	}
+	r.events.publish(TransitionEvent{
+		DeploymentID: deploymentID,
+		ClusterARN:   status.ClusterARN,
+		ServiceName:  status.ServiceName,
+		Strategy:     status.Strategy,
+		From:         from,
+		To:           status.Status,
+		Message:      status.Message,
+		Progress:     status.Progress,
+		Timestamp:    time.Now(),
+	})
+}
+
+// WebhookSubscriber posts each transition as JSON to a URL
+type WebhookSubscriber struct {
+	url    string
+	client *http.Client
+}
+
+// webhookEvent is the body WebhookSubscriber posts. Text makes it usable
+// as-is by Slack incoming webhooks.
+type webhookEvent struct {
+	TransitionEvent
+	Text string `json:"text"`
+}
+
+// NewWebhookSubscriber creates a subscriber posting to url, giving up on a
+// delivery after timeout
+func NewWebhookSubscriber(url string, timeout time.Duration) *WebhookSubscriber {
+	return &WebhookSubscriber{url: url, client: &http.Client{Timeout: timeout}}
+}
+
+func (w *WebhookSubscriber) Name() string {
+	return "webhook:" + w.url
+}
+
+// HandleEvent posts event and fails on any non-2xx response
+func (w *WebhookSubscriber) HandleEvent(ctx context.Context, event TransitionEvent) error {
+	text := fmt.Sprintf("Deployment %s of %s is %s", event.DeploymentID, event.ServiceName, event.To)
+	if event.Message != "" {
+		text += ": " + event.Message
+	}
+	body, err := json.Marshal(webhookEvent{TransitionEvent: event, Text: text})
+	if err != nil {
+		return fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("invalid event webhook URL: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("event webhook failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("event webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// AuditSubscriber records each transition in the audit log
+type AuditSubscriber struct {
+	logger *audit.AuditLogger
+}
+
+// NewAuditSubscriber creates a subscriber writing to logger
+func NewAuditSubscriber(logger *audit.AuditLogger) *AuditSubscriber {
+	return &AuditSubscriber{logger: logger}
+}
+
+func (a *AuditSubscriber) Name() string {
+	return "audit"
+}
+
+// HandleEvent writes event as a deployment.transition audit event
+func (a *AuditSubscriber) HandleEvent(ctx context.Context, event TransitionEvent) error {
+	return a.logger.Log(audit.AuditEvent{
+		Timestamp:    event.Timestamp,
+		EventType:    audit.EventStatusTransition,
+		DeploymentID: event.DeploymentID,
+		ClusterARN:   event.ClusterARN,
+		ServiceName:  event.ServiceName,
+		Strategy:     event.Strategy,
+		Status:       event.To,
+		Metadata: map[string]interface{}{
+			"from":     event.From,
+			"message":  event.Message,
+			"progress": event.Progress,
+		},
+	})
+}
@@ -0,0 +1,96 @@
+package plugin
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// HealthListener is told whenever the router's ability to serve
+// deployments changes; reason explains a NOT_SERVING state
+type HealthListener func(serving bool, reason string)
+
+// SetHealthListener registers fn for health changes and immediately reports
+// the current state to it
+func (r *Router) SetHealthListener(fn HealthListener) {
+	r.healthMu.Lock()
+	r.healthListener = fn
+	serving, reason := r.unhealthyReason == "", r.unhealthyReason
+	r.healthMu.Unlock()
+
+	if fn != nil {
+		fn(serving, reason)
+	}
+}
+
+// Healthy reports whether the router can serve deployments, and why not
+func (r *Router) Healthy() (bool, string) {
+	r.healthMu.Lock()
+	defer r.healthMu.Unlock()
+	return r.unhealthyReason == "", r.unhealthyReason
+}
+
+// MarkUnhealthy reports the router as unable to serve deployments
+func (r *Router) MarkUnhealthy(reason string) {
+	if reason == "" {
+		reason = "unhealthy"
+	}
+	r.setHealth(reason)
+}
+
+// MarkHealthy reports the router as able to serve deployments again
+func (r *Router) MarkHealthy() {
+	r.setHealth("")
+}
+
+// setHealth records the unhealthy reason ("" when healthy) and notifies the
+// listener when the serving state or reason changed
+func (r *Router) setHealth(reason string) {
+	r.healthMu.Lock()
+	if reason == r.unhealthyReason {
+		r.healthMu.Unlock()
+		return
+	}
+	r.unhealthyReason = reason
+	listener := r.healthListener
+	r.healthMu.Unlock()
+
+	if reason == "" {
+		log.Println("[ROUTER] Health: SERVING")
+	} else {
+		log.Printf("[ROUTER] Health: NOT_SERVING (%s)", reason)
+	}
+	if listener != nil {
+		listener(reason == "", reason)
+	}
+}
+
+// healthProbeLoop checks AWS connectivity every interval, marking the router
+// unhealthy while AWS cannot be reached
+func (r *Router) healthProbeLoop(interval, timeout time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		r.probeHealth(timeout)
+		<-ticker.C
+	}
+}
+
+func (r *Router) probeHealth(timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	if err := r.executor.CheckAWS(ctx); err != nil {
+		r.MarkUnhealthy("cannot reach AWS: " + err.Error())
+		return
+	}
+	r.MarkHealthy()
+}
+
+// ValidatePermissions checks the credentials deployments run with are
+// allowed every AWS action they use, returning an error naming any denied
+func (r *Router) ValidatePermissions(ctx context.Context) error {
+	_, err := r.executor.ValidatePermissions(ctx)
+	return err
+}
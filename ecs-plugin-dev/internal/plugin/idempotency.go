@@ -0,0 +1,64 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+
+	"ecs-plugin-dev/internal/util"
+)
+
+// claimDeploymentID treats a deployment ID as an idempotency key. It returns
+// nil when the request may go ahead, holding the ID in r.startingIDs until
+// the caller deletes it. Otherwise it returns the result to send back: the
+// deployment already using the ID, or an error when that deployment targets
+// another service. A finished deployment is only replaced when req.Force is
+// set, and a scheduled one only by itself when its time comes.
+func (r *Router) claimDeploymentID(ctx context.Context, req *DeploymentRequest) (*DeploymentResult, error) {
+	if _, starting := r.startingIDs.LoadOrStore(req.DeploymentID, struct{}{}); starting {
+		util.Logf(ctx, "[ROUTER] Deployment %s is already being started, returning it", req.DeploymentID)
+		return &DeploymentResult{
+			Success:      true,
+			Message:      "deployment is already being started",
+			DeploymentID: req.DeploymentID,
+		}, nil
+	}
+
+	status, exists := r.loadStatus(req.DeploymentID)
+	if !exists {
+		return nil, nil
+	}
+
+	if req.firing && status.Status == "SCHEDULED" {
+		return nil, nil
+	}
+
+	if status.ClusterARN != req.ClusterARN || status.ServiceName != req.ServiceName {
+		r.startingIDs.Delete(req.DeploymentID)
+		err := fmt.Errorf("invalid deployment ID %s: already used for service %s/%s",
+			req.DeploymentID, status.ClusterARN, status.ServiceName)
+		return &DeploymentResult{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	if req.Force && IsTerminalStatus(status.Status) {
+		util.Logf(ctx, "[ROUTER] Forcing new deployment %s over previous %s run", req.DeploymentID, status.Status)
+		r.manifests.Delete(req.DeploymentID)
+		return nil, nil
+	}
+
+	r.startingIDs.Delete(req.DeploymentID)
+	util.Logf(ctx, "[ROUTER] Deployment %s already exists with status %s, returning it", req.DeploymentID, status.Status)
+	message := fmt.Sprintf("deployment already exists with status %s", status.Status)
+	if IsTerminalStatus(status.Status) {
+		message += "; set force to run it again"
+	}
+	return &DeploymentResult{
+		Success:         true,
+		Message:         message,
+		DeploymentID:    req.DeploymentID,
+		PendingApproval: status.Status == "PENDING_APPROVAL",
+		ExistingStatus:  status.Status,
+	}, nil
+}
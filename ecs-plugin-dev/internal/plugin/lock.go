@@ -0,0 +1,117 @@
+package plugin
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"ecs-plugin-dev/internal/audit"
+	"ecs-plugin-dev/internal/metrics"
+)
+
+// touchServiceLock records that deploymentID still holds its service. A
+// deployment taking a service is touched under r.queueMu, so an expiry check
+// never finds a holder without a heartbeat.
+func (r *Router) touchServiceLock(deploymentID string) {
+	r.lockHeartbeats.Store(deploymentID, time.Now())
+}
+
+// heartbeatServiceLock refreshes deploymentID's hold on its service until
+// the returned function is called. The deployment goroutine calls it on its
+// way out, however it exits, so a hold whose heartbeat stops belongs to a
+// deployment that is gone.
+func (r *Router) heartbeatServiceLock(deploymentID string) func() {
+	if r.serviceLockTTL <= 0 {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(r.serviceLockTTL / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				// Only a hold not yet released is refreshed
+				r.queueMu.Lock()
+				if _, held := r.lockHeartbeats.Load(deploymentID); held {
+					r.touchServiceLock(deploymentID)
+				}
+				r.queueMu.Unlock()
+			}
+		}
+	}()
+	return func() { close(done) }
+}
+
+// expireServiceLock releases serviceKey when the deployment holding it has
+// not heartbeated for serviceLockTTL, marking that deployment FAILED if it
+// never finished, and starts the next deployment queued for the service.
+// It reports whether the lock expired.
+func (r *Router) expireServiceLock(serviceKey string) bool {
+	if r.serviceLockTTL <= 0 {
+		return false
+	}
+
+	r.queueMu.Lock()
+	val, ok := r.serviceQueue.Load(serviceKey)
+	if !ok {
+		r.queueMu.Unlock()
+		return false
+	}
+	deploymentID := val.(string)
+	beat, ok := r.lockHeartbeats.Load(deploymentID)
+	if !ok || time.Since(beat.(time.Time)) < r.serviceLockTTL {
+		r.queueMu.Unlock()
+		return false
+	}
+	silent := time.Since(beat.(time.Time)).Round(time.Second)
+	r.serviceQueue.Delete(serviceKey)
+	r.lockHeartbeats.Delete(deploymentID)
+	next := r.handOffLocked(serviceKey)
+	r.queueMu.Unlock()
+
+	log.Printf("[ROUTER] Service %s lock held by deployment %s expired after %v without a heartbeat", serviceKey, deploymentID, silent)
+	metrics.RecordError("router", "service_lock_expired")
+	if val, ok := r.statuses.Load(deploymentID); ok {
+		status := *val.(*DeploymentStatus)
+		if !IsTerminalStatus(status.Status) {
+			status.Status = "FAILED"
+			status.Message = fmt.Sprintf("service lock expired (last message: %s)", status.Message)
+			status.EndTime = time.Now()
+			r.setStatus(deploymentID, &status)
+		}
+	}
+	// Cluster ARNs contain slashes, service names do not
+	split := strings.LastIndex(serviceKey, "/")
+	r.audit(audit.AuditEvent{
+		EventType:    audit.EventServiceReleased,
+		DeploymentID: deploymentID,
+		ClusterARN:   serviceKey[:split],
+		ServiceName:  serviceKey[split+1:],
+		Status:       "expired",
+		Metadata: map[string]interface{}{
+			"reason": fmt.Sprintf("no heartbeat for %v", silent),
+		},
+	})
+
+	r.runQueued(serviceKey, next)
+	return true
+}
+
+// expireServiceLocksLoop expires stale service locks every half TTL, so
+// deployments queued behind one start without waiting for another deploy
+func (r *Router) expireServiceLocksLoop() {
+	ticker := time.NewTicker(r.serviceLockTTL / 2)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		r.serviceQueue.Range(func(key, _ interface{}) bool {
+			r.expireServiceLock(key.(string))
+			return true
+		})
+	}
+}
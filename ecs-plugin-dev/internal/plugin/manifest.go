@@ -0,0 +1,125 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	"ecs-plugin-dev/internal/executor"
+	"ecs-plugin-dev/internal/strategy"
+)
+
+// DeploymentManifest records exactly what a deployment did, for audit and reproducibility
+type DeploymentManifest struct {
+	DeploymentID      string                        `json:"deployment_id"`
+	ClusterARN        string                        `json:"cluster_arn"`
+	ServiceName       string                        `json:"service_name"`
+	Strategy          string                        `json:"strategy"`
+	TaskDefinition    string                        `json:"task_definition"`
+	TaskDefinitionARN string                        `json:"task_definition_arn,omitempty"`
+	Config            map[string]string             `json:"config,omitempty"`
+	DependsOn         []string                      `json:"depends_on,omitempty"`
+	Snapshot          *executor.ServiceSnapshot     `json:"snapshot,omitempty"`
+	Stages            []strategy.StageRecord        `json:"stages"`
+	TrafficShifts     []strategy.TrafficShiftRecord `json:"traffic_shifts"`
+	Status            string                        `json:"status"`
+	Message           string                        `json:"message"`
+	StartTime         time.Time                     `json:"start_time"`
+	EndTime           time.Time                     `json:"end_time"`
+	DurationSeconds   float64                       `json:"duration_seconds"`
+
+	// RetriedFrom is the deployment this one retried, if any
+	RetriedFrom string `json:"retried_from,omitempty"`
+}
+
+// recordManifest builds the manifest for a finished deployment, keeps it for
+// GetManifest and writes it to the manifest directory when one is configured
+func (r *Router) recordManifest(req *DeploymentRequest, recorder *strategy.DeploymentRecorder) {
+	val, ok := r.statuses.Load(req.DeploymentID)
+	if !ok {
+		return
+	}
+	status := val.(*DeploymentStatus)
+
+	manifest := &DeploymentManifest{
+		DeploymentID:      req.DeploymentID,
+		ClusterARN:        req.ClusterARN,
+		ServiceName:       req.ServiceName,
+		Strategy:          req.Strategy,
+		TaskDefinition:    req.TaskDefinition,
+		TaskDefinitionARN: recorder.TaskDefinitionARN(),
+		Config:            req.Config,
+		DependsOn:         req.DependsOn,
+		Snapshot:          recorder.Snapshot(),
+		Stages:            recorder.Stages(),
+		TrafficShifts:     recorder.TrafficShifts(),
+		Status:            status.Status,
+		Message:           status.Message,
+		StartTime:         status.StartTime,
+		EndTime:           status.EndTime,
+		DurationSeconds:   status.EndTime.Sub(status.StartTime).Seconds(),
+		RetriedFrom:       req.RetriedFrom,
+	}
+	r.manifests.Store(req.DeploymentID, manifest)
+
+	if r.manifestDir == "" {
+		return
+	}
+	if err := writeManifestFile(r.manifestDir, manifest); err != nil {
+		log.Printf("[ROUTER] Failed to write manifest for %s: %v", req.DeploymentID, err)
+		return
+	}
+	log.Printf("[ROUTER] Manifest for deployment %s written to %s", req.DeploymentID, r.manifestDir)
+}
+
+// GetManifest returns the manifest of a finished deployment, falling back to
+// the manifest directory for deployments from before a restart
+func (r *Router) GetManifest(deploymentID string) (*DeploymentManifest, error) {
+	if val, ok := r.manifests.Load(deploymentID); ok {
+		return val.(*DeploymentManifest), nil
+	}
+
+	if val, ok := r.statuses.Load(deploymentID); ok {
+		status := val.(*DeploymentStatus)
+		if !IsTerminalStatus(status.Status) {
+			return nil, fmt.Errorf("deployment %s has not finished (status: %s)", deploymentID, status.Status)
+		}
+	}
+
+	if r.manifestDir != "" {
+		data, err := os.ReadFile(manifestPath(r.manifestDir, deploymentID))
+		if err == nil {
+			var manifest DeploymentManifest
+			if err := json.Unmarshal(data, &manifest); err != nil {
+				return nil, fmt.Errorf("failed to parse manifest: %w", err)
+			}
+			return &manifest, nil
+		}
+		if !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to read manifest: %w", err)
+		}
+	}
+
+	return nil, fmt.Errorf("manifest not found for deployment %s", deploymentID)
+}
+
+func writeManifestFile(dir string, manifest *DeploymentManifest) error {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("failed to create manifest directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+
+	return os.WriteFile(manifestPath(dir, manifest.DeploymentID), data, 0644)
+}
+
+func manifestPath(dir, deploymentID string) string {
+	return filepath.Join(dir, url.PathEscape(deploymentID)+".manifest.json")
+}
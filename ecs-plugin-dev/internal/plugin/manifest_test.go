@@ -0,0 +1,65 @@
+package plugin
+
+import (
+	"os"
+	"testing"
+
+	"ecs-plugin-dev/internal/config"
+)
+
+func TestManifestForCompletedCanary(t *testing.T) {
+	dir := t.TempDir()
+	r := newTestRouter(t, func(cfg *config.Config) { cfg.Deployment.ManifestDir = dir })
+
+	req := testRequest("manifest-canary", "manifest-canary")
+	req.Strategy = "canary"
+	req.Config = map[string]string{"canary_stages": "100", "stage_timeout": "1ms"}
+	route(t, r, req)
+	if status := waitForTerminal(t, r, req.DeploymentID); status.Status != "SUCCESS" {
+		t.Fatalf("canary ended %s: %s", status.Status, status.Message)
+	}
+
+	manifest, err := r.GetManifest(req.DeploymentID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if manifest.Status != "SUCCESS" || manifest.Strategy != "canary" || manifest.ServiceName != req.ServiceName {
+		t.Errorf("unexpected manifest header: %+v", manifest)
+	}
+	if manifest.TaskDefinition != testTaskDefinition || manifest.TaskDefinitionARN == "" {
+		t.Errorf("expected the submitted and registered task definitions, got %q and %q", manifest.TaskDefinition, manifest.TaskDefinitionARN)
+	}
+	if manifest.Config["canary_stages"] != "100" {
+		t.Errorf("expected the deployment config, got %v", manifest.Config)
+	}
+	if manifest.Snapshot == nil {
+		t.Error("expected the pre-deployment snapshot")
+	}
+	if manifest.DurationSeconds <= 0 || manifest.EndTime.Before(manifest.StartTime) {
+		t.Errorf("unexpected timing: %v from %v to %v", manifest.DurationSeconds, manifest.StartTime, manifest.EndTime)
+	}
+
+	if len(manifest.Stages) != 1 || manifest.Stages[0].Name != "100%" || manifest.Stages[0].Status != "success" {
+		t.Errorf("expected the single successful stage, got %+v", manifest.Stages)
+	}
+	shifts := manifest.TrafficShifts
+	if len(shifts) == 0 {
+		t.Fatal("expected the final traffic shift")
+	}
+	if final := shifts[len(shifts)-1]; final.CanaryWeight != 100 || final.PrimaryWeight != 0 || final.Status != "success" {
+		t.Errorf("expected a successful 100/0 final shift, got %+v", final)
+	}
+
+	// The copy written to the manifest directory outlives the router
+	if _, err := os.Stat(manifestPath(dir, req.DeploymentID)); err != nil {
+		t.Fatalf("expected a manifest file: %v", err)
+	}
+	restarted := newTestRouter(t, func(cfg *config.Config) { cfg.Deployment.ManifestDir = dir })
+	loaded, err := restarted.GetManifest(req.DeploymentID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.TaskDefinitionARN != manifest.TaskDefinitionARN || len(loaded.TrafficShifts) != len(shifts) {
+		t.Errorf("expected the file to match the in-memory manifest, got %+v", loaded)
+	}
+}
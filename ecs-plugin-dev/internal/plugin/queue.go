@@ -0,0 +1,145 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"ecs-plugin-dev/internal/util"
+)
+
+// Policies for a deploy to a service that already has one in progress
+const (
+	ConcurrentPolicyReject = "reject"
+	ConcurrentPolicyQueue  = "queue"
+)
+
+// queuedDeployment is a deployment waiting for its service to be free
+type queuedDeployment struct {
+	ctx         context.Context
+	req         *DeploymentRequest
+	contentHash string
+}
+
+// enqueueLocked appends a deployment to its service's queue, marks it
+// QUEUED and returns its 1-based position. r.queueMu must be held.
+func (r *Router) enqueueLocked(ctx context.Context, req *DeploymentRequest, serviceKey, contentHash string) (int, error) {
+	queue := r.queues[serviceKey]
+	if r.maxQueueDepth > 0 && len(queue) >= r.maxQueueDepth {
+		return 0, fmt.Errorf("deployment queue is full for service %s (%d waiting)", serviceKey, len(queue))
+	}
+
+	r.queues[serviceKey] = append(queue, &queuedDeployment{ctx: ctx, req: req, contentHash: contentHash})
+	position := len(queue) + 1
+	r.rememberRequest(req)
+	r.setStatus(req.DeploymentID, &DeploymentStatus{
+		Status:      "QUEUED",
+		Message:     fmt.Sprintf("queued at position %d", position),
+		StartTime:   time.Now(),
+		ClusterARN:  req.ClusterARN,
+		ServiceName: req.ServiceName,
+		Strategy:    req.Strategy,
+	})
+	return position, nil
+}
+
+// releaseService frees a service held by deploymentID and starts the next
+// deployment queued for it, if any. A service force-released and taken by
+// another deployment since is left alone.
+func (r *Router) releaseService(serviceKey, deploymentID string) {
+	r.queueMu.Lock()
+	if !r.serviceQueue.CompareAndDelete(serviceKey, deploymentID) {
+		r.queueMu.Unlock()
+		return
+	}
+	r.lockHeartbeats.Delete(deploymentID)
+	next := r.handOffLocked(serviceKey)
+	r.queueMu.Unlock()
+
+	r.runQueued(serviceKey, next)
+}
+
+// handOffLocked pops the next deployment queued for a free service and
+// gives it the service. r.queueMu must be held.
+func (r *Router) handOffLocked(serviceKey string) *queuedDeployment {
+	queue := r.queues[serviceKey]
+	if len(queue) == 0 {
+		return nil
+	}
+
+	next := queue[0]
+	r.removeQueuedLocked(serviceKey, 0)
+	r.serviceQueue.Store(serviceKey, next.req.DeploymentID)
+	r.touchServiceLock(next.req.DeploymentID)
+	return next
+}
+
+// removeQueuedLocked drops the i-th queued deployment of a service and
+// renumbers the ones behind it. r.queueMu must be held.
+func (r *Router) removeQueuedLocked(serviceKey string, i int) {
+	queue := append(r.queues[serviceKey][:i:i], r.queues[serviceKey][i+1:]...)
+	if len(queue) == 0 {
+		delete(r.queues, serviceKey)
+		return
+	}
+	r.queues[serviceKey] = queue
+
+	for position, queued := range queue[i:] {
+		if val, ok := r.statuses.Load(queued.req.DeploymentID); ok {
+			status := *val.(*DeploymentStatus)
+			status.Message = fmt.Sprintf("queued at position %d", i+position+1)
+			r.setStatus(queued.req.DeploymentID, &status)
+		}
+	}
+}
+
+// runQueued starts a deployment taken off the queue. If it cannot start it
+// is marked FAILED, and the service passes to the next one in line.
+func (r *Router) runQueued(serviceKey string, next *queuedDeployment) {
+	if next == nil {
+		return
+	}
+
+	util.Logf(next.ctx, "[ROUTER] Starting queued deployment %s for service %s", next.req.DeploymentID, serviceKey)
+	if _, err := r.startDeployment(next.ctx, next.req, serviceKey, next.contentHash); err != nil {
+		util.Logf(next.ctx, "[ROUTER] Queued deployment %s could not start: %v", next.req.DeploymentID, err)
+		now := time.Now()
+		r.setStatus(next.req.DeploymentID, &DeploymentStatus{
+			Status:      "FAILED",
+			Message:     fmt.Sprintf("queued deployment could not start: %v", err),
+			Progress:    100,
+			StartTime:   now,
+			EndTime:     now,
+			ClusterARN:  next.req.ClusterARN,
+			ServiceName: next.req.ServiceName,
+			Strategy:    next.req.Strategy,
+		})
+	}
+}
+
+// cancelQueued removes a queued deployment and marks it CANCELLED. It
+// reports false if the deployment is no longer queued.
+func (r *Router) cancelQueued(deploymentID string, status *DeploymentStatus) bool {
+	serviceKey := fmt.Sprintf("%s/%s", status.ClusterARN, status.ServiceName)
+
+	r.queueMu.Lock()
+	defer r.queueMu.Unlock()
+
+	for i, queued := range r.queues[serviceKey] {
+		if queued.req.DeploymentID != deploymentID {
+			continue
+		}
+		r.removeQueuedLocked(serviceKey, i)
+
+		cancelled := *status
+		cancelled.Status = "CANCELLED"
+		cancelled.Message = "deployment cancelled while queued"
+		cancelled.Progress = 100
+		cancelled.EndTime = time.Now()
+		r.setStatus(deploymentID, &cancelled)
+		log.Printf("[ROUTER] Queued deployment %s cancelled", deploymentID)
+		return true
+	}
+	return false
+}
@@ -2,6 +2,7 @@ package plugin
 
 import (
 	"fmt"
+	"sort"
 	"sync"
 
 	"ecs-plugin-dev/internal/strategy"
@@ -22,6 +23,10 @@ func NewRegistry() *Registry {
 
 // Register adds a strategy to the registry
 func (r *Registry) Register(name string, s strategy.Strategy) error {
+	if name == "" || s == nil {
+		return fmt.Errorf("strategy name and implementation are required")
+	}
+
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
@@ -42,7 +47,7 @@ func (r *Registry) Get(name string) (strategy.Strategy, bool) {
 	return s, ok
 }
 
-// List returns all registered strategy names
+// List returns all registered strategy names, sorted
 func (r *Registry) List() []string {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -51,6 +56,7 @@ func (r *Registry) List() []string {
 	for name := range r.strategies {
 		names = append(names, name)
 	}
+	sort.Strings(names)
 	return names
 }
 
@@ -0,0 +1,158 @@
+package plugin
+
+import (
+	"container/list"
+	"log"
+	"sort"
+	"sync"
+	"time"
+)
+
+// pruneInterval is how often finished deployments are checked against the
+// status retention limits
+const pruneInterval = time.Minute
+
+// recentStatuses is a fixed-size LRU of statuses pruned from the router, so
+// recently finished deployments can still be queried
+type recentStatuses struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // Most recently used at the front
+	entries  map[string]*list.Element
+}
+
+type recentStatus struct {
+	deploymentID string
+	status       *DeploymentStatus
+}
+
+func newRecentStatuses(capacity int) *recentStatuses {
+	return &recentStatuses{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+	}
+}
+
+// Add caches a status, evicting the least recently used one when full
+func (c *recentStatuses) Add(deploymentID string, status *DeploymentStatus) {
+	if c.capacity <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[deploymentID]; ok {
+		elem.Value.(*recentStatus).status = status
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[deploymentID] = c.order.PushFront(&recentStatus{deploymentID: deploymentID, status: status})
+	if c.order.Len() > c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*recentStatus).deploymentID)
+	}
+}
+
+// Get returns a cached status and marks it recently used
+func (c *recentStatuses) Get(deploymentID string) (*DeploymentStatus, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[deploymentID]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*recentStatus).status, true
+}
+
+// loadStatus returns a deployment's status, falling back to the cache of
+// recently pruned statuses
+func (r *Router) loadStatus(deploymentID string) (*DeploymentStatus, bool) {
+	if val, ok := r.statuses.Load(deploymentID); ok {
+		return val.(*DeploymentStatus), true
+	}
+	return r.recentStatuses.Get(deploymentID)
+}
+
+// pruneStatusesLoop prunes finished deployments every pruneInterval
+func (r *Router) pruneStatusesLoop() {
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if pruned := r.pruneStatuses(time.Now()); pruned > 0 {
+			log.Printf("[ROUTER] Pruned %d finished deployments", pruned)
+		}
+	}
+}
+
+// pruneStatuses drops finished deployments that ended longer than
+// statusRetention ago, and the oldest ones beyond maxStatuses. Deployments
+// whose goroutine is still wrapping up or that are being streamed are kept.
+// Pruned statuses move to the recent-status cache.
+func (r *Router) pruneStatuses(now time.Time) int {
+	type candidate struct {
+		deploymentID string
+		status       *DeploymentStatus
+	}
+
+	var candidates []candidate
+	r.statuses.Range(func(key, value interface{}) bool {
+		deploymentID := key.(string)
+		status := value.(*DeploymentStatus)
+		if !IsTerminalStatus(status.Status) {
+			return true
+		}
+		if _, active := r.cancelFuncs.Load(deploymentID); active {
+			return true
+		}
+		if r.hasSubscribers(deploymentID) {
+			return true
+		}
+		candidates = append(candidates, candidate{deploymentID: deploymentID, status: status})
+		return true
+	})
+
+	sort.Slice(candidates, func(i, j int) bool {
+		return candidates[i].status.EndTime.Before(candidates[j].status.EndTime)
+	})
+
+	excess := 0
+	if r.maxStatuses > 0 {
+		excess = len(candidates) - r.maxStatuses
+	}
+
+	pruned := 0
+	for i, c := range candidates {
+		expired := r.statusRetention > 0 && now.Sub(c.status.EndTime) > r.statusRetention
+		if i >= excess && !expired {
+			continue
+		}
+
+		// Cache first so readers never see a gap, and only delete the status
+		// if it was not replaced in the meantime
+		r.recentStatuses.Add(c.deploymentID, c.status)
+		if !r.statuses.CompareAndDelete(c.deploymentID, c.status) {
+			continue
+		}
+		r.manifests.Delete(c.deploymentID)
+		r.requests.Delete(c.deploymentID)
+		if err := r.store.Delete(c.deploymentID); err != nil {
+			log.Printf("[ROUTER] Failed to delete persisted status for %s: %v", c.deploymentID, err)
+		}
+		pruned++
+	}
+	return pruned
+}
+
+// hasSubscribers reports whether a deployment's status is being streamed
+func (r *Router) hasSubscribers(deploymentID string) bool {
+	r.subMu.Lock()
+	defer r.subMu.Unlock()
+	return len(r.subscribers[deploymentID]) > 0
+}
@@ -0,0 +1,80 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"ecs-plugin-dev/internal/util"
+)
+
+// retrySuffix separates a retried deployment's ID from the attempt number
+const retrySuffix = "-retry-"
+
+// rememberRequest keeps the request a deployment was accepted with, so it
+// can be retried without the client resending it. It lives as long as the
+// deployment's status and, like a schedule, is not persisted.
+func (r *Router) rememberRequest(req *DeploymentRequest) {
+	spec := *req
+	spec.ScheduledAt = time.Time{}
+	spec.firing = false
+	r.requests.Store(req.DeploymentID, &spec)
+}
+
+// RetryDeployment routes a FAILED or CANCELLED deployment's original request
+// again as a new deployment, under newID or, when that is empty, the next
+// free <id>-retry-<n>. The new deployment records the one it retries.
+func (r *Router) RetryDeployment(ctx context.Context, deploymentID, newID, user string) (*DeploymentResult, error) {
+	status, ok := r.loadStatus(deploymentID)
+	if !ok {
+		return nil, fmt.Errorf("deployment not found: %s", deploymentID)
+	}
+	if status.Status != "FAILED" && status.Status != "CANCELLED" {
+		return nil, fmt.Errorf("deployment %s cannot be retried (status: %s): only FAILED or CANCELLED deployments can be", deploymentID, status.Status)
+	}
+	val, ok := r.requests.Load(deploymentID)
+	if !ok {
+		return nil, fmt.Errorf("deployment %s cannot be retried: its original request is no longer available", deploymentID)
+	}
+	if newID == deploymentID {
+		return nil, fmt.Errorf("invalid new deployment ID %q: must differ from the deployment being retried", newID)
+	}
+
+	original := val.(*DeploymentRequest)
+	retry := *original
+	retry.Config = make(map[string]string, len(original.Config))
+	for k, v := range original.Config {
+		retry.Config[k] = v
+	}
+	retry.DependsOn = append([]string(nil), original.DependsOn...)
+	retry.DeploymentID = newID
+	if retry.DeploymentID == "" {
+		retry.DeploymentID = r.nextRetryID(deploymentID)
+	}
+	retry.RetriedFrom = deploymentID
+	retry.User = user
+	retry.CorrelationID = ""
+	retry.Force = false
+
+	util.Logf(ctx, "[ROUTER] Retrying %s deployment %s as %s", status.Status, deploymentID, retry.DeploymentID)
+	return r.RouteDeployment(ctx, &retry)
+}
+
+// nextRetryID returns the first unused <base>-retry-<n>, where base drops
+// any retry suffix so retries of retries keep counting from the original
+func (r *Router) nextRetryID(deploymentID string) string {
+	base := deploymentID
+	if i := strings.LastIndex(base, retrySuffix); i > 0 {
+		if _, err := strconv.Atoi(base[i+len(retrySuffix):]); err == nil {
+			base = base[:i]
+		}
+	}
+	for n := 1; ; n++ {
+		candidate := fmt.Sprintf("%s%s%d", base, retrySuffix, n)
+		if _, exists := r.loadStatus(candidate); !exists {
+			return candidate
+		}
+	}
+}
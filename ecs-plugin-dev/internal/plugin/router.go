@@ -3,14 +3,28 @@ package plugin
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"log"
+	"runtime/debug"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
+	"ecs-plugin-dev/internal/audit"
+	"ecs-plugin-dev/internal/config"
 	"ecs-plugin-dev/internal/executor"
 	"ecs-plugin-dev/internal/metrics"
 	"ecs-plugin-dev/internal/strategy"
+	"ecs-plugin-dev/internal/tracing"
+	"ecs-plugin-dev/internal/util"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type DeploymentRequest struct {
@@ -20,34 +34,187 @@ type DeploymentRequest struct {
 	TaskDefinition string
 	Strategy       string
 	Config         map[string]string
+	DependsOn      []string // Deployment IDs that must succeed before this one runs
+	DryRun         bool     // Plan the deployment without calling AWS
+
+	// RequireApproval holds the deployment in PENDING_APPROVAL until approved
+	RequireApproval bool
+	// User is the authenticated principal requesting the deployment, if any
+	User string
+	// Force reruns a finished deployment under the same ID instead of
+	// returning it
+	Force bool
+	// CorrelationID ties the deployment's logs and audit events to the
+	// request that started it
+	CorrelationID string
+	// ScheduledAt holds the deployment in SCHEDULED until this time, when
+	// set; it must be in the future
+	ScheduledAt time.Time
+	// RetriedFrom is the deployment this one retries, if any
+	RetriedFrom string
+
+	// firing marks a scheduled deployment being started at its time, which
+	// may claim the ID its SCHEDULED status holds
+	firing bool
 }
 
 type DeploymentResult struct {
 	Success      bool
 	Message      string
 	DeploymentID string
+	PlannedSteps []string // AWS operations a dry run would perform, in order
+
+	// PendingApproval is set when the deployment waits for approval before running
+	PendingApproval bool
+	// QueuePosition is the deployment's 1-based place in its service's queue,
+	// or 0 when it started right away
+	QueuePosition int
+	// ExistingStatus is set when the deployment ID was already in use and
+	// that deployment was returned instead of starting a new one
+	ExistingStatus string
 }
 
 type DeploymentStatus struct {
-	Status    string
-	Message   string
-	Progress  int32
-	StartTime time.Time
-	EndTime   time.Time
+	Status      string    `json:"status"`
+	Message     string    `json:"message"`
+	Progress    int32     `json:"progress"`
+	StartTime   time.Time `json:"start_time"`
+	EndTime     time.Time `json:"end_time"`
+	ClusterARN  string    `json:"cluster_arn"`
+	ServiceName string    `json:"service_name"`
+	Strategy    string    `json:"strategy"`
+}
+
+// RollbackTarget identifies a service to roll back
+type RollbackTarget struct {
+	ClusterARN     string
+	ServiceName    string
+	TaskDefinition string // Target revision; empty rolls back to the previous one
+}
+
+// RollbackTargetResult reports the rollback outcome for a single service
+type RollbackTargetResult struct {
+	ClusterARN  string
+	ServiceName string
+	Success     bool
+	Message     string
+}
+
+// ServiceSummary describes the deployment state of a single service
+type ServiceSummary struct {
+	ClusterARN           string
+	ServiceName          string
+	LastDeploymentID     string
+	LastStatus           string
+	LastMessage          string
+	InFlightDeploymentID string
+	DriftMonitored       bool
+	TotalDeployments     int64
+	SuccessRate          float64
 }
 
+// StrategyInfo describes a registered deployment strategy
+type StrategyInfo struct {
+	Name          string
+	Description   string
+	ConfigKeys    []string
+	SupportsPause bool
+}
+
+// dependencyPollInterval is how often a waiting deployment re-checks its dependencies
+const dependencyPollInterval = 5 * time.Second
+
 type Router struct {
-	strategies      map[string]strategy.Strategy
+	strategies      *Registry
 	executor        *executor.Executor
 	statuses        sync.Map
+	store           StatusStore
+	manifests       sync.Map // Deployment ID -> *DeploymentManifest for finished deployments
+	etas            sync.Map // Deployment ID -> *etaTracker for running deployments
+	auditLogger     *audit.AuditLogger
+	manifestDir     string
 	serviceQueue    sync.Map // Tracks active deployments per service
 	hooks           *executor.HookRegistry
 	cancelFuncs     sync.Map // Tracks cancel functions for active deployments
+	pauseGates      sync.Map // Deployment ID -> *strategy.PauseGate for running pausable deployments
 	approvalManager *executor.ApprovalManager
+	driftMonitors   *executor.DriftMonitorManager
+	dedupWindow     time.Duration
+	recentRequests  sync.Map // Tracks content hash -> deployment ID for deduplication
+	startingIDs     sync.Map // Deployment IDs RouteDeployment is currently starting
+	batches         sync.Map // Batch ID -> *batchState
+	scheduled       sync.Map // Deployment ID -> *scheduledDeployment waiting for its time
+	requests        sync.Map // Deployment ID -> *DeploymentRequest it was accepted with, for retries
+	subMu           sync.Mutex
+	subscribers     map[string]map[chan *DeploymentStatus]struct{} // Status subscribers per deployment
+	events          *eventBus                                      // Transition subscribers across deployments
+
+	// approvalStrategies always require approval, whatever the request says
+	approvalStrategies map[string]bool
+
+	// Finished deployments are pruned after statusRetention or beyond
+	// maxStatuses; recentStatuses still answers for the latest of them
+	statusRetention time.Duration
+	maxStatuses     int
+	recentStatuses  *recentStatuses
+
+	// capacity limits concurrent deployments globally and per cluster
+	capacity *deploymentLimiter
+
+	// With queueConcurrent set, deploys to a busy service wait in queues (at
+	// most maxQueueDepth per service) instead of being rejected. queueMu
+	// guards queues and hand-offs of serviceQueue entries.
+	queueConcurrent bool
+	maxQueueDepth   int
+	queueMu         sync.Mutex
+	queues          map[string][]*queuedDeployment
+
+	// Running deployments heartbeat their serviceQueue entry; one silent for
+	// serviceLockTTL has expired. lockHeartbeats maps deployment ID -> time.Time.
+	serviceLockTTL time.Duration
+	lockHeartbeats sync.Map
+
+	// Successful deploys deregister their family's revisions beyond the
+	// newest keepRevisions when deregisterOldRevisions is set
+	deregisterOldRevisions bool
+	keepRevisions          int
+
+	// unhealthyReason is empty while the router can serve deployments
+	healthMu        sync.Mutex
+	unhealthyReason string
+	healthListener  HealthListener
+
+	// inflight counts running deployment goroutines so Drain can wait for
+	// them. drainMu guards draining and additions to inflight.
+	drainMu  sync.Mutex
+	draining bool
+	inflight sync.WaitGroup
 }
 
+// subscriberBuffer is the number of status updates buffered per subscriber
+const subscriberBuffer = 16
+
 func NewRouter() *Router {
-	exec := executor.NewExecutor()
+	return NewRouterWithConfig(config.DefaultConfig())
+}
+
+// NewRouterWithConfig creates a router using the given configuration
+func NewRouterWithConfig(cfg *config.Config) *Router {
+	return NewRouterWithStore(cfg, NewMemoryStatusStore())
+}
+
+// NewRouterWithStore creates a router that persists statuses to store and
+// rehydrates any statuses already saved there
+func NewRouterWithStore(cfg *config.Config, store StatusStore) *Router {
+	exec := executor.NewExecutorWithRetry(util.RetryConfig{
+		MaxAttempts: cfg.AWS.MaxRetries + 1,
+		BaseDelay:   cfg.AWS.RetryDelay,
+		MaxDelay:    cfg.AWS.MaxRetryDelay,
+		Jitter:      util.JitterMode(cfg.AWS.RetryJitter),
+
+		BreakerThreshold: cfg.AWS.CircuitBreakerThreshold,
+		BreakerCooldown:  cfg.AWS.CircuitBreakerCooldown,
+	})
 	hooks := executor.NewHookRegistry()
 
 	// Register default hooks
@@ -55,29 +222,174 @@ func NewRouter() *Router {
 		Name: "validation",
 		Fn:   executor.ValidationHook,
 	})
+	if len(cfg.Hooks.DependencyChecks) > 0 {
+		hooks.RegisterHook(executor.PreDeployHook, executor.Hook{
+			Name: "dependency-health",
+			Fn:   executor.DependencyHealthHook(cfg.Hooks.DependencyChecks, cfg.Hooks.DependencyTimeout),
+		})
+	}
+	// The ECS convergence check always runs; a configured health URL is
+	// probed once it passes
+	healthCheck := executor.HealthCheckHook(exec)
+	if cfg.Hooks.HealthCheckURL != "" {
+		ecsCheck := healthCheck
+		httpCheck := executor.HTTPHealthCheckHook(executor.HTTPHealthCheck{
+			URLTemplate:    cfg.Hooks.HealthCheckURL,
+			ExpectedStatus: cfg.Hooks.HealthCheckExpectedStatus,
+			Timeout:        cfg.Hooks.HealthCheckTimeout,
+			Attempts:       cfg.Hooks.HealthCheckRetries + 1,
+			RetryInterval:  cfg.Hooks.HealthCheckRetryInterval,
+		})
+		healthCheck = func(ctx context.Context, deploymentID, cluster, service string) error {
+			if err := ecsCheck(ctx, deploymentID, cluster, service); err != nil {
+				return err
+			}
+			return httpCheck(ctx, deploymentID, cluster, service)
+		}
+	}
 	hooks.RegisterHook(executor.PostDeployHook, executor.Hook{
 		Name: "health-check",
-		Fn:   executor.HealthCheckHook,
+		Fn:   healthCheck,
 	})
+	notify := executor.NotificationHook
+	if cfg.Hooks.NotificationURL != "" {
+		notify = executor.WebhookNotificationHook(cfg.Hooks.NotificationURL, cfg.Hooks.NotificationTimeout)
+	}
 	hooks.RegisterHook(executor.PostDeployHook, executor.Hook{
-		Name: "notification",
-		Fn:   executor.NotificationHook,
+		Name:       "notification",
+		Fn:         notify,
+		BestEffort: !cfg.Hooks.NotificationRequired,
 	})
+	for _, command := range cfg.Hooks.PreDeploy {
+		hooks.RegisterHook(executor.PreDeployHook, executor.Hook{
+			Name:    "exec:" + command,
+			Fn:      executor.ExecCommandHook(command),
+			Timeout: cfg.Hooks.CommandTimeout,
+		})
+	}
+	for _, command := range cfg.Hooks.PostDeploy {
+		hooks.RegisterHook(executor.PostDeployHook, executor.Hook{
+			Name:    "exec:" + command,
+			Fn:      executor.ExecCommandHook(command),
+			Timeout: cfg.Hooks.CommandTimeout,
+		})
+	}
+	hooks.MarkBestEffort(cfg.Hooks.BestEffort...)
 
-	return &Router{
-		strategies: map[string]strategy.Strategy{
-			"quicksync": strategy.NewQuickSyncStrategy(exec),
-			"canary":    strategy.NewCanaryStrategy(exec),
-			"bluegreen": strategy.NewBlueGreenStrategy(exec),
-			"rolling":   strategy.NewRollingStrategy(exec),
-		},
+	strategies := NewRegistry()
+	for name, s := range map[string]strategy.Strategy{
+		"quicksync":   strategy.NewQuickSyncStrategy(exec),
+		"canary":      strategy.NewCanaryStrategyWithAnalysis(exec, canaryAnalyzer(exec, cfg.Strategy.Canary.Analysis)),
+		"bluegreen":   strategy.NewBlueGreenStrategy(exec),
+		"rolling":     strategy.NewRollingStrategy(exec),
+		"progressive": strategy.NewProgressiveStrategy(exec),
+	} {
+		strategies.Register(name, s)
+	}
+
+	r := &Router{
+		strategies:      strategies,
 		executor:        exec,
 		hooks:           hooks,
 		approvalManager: executor.NewApprovalManager(),
+		driftMonitors:   executor.NewDriftMonitorManager(exec),
+		dedupWindow:     cfg.Deployment.DedupWindow,
+		store:           store,
+		manifestDir:     cfg.Deployment.ManifestDir,
+		auditLogger:     audit.GetGlobalAuditLogger(),
+		subscribers:     make(map[string]map[chan *DeploymentStatus]struct{}),
+		events:          newEventBus(cfg.Hooks.EventBuffer),
+
+		approvalStrategies: make(map[string]bool),
+
+		statusRetention: cfg.Deployment.StatusRetention,
+		maxStatuses:     cfg.Deployment.MaxStatuses,
+		recentStatuses:  newRecentStatuses(cfg.Deployment.RecentStatusCache),
+
+		capacity: newDeploymentLimiter(cfg.Deployment.MaxConcurrent, cfg.Deployment.MaxConcurrentPerCluster),
+
+		queueConcurrent: cfg.Deployment.ConcurrentPolicy == ConcurrentPolicyQueue,
+		maxQueueDepth:   cfg.Deployment.MaxQueueDepth,
+		queues:          make(map[string][]*queuedDeployment),
+
+		serviceLockTTL: cfg.Deployment.ServiceLockTTL,
+
+		deregisterOldRevisions: cfg.Deployment.DeregisterOldRevisions,
+		keepRevisions:          cfg.Deployment.KeepRevisions,
+	}
+	for _, name := range cfg.Deployment.ApprovalStrategies {
+		r.approvalStrategies[name] = true
+	}
+	if cfg.Hooks.AuditTransitions && r.auditLogger != nil {
+		r.Subscribe(NewAuditSubscriber(r.auditLogger))
+	}
+	for _, url := range cfg.Hooks.EventWebhookURLs {
+		r.Subscribe(NewWebhookSubscriber(url, cfg.Hooks.EventWebhookTimeout))
+	}
+	r.rehydrate()
+	if r.statusRetention > 0 || r.maxStatuses > 0 {
+		go r.pruneStatusesLoop()
+	}
+	if r.serviceLockTTL > 0 {
+		go r.expireServiceLocksLoop()
+	}
+	if cfg.Server.HealthProbeInterval > 0 {
+		go r.healthProbeLoop(cfg.Server.HealthProbeInterval, cfg.AWS.Timeout)
+	}
+
+	return r
+}
+
+// canaryAnalyzer maps the server's canary analysis settings to the analyzer
+// the canary strategy consults after each stage
+func canaryAnalyzer(exec *executor.Executor, cfg config.CanaryAnalysisConfig) *strategy.CanaryAnalyzer {
+	return strategy.NewCanaryAnalyzer(exec, strategy.AnalysisThresholds{
+		Enabled:              cfg.Enabled,
+		MaxErrorRateIncrease: cfg.MaxErrorRateIncrease,
+		MaxLatencyIncrease:   cfg.MaxLatencyIncrease,
+		MinRequests:          int64(cfg.MinRequests),
+		Confidence:           cfg.Confidence,
+	})
+}
+
+// rehydrate loads persisted statuses. Deployments that were still in flight
+// when the server stopped have no goroutine driving them any more, so they
+// are marked INTERRUPTED rather than left looking active.
+func (r *Router) rehydrate() {
+	statuses, err := r.store.List()
+	if err != nil {
+		log.Printf("[ROUTER] Failed to load persisted statuses: %v", err)
+		return
+	}
+
+	interrupted := 0
+	for deploymentID, status := range statuses {
+		if !IsTerminalStatus(status.Status) {
+			if _, active := r.cancelFuncs.Load(deploymentID); !active {
+				status.Status = "INTERRUPTED"
+				status.Message = fmt.Sprintf("deployment interrupted by server restart (last message: %s)", status.Message)
+				status.EndTime = time.Now()
+				if err := r.store.Save(deploymentID, status); err != nil {
+					log.Printf("[ROUTER] Failed to persist status for %s: %v", deploymentID, err)
+				}
+				interrupted++
+			}
+		}
+		r.statuses.Store(deploymentID, status)
+	}
+
+	if len(statuses) > 0 {
+		log.Printf("[ROUTER] Restored %d deployment statuses (%d interrupted)", len(statuses), interrupted)
 	}
 }
 
 func (r *Router) RouteDeployment(ctx context.Context, req *DeploymentRequest) (*DeploymentResult, error) {
+	if req.CorrelationID == "" {
+		req.CorrelationID = util.CorrelationIDFromContext(ctx)
+	} else if util.CorrelationIDFromContext(ctx) == "" {
+		ctx = util.WithCorrelationID(ctx, req.CorrelationID)
+	}
+
 	// Validate request first
 	if err := r.ValidateRequest(req); err != nil {
 		return &DeploymentResult{
@@ -86,77 +398,341 @@ func (r *Router) RouteDeployment(ctx context.Context, req *DeploymentRequest) (*
 		}, err
 	}
 
-	// Check for concurrent deployments to same service
+	if req.DryRun {
+		return r.dryRun(ctx, req)
+	}
+
+	if r.isDraining() {
+		return &DeploymentResult{
+			Success: false,
+			Message: "server is shutting down",
+		}, errShuttingDown
+	}
+
+	// Resending a deployment ID returns the deployment it already started
+	if existing, err := r.claimDeploymentID(ctx, req); existing != nil {
+		return existing, err
+	}
+	defer r.startingIDs.Delete(req.DeploymentID)
+
+	if !req.ScheduledAt.IsZero() {
+		return r.scheduleDeployment(ctx, req)
+	}
+
+	// Return the existing deployment if identical content was resent. A
+	// scheduled deployment was accepted when it was scheduled and always
+	// runs, and a retry means to repeat the content of the one it retries.
+	contentHash := requestHash(req)
+	if existingID, ok := r.findDuplicate(contentHash); ok && !req.firing && req.RetriedFrom == "" {
+		util.Logf(ctx, "[ROUTER] Deployment %s duplicates %s, returning existing deployment", req.DeploymentID, existingID)
+		return &DeploymentResult{
+			Success:      true,
+			Message:      "duplicate request, returning existing deployment",
+			DeploymentID: existingID,
+		}, nil
+	}
+
+	// Check for concurrent deployments to same service, queueing behind them
+	// when configured to
 	serviceKey := fmt.Sprintf("%s/%s", req.ClusterARN, req.ServiceName)
+	r.expireServiceLock(serviceKey)
+	r.queueMu.Lock()
 	if _, loaded := r.serviceQueue.LoadOrStore(serviceKey, req.DeploymentID); loaded {
+		if !r.queueConcurrent {
+			r.queueMu.Unlock()
+			return &DeploymentResult{
+				Success: false,
+				Message: "deployment already in progress for this service",
+			}, fmt.Errorf("concurrent deployment detected")
+		}
+
+		position, err := r.enqueueLocked(ctx, req, serviceKey, contentHash)
+		r.queueMu.Unlock()
+		if err != nil {
+			return &DeploymentResult{
+				Success: false,
+				Message: err.Error(),
+			}, err
+		}
+
+		if r.dedupWindow > 0 {
+			r.recentRequests.Store(contentHash, req.DeploymentID)
+		}
+		util.Logf(ctx, "[ROUTER] Deployment %s queued at position %d for service %s", req.DeploymentID, position, serviceKey)
 		return &DeploymentResult{
-			Success: false,
-			Message: "deployment already in progress for this service",
-		}, fmt.Errorf("concurrent deployment detected")
+			Success:       true,
+			Message:       fmt.Sprintf("deployment queued at position %d", position),
+			DeploymentID:  req.DeploymentID,
+			QueuePosition: position,
+		}, nil
 	}
+	r.touchServiceLock(req.DeploymentID)
+	r.queueMu.Unlock()
+
+	return r.startDeployment(ctx, req, serviceKey, contentHash)
+}
 
-	strat, ok := r.strategies[req.Strategy]
+// startDeployment runs a deployment that holds its service, releasing the
+// service again if the deployment cannot start
+func (r *Router) startDeployment(ctx context.Context, req *DeploymentRequest, serviceKey, contentHash string) (*DeploymentResult, error) {
+	strat, ok := r.strategies.Get(req.Strategy)
 	if !ok {
-		r.serviceQueue.Delete(serviceKey)
+		r.releaseService(serviceKey, req.DeploymentID)
 		return nil, fmt.Errorf("unknown strategy: %s", req.Strategy)
 	}
 
+	// Bound the number of deployments hitting ECS at once
+	if err := r.capacity.acquire(req.ClusterARN); err != nil {
+		r.releaseService(serviceKey, req.DeploymentID)
+		util.Logf(ctx, "[ROUTER] Rejecting deployment %s: %v", req.DeploymentID, err)
+		return &DeploymentResult{
+			Success: false,
+			Message: err.Error(),
+		}, err
+	}
+
+	// Drain waits for every deployment that gets past here
+	if !r.trackDeployment() {
+		r.capacity.release(req.ClusterARN)
+		r.releaseService(serviceKey, req.DeploymentID)
+		return &DeploymentResult{
+			Success: false,
+			Message: "server is shutting down",
+		}, errShuttingDown
+	}
+
+	if r.dedupWindow > 0 {
+		r.recentRequests.Store(contentHash, req.DeploymentID)
+	}
+
+	initialStatus, initialMessage := "RUNNING", "deployment started"
+	if len(req.DependsOn) > 0 {
+		initialStatus = "WAITING_DEPENDENCY"
+		initialMessage = fmt.Sprintf("waiting for dependencies: %s", strings.Join(req.DependsOn, ", "))
+	}
+
 	startTime := time.Now()
-	r.statuses.Store(req.DeploymentID, &DeploymentStatus{
-		Status:    "RUNNING",
-		Message:   "deployment started",
-		Progress:  0,
-		StartTime: startTime,
+	r.rememberRequest(req)
+	r.setStatus(req.DeploymentID, &DeploymentStatus{
+		Status:      initialStatus,
+		Message:     initialMessage,
+		Progress:    0,
+		StartTime:   startTime,
+		ClusterARN:  req.ClusterARN,
+		ServiceName: req.ServiceName,
+		Strategy:    req.Strategy,
 	})
 
 	metrics.IncrementInProgress()
+	r.auditStarted(req)
 
-	// Create cancellable context for this deployment
-	deployCtx, cancel := context.WithCancel(ctx)
+	// Create cancellable context for this deployment, detached from the RPC
+	// context so the deployment outlives the Deploy call. AWS calls made with it
+	// are attributed to the deployment.
+	deployCtx, cancel := context.WithCancel(metrics.WithDeploymentID(context.WithoutCancel(ctx), req.DeploymentID))
 	r.cancelFuncs.Store(req.DeploymentID, cancel)
 
+	// The deployment's span starts under the Deploy call's and outlives it
+	deployCtx, span := tracing.Start(deployCtx, "deployment",
+		attribute.String("deployment.id", req.DeploymentID),
+		attribute.String("deployment.strategy", req.Strategy),
+		attribute.String("ecs.cluster", req.ClusterARN),
+		attribute.String("ecs.service", req.ServiceName),
+		attribute.String("correlation_id", req.CorrelationID),
+	)
+
+	requireApproval := r.requiresApproval(req)
+
+	var pause *strategy.PauseGate
+	if strategy.SupportsPause(strat) {
+		pause = strategy.NewPauseGate()
+		r.pauseGates.Store(req.DeploymentID, pause)
+	}
+
+	recorder := strategy.NewDeploymentRecorder()
+	var eta *etaTracker
+	if planner, ok := strat.(strategy.Planner); ok {
+		eta = &etaTracker{plan: planner.PlanStages(req.Config), recorder: recorder}
+		r.etas.Store(req.DeploymentID, eta)
+	}
+
+	stopHeartbeat := r.heartbeatServiceLock(req.DeploymentID)
 	go func() {
+		defer r.inflight.Done()
+		defer stopHeartbeat()
 		defer func() {
-			r.serviceQueue.Delete(serviceKey)
+			// A panic here is outside the gRPC recovery interceptor; without this
+			// the service would stay locked and the status stuck in RUNNING
+			if p := recover(); p != nil {
+				util.Logf(ctx, "[ROUTER] Deployment %s panicked: %v\n%s", req.DeploymentID, p, debug.Stack())
+				metrics.RecordError("router", "deployment_panic")
+				r.setStatus(req.DeploymentID, &DeploymentStatus{
+					Status:      "FAILED",
+					Message:     fmt.Sprintf("deployment panicked: %v", p),
+					Progress:    100,
+					StartTime:   startTime,
+					EndTime:     time.Now(),
+					ClusterARN:  req.ClusterARN,
+					ServiceName: req.ServiceName,
+					Strategy:    req.Strategy,
+				})
+				metrics.RecordDeploymentContext(deployCtx, req.Strategy, "failed", time.Since(startTime))
+			}
+
+			// Past the recover above, a panic in the bookkeeping would crash the
+			// server before the capacity slot and the service are released
+			r.safely(req.DeploymentID, "manifest", func() { r.recordManifest(req, recorder) })
+			r.safely(req.DeploymentID, "audit outcome", func() { r.auditOutcome(req) })
+			r.safely(req.DeploymentID, "analysis", func() { r.recordAnalysis(req) })
+			r.safely(req.DeploymentID, "trace span", func() { r.endDeploymentSpan(span, req.DeploymentID) })
+			r.etas.Delete(req.DeploymentID)
+			r.pauseGates.Delete(req.DeploymentID)
 			r.cancelFuncs.Delete(req.DeploymentID)
+			r.capacity.release(req.ClusterARN)
 			metrics.DecrementInProgress()
 			cancel() // Ensure context is cancelled
+
+			// Release the service last so a queued deployment taking it over
+			// finds this one's capacity slot free
+			r.releaseService(serviceKey, req.DeploymentID)
 		}()
 
+		// Hold until upstream deployments have succeeded
+		if len(req.DependsOn) > 0 {
+			if err := r.waitForDependencies(deployCtx, req.DependsOn); err != nil {
+				status, event := waitOutcome(err)
+				r.setStatus(req.DeploymentID, &DeploymentStatus{
+					Status:      status,
+					Message:     fmt.Sprintf("aborted waiting for dependencies: %v", err),
+					Progress:    100,
+					StartTime:   startTime,
+					EndTime:     time.Now(),
+					ClusterARN:  req.ClusterARN,
+					ServiceName: req.ServiceName,
+					Strategy:    req.Strategy,
+				})
+				metrics.RecordDeploymentContext(deployCtx, req.Strategy, event, time.Since(startTime))
+				return
+			}
+
+			util.Logf(ctx, "[ROUTER] Dependencies satisfied for deployment %s", req.DeploymentID)
+			r.setStatus(req.DeploymentID, &DeploymentStatus{
+				Status:      "RUNNING",
+				Message:     "dependencies satisfied, deployment started",
+				Progress:    0,
+				StartTime:   startTime,
+				ClusterARN:  req.ClusterARN,
+				ServiceName: req.ServiceName,
+				Strategy:    req.Strategy,
+			})
+		}
+
+		// Hold until the deployment's approval group is approved
+		if groupID := req.Config["approval_group"]; groupID != "" {
+			if err := r.waitForGroupApproval(deployCtx, req, groupID, startTime); err != nil {
+				status, event := waitOutcome(err)
+				r.setStatus(req.DeploymentID, &DeploymentStatus{
+					Status:      status,
+					Message:     fmt.Sprintf("approval group %s: %v", groupID, err),
+					Progress:    100,
+					StartTime:   startTime,
+					EndTime:     time.Now(),
+					ClusterARN:  req.ClusterARN,
+					ServiceName: req.ServiceName,
+					Strategy:    req.Strategy,
+				})
+				metrics.RecordDeploymentContext(deployCtx, req.Strategy, event, time.Since(startTime))
+				return
+			}
+		}
+
+		// Hold until the deployment itself is approved
+		if requireApproval {
+			if err := r.waitForApproval(deployCtx, req, startTime); err != nil {
+				status, event := waitOutcome(err)
+				r.setStatus(req.DeploymentID, &DeploymentStatus{
+					Status:      status,
+					Message:     fmt.Sprintf("approval: %v", err),
+					Progress:    100,
+					StartTime:   startTime,
+					EndTime:     time.Now(),
+					ClusterARN:  req.ClusterARN,
+					ServiceName: req.ServiceName,
+					Strategy:    req.Strategy,
+				})
+				metrics.RecordDeploymentContext(deployCtx, req.Strategy, event, time.Since(startTime))
+				return
+			}
+		}
+
 		// Execute pre-deploy hooks
 		if err := r.hooks.ExecutePreDeployHooks(deployCtx, req.DeploymentID, req.ClusterARN, req.ServiceName); err != nil {
-			r.statuses.Store(req.DeploymentID, &DeploymentStatus{
-				Status:    "FAILED",
-				Message:   fmt.Sprintf("pre-deploy hook failed: %v", err),
-				Progress:  100,
-				StartTime: startTime,
-				EndTime:   time.Now(),
+			r.setStatus(req.DeploymentID, &DeploymentStatus{
+				Status:      "FAILED",
+				Message:     fmt.Sprintf("pre-deploy hook failed: %v", err),
+				Progress:    100,
+				StartTime:   startTime,
+				EndTime:     time.Now(),
+				ClusterARN:  req.ClusterARN,
+				ServiceName: req.ServiceName,
+				Strategy:    req.Strategy,
 			})
-			metrics.RecordDeployment(req.Strategy, "failed", time.Since(startTime))
+			metrics.RecordDeploymentContext(deployCtx, req.Strategy, "failed", time.Since(startTime))
 			return
 		}
 
 		// Check if deployment was cancelled before execution
 		select {
 		case <-deployCtx.Done():
-			r.statuses.Store(req.DeploymentID, &DeploymentStatus{
-				Status:    "CANCELLED",
-				Message:   "deployment cancelled before execution",
-				Progress:  100,
-				StartTime: startTime,
-				EndTime:   time.Now(),
+			r.setStatus(req.DeploymentID, &DeploymentStatus{
+				Status:      "CANCELLED",
+				Message:     "deployment cancelled before execution",
+				Progress:    100,
+				StartTime:   startTime,
+				EndTime:     time.Now(),
+				ClusterARN:  req.ClusterARN,
+				ServiceName: req.ServiceName,
+				Strategy:    req.Strategy,
 			})
-			metrics.RecordDeployment(req.Strategy, "cancelled", time.Since(startTime))
+			metrics.RecordDeploymentContext(deployCtx, req.Strategy, "cancelled", time.Since(startTime))
 			return
 		default:
 		}
 
+		if eta != nil {
+			eta.started(time.Now())
+		}
 		err := strat.Execute(deployCtx, &strategy.DeploymentContext{
 			DeploymentID:   req.DeploymentID,
 			ClusterARN:     req.ClusterARN,
 			ServiceName:    req.ServiceName,
 			TaskDefinition: req.TaskDefinition,
 			Config:         req.Config,
+			Recorder:       recorder,
+			Progress: func(percent int32, message string) {
+				r.setStatus(req.DeploymentID, &DeploymentStatus{
+					Status:      "RUNNING",
+					Message:     message,
+					Progress:    percent,
+					StartTime:   startTime,
+					ClusterARN:  req.ClusterARN,
+					ServiceName: req.ServiceName,
+					Strategy:    req.Strategy,
+				})
+			},
+			Pause: pause,
+			Paused: func(percent int32, message string) {
+				util.Logf(ctx, "[ROUTER] Deployment %s %s", req.DeploymentID, message)
+				r.setStatus(req.DeploymentID, &DeploymentStatus{
+					Status:      "PAUSED",
+					Message:     message,
+					Progress:    percent,
+					StartTime:   startTime,
+					ClusterARN:  req.ClusterARN,
+					ServiceName: req.ServiceName,
+					Strategy:    req.Strategy,
+				})
+			},
 		})
 
 		endTime := time.Now()
@@ -167,58 +743,533 @@ func (r *Router) RouteDeployment(ctx context.Context, req *DeploymentRequest) (*
 			if err == context.Canceled {
 				status = "CANCELLED"
 			}
-			r.statuses.Store(req.DeploymentID, &DeploymentStatus{
-				Status:    status,
-				Message:   err.Error(),
-				Progress:  100,
-				StartTime: startTime,
-				EndTime:   endTime,
+			r.setStatus(req.DeploymentID, &DeploymentStatus{
+				Status:      status,
+				Message:     err.Error(),
+				Progress:    100,
+				StartTime:   startTime,
+				EndTime:     endTime,
+				ClusterARN:  req.ClusterARN,
+				ServiceName: req.ServiceName,
+				Strategy:    req.Strategy,
 			})
-			metrics.RecordDeployment(req.Strategy, status, duration)
+			metrics.RecordDeploymentContext(deployCtx, req.Strategy, status, duration)
 		} else {
 			// Execute post-deploy hooks
 			if hookErr := r.hooks.ExecutePostDeployHooks(deployCtx, req.DeploymentID, req.ClusterARN, req.ServiceName); hookErr != nil {
-				r.statuses.Store(req.DeploymentID, &DeploymentStatus{
-					Status:    "FAILED",
-					Message:   fmt.Sprintf("post-deploy hook failed: %v", hookErr),
-					Progress:  100,
-					StartTime: startTime,
-					EndTime:   time.Now(),
+				r.setStatus(req.DeploymentID, &DeploymentStatus{
+					Status:      "FAILED",
+					Message:     fmt.Sprintf("post-deploy hook failed: %v", hookErr),
+					Progress:    100,
+					StartTime:   startTime,
+					EndTime:     time.Now(),
+					ClusterARN:  req.ClusterARN,
+					ServiceName: req.ServiceName,
+					Strategy:    req.Strategy,
 				})
-				metrics.RecordDeployment(req.Strategy, "failed", duration)
+				metrics.RecordDeploymentContext(deployCtx, req.Strategy, "failed", duration)
 				return
 			}
 
-			r.statuses.Store(req.DeploymentID, &DeploymentStatus{
-				Status:    "SUCCESS",
-				Message:   "deployment completed",
-				Progress:  100,
-				StartTime: startTime,
-				EndTime:   endTime,
+			r.setStatus(req.DeploymentID, &DeploymentStatus{
+				Status:      "SUCCESS",
+				Message:     "deployment completed",
+				Progress:    100,
+				StartTime:   startTime,
+				EndTime:     endTime,
+				ClusterARN:  req.ClusterARN,
+				ServiceName: req.ServiceName,
+				Strategy:    req.Strategy,
 			})
-			metrics.RecordDeployment(req.Strategy, "success", duration)
+			metrics.RecordDeploymentContext(deployCtx, req.Strategy, "success", duration)
+
+			r.deregisterOldRevisionsAfter(deployCtx, req, recorder.TaskDefinitionARN())
 		}
 	}()
 
+	return &DeploymentResult{
+		Success:         true,
+		Message:         "deployment initiated",
+		DeploymentID:    req.DeploymentID,
+		PendingApproval: requireApproval,
+	}, nil
+}
+
+// deregisterOldRevisionsAfter cleans up old revisions of the task definition
+// family a successful deployment registered, if enabled for the deployment.
+// It is best effort: failures are logged and never fail the deployment.
+func (r *Router) deregisterOldRevisionsAfter(ctx context.Context, req *DeploymentRequest, deployedARN string) {
+	enabled, keep := r.deregisterOldRevisions, r.keepRevisions
+	if value, ok := req.Config["deregister_old_revisions"]; ok {
+		enabled, _ = strconv.ParseBool(value)
+	}
+	if !enabled {
+		return
+	}
+	if value, ok := req.Config["keep_revisions"]; ok {
+		if n, err := strconv.Atoi(value); err == nil && n > 0 {
+			keep = n
+		} else {
+			util.Logf(ctx, "[ROUTER] Ignoring keep_revisions %q: not a positive integer", value)
+		}
+	}
+	if deployedARN == "" {
+		util.Logf(ctx, "[ROUTER] Skipping revision cleanup for %s: no task definition was registered", req.DeploymentID)
+		return
+	}
+
+	deregistered, err := r.executor.DeregisterOldRevisions(ctx, req.ClusterARN, req.ServiceName, deployedARN, keep)
+	if err != nil {
+		metrics.RecordError("router", "deregister_revisions")
+		util.Logf(ctx, "[ROUTER] Revision cleanup after %s incomplete: %v", req.DeploymentID, err)
+	}
+	if len(deregistered) > 0 {
+		util.Logf(ctx, "[ROUTER] Deregistered %d old revisions after %s, keeping the newest %d", len(deregistered), req.DeploymentID, keep)
+	}
+}
+
+// dryRun runs the strategy with AWS calls recorded instead of made and waits
+// skipped. Nothing is stored, no service lock is taken and no hooks run.
+func (r *Router) dryRun(ctx context.Context, req *DeploymentRequest) (*DeploymentResult, error) {
+	strat, ok := r.strategies.Get(req.Strategy)
+	if !ok {
+		return nil, fmt.Errorf("unknown strategy: %s", req.Strategy)
+	}
+
+	// Strategies may write into their config; keep the caller's map untouched
+	config := make(map[string]string, len(req.Config))
+	for k, v := range req.Config {
+		config[k] = v
+	}
+
+	util.Logf(ctx, "[ROUTER] Dry run of deployment %s (%s)", req.DeploymentID, req.Strategy)
+	plan := &executor.DryRunPlan{}
+	err := strat.Execute(executor.WithDryRun(ctx, plan), &strategy.DeploymentContext{
+		DeploymentID:   req.DeploymentID,
+		ClusterARN:     req.ClusterARN,
+		ServiceName:    req.ServiceName,
+		TaskDefinition: req.TaskDefinition,
+		Config:         config,
+		DryRun:         true,
+	})
+	if err != nil {
+		return &DeploymentResult{
+			Success:      false,
+			Message:      fmt.Sprintf("dry run failed: %v", err),
+			DeploymentID: req.DeploymentID,
+			PlannedSteps: plan.Steps(),
+		}, err
+	}
+
+	steps := plan.Steps()
 	return &DeploymentResult{
 		Success:      true,
-		Message:      "deployment initiated",
+		Message:      fmt.Sprintf("dry run: %d planned steps", len(steps)),
 		DeploymentID: req.DeploymentID,
+		PlannedSteps: steps,
 	}, nil
 }
 
+// setStatus stores a deployment status, pushes it to its watchers and
+// publishes a transition event when the status itself changed
+func (r *Router) setStatus(deploymentID string, status *DeploymentStatus) {
+	previous, _ := r.statuses.Swap(deploymentID, status)
+	if err := r.store.Save(deploymentID, status); err != nil {
+		log.Printf("[ROUTER] Failed to persist status for %s: %v", deploymentID, err)
+	}
+	prev, _ := previous.(*DeploymentStatus)
+	r.publishTransition(deploymentID, prev, status)
+
+	r.subMu.Lock()
+	defer r.subMu.Unlock()
+
+	for ch := range r.subscribers[deploymentID] {
+		update := *status // Each subscriber gets its own copy
+		select {
+		case ch <- &update:
+		default:
+			// Subscriber is behind; drop the oldest update so the latest state always lands
+			select {
+			case <-ch:
+			default:
+			}
+			ch <- &update
+		}
+	}
+}
+
+// WatchStatus returns a channel receiving every status change for a
+// deployment and a function that must be called to release the subscription
+func (r *Router) WatchStatus(deploymentID string) (<-chan *DeploymentStatus, func()) {
+	ch := make(chan *DeploymentStatus, subscriberBuffer)
+
+	r.subMu.Lock()
+	if r.subscribers[deploymentID] == nil {
+		r.subscribers[deploymentID] = make(map[chan *DeploymentStatus]struct{})
+	}
+	r.subscribers[deploymentID][ch] = struct{}{}
+	r.subMu.Unlock()
+
+	unsubscribe := func() {
+		r.subMu.Lock()
+		defer r.subMu.Unlock()
+
+		delete(r.subscribers[deploymentID], ch)
+		if len(r.subscribers[deploymentID]) == 0 {
+			delete(r.subscribers, deploymentID)
+		}
+	}
+
+	return ch, unsubscribe
+}
+
+// waitForDependencies blocks until every dependency reaches SUCCESS, returning
+// an error as soon as one fails, is cancelled, or is unknown
+func (r *Router) waitForDependencies(ctx context.Context, dependsOn []string) error {
+	ticker := time.NewTicker(dependencyPollInterval)
+	defer ticker.Stop()
+
+	for {
+		pending := 0
+		for _, depID := range dependsOn {
+			status, ok := r.loadStatus(depID)
+			if !ok {
+				return fmt.Errorf("dependency %s not found", depID)
+			}
+
+			switch {
+			case status.Status == "SUCCESS":
+			case IsTerminalStatus(status.Status):
+				return fmt.Errorf("dependency %s ended with status %s", depID, status.Status)
+			default:
+				pending++
+			}
+		}
+
+		if pending == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// waitForGroupApproval joins the deployment to its approval group and blocks
+// until the group is approved, rejected, or times out
+func (r *Router) waitForGroupApproval(ctx context.Context, req *DeploymentRequest, groupID string, startTime time.Time) error {
+	if err := r.approvalManager.RequestGroupApproval(ctx, groupID, req.DeploymentID, approvalTimeout(req)); err != nil {
+		return err
+	}
+
+	r.setStatus(req.DeploymentID, &DeploymentStatus{
+		Status:      "PENDING_APPROVAL",
+		Message:     fmt.Sprintf("waiting for approval of group %s", groupID),
+		Progress:    0,
+		StartTime:   startTime,
+		ClusterARN:  req.ClusterARN,
+		ServiceName: req.ServiceName,
+		Strategy:    req.Strategy,
+	})
+
+	if err := r.approvalManager.WaitForApproval(ctx, groupID, 0); err != nil {
+		return err
+	}
+
+	util.Logf(ctx, "[ROUTER] Approval group %s approved, deployment %s proceeding", groupID, req.DeploymentID)
+	r.setStatus(req.DeploymentID, &DeploymentStatus{
+		Status:      "RUNNING",
+		Message:     fmt.Sprintf("approval group %s approved, deployment started", groupID),
+		Progress:    0,
+		StartTime:   startTime,
+		ClusterARN:  req.ClusterARN,
+		ServiceName: req.ServiceName,
+		Strategy:    req.Strategy,
+	})
+	return nil
+}
+
+// requiresApproval reports whether a deployment must be approved before it
+// runs: requested explicitly, via the require_approval config key, or because
+// its strategy is listed in deployment.approval_strategies
+func (r *Router) requiresApproval(req *DeploymentRequest) bool {
+	if req.RequireApproval || r.approvalStrategies[req.Strategy] {
+		return true
+	}
+	required, _ := strconv.ParseBool(req.Config["require_approval"])
+	return required
+}
+
+// waitForApproval requests approval for a single deployment and blocks until
+// it is approved, rejected, or times out
+func (r *Router) waitForApproval(ctx context.Context, req *DeploymentRequest, startTime time.Time) error {
+	timeout := approvalTimeout(req)
+	if err := r.approvalManager.RequestApproval(ctx, req.DeploymentID, req.ClusterARN, req.ServiceName, req.Strategy, timeout); err != nil {
+		return err
+	}
+
+	r.setStatus(req.DeploymentID, &DeploymentStatus{
+		Status:      "PENDING_APPROVAL",
+		Message:     "waiting for approval",
+		Progress:    0,
+		StartTime:   startTime,
+		ClusterARN:  req.ClusterARN,
+		ServiceName: req.ServiceName,
+		Strategy:    req.Strategy,
+	})
+
+	if err := r.approvalManager.WaitForApproval(ctx, req.DeploymentID, timeout); err != nil {
+		return err
+	}
+
+	util.Logf(ctx, "[ROUTER] Deployment %s approved, proceeding", req.DeploymentID)
+	r.setStatus(req.DeploymentID, &DeploymentStatus{
+		Status:      "RUNNING",
+		Message:     "approved, deployment started",
+		Progress:    0,
+		StartTime:   startTime,
+		ClusterARN:  req.ClusterARN,
+		ServiceName: req.ServiceName,
+		Strategy:    req.Strategy,
+	})
+	return nil
+}
+
+// approvalTimeout is how long a deployment waits for approval, from its
+// approval_timeout config key; 0 uses the approval manager's default. Values
+// are checked by ValidateRequest.
+func approvalTimeout(req *DeploymentRequest) time.Duration {
+	timeout, _ := time.ParseDuration(req.Config["approval_timeout"])
+	return timeout
+}
+
+// waitOutcome maps the error that ended a deployment's wait for dependencies
+// or approval to its final status and metrics event
+func waitOutcome(err error) (string, string) {
+	switch {
+	case err == context.Canceled:
+		return "CANCELLED", "cancelled"
+	case errors.Is(err, executor.ErrApprovalTimeout):
+		return "APPROVAL_TIMEOUT", "approval_timeout"
+	}
+	return "FAILED", "failed"
+}
+
+// IsTerminalStatus reports whether a deployment status is final
+func IsTerminalStatus(status string) bool {
+	switch status {
+	case "SUCCESS", "FAILED", "CANCELLED", "INTERRUPTED", "APPROVAL_TIMEOUT":
+		return true
+	}
+	return false
+}
+
+// requestHash computes a content hash over the fields that define a deployment
+func requestHash(req *DeploymentRequest) string {
+	h := sha256.New()
+	for _, field := range []string{req.ClusterARN, req.ServiceName, req.TaskDefinition, req.Strategy} {
+		h.Write([]byte(field))
+		h.Write([]byte{0})
+	}
+
+	keys := make([]string, 0, len(req.Config))
+	for k := range req.Config {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		h.Write([]byte(k))
+		h.Write([]byte{'='})
+		h.Write([]byte(req.Config[k]))
+		h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// findDuplicate returns the deployment with the same content hash if it is
+// still running or succeeded within the dedup window. A failed or cancelled
+// deployment never absorbs a resend, since resending is how clients retry it.
+func (r *Router) findDuplicate(contentHash string) (string, bool) {
+	if r.dedupWindow <= 0 {
+		return "", false
+	}
+
+	val, ok := r.recentRequests.Load(contentHash)
+	if !ok {
+		return "", false
+	}
+	deploymentID := val.(string)
+
+	statusVal, ok := r.statuses.Load(deploymentID)
+	if !ok {
+		r.recentRequests.Delete(contentHash)
+		return "", false
+	}
+
+	status := statusVal.(*DeploymentStatus)
+	if !IsTerminalStatus(status.Status) {
+		return deploymentID, true
+	}
+	if status.Status == "SUCCESS" && time.Since(status.EndTime) <= r.dedupWindow {
+		return deploymentID, true
+	}
+
+	// Prior deployment did not succeed or aged out of the window
+	r.recentRequests.Delete(contentHash)
+	return "", false
+}
+
 func (r *Router) GetDeploymentStatus(ctx context.Context, deploymentID string) (*DeploymentStatus, error) {
-	val, ok := r.statuses.Load(deploymentID)
+	status, ok := r.loadStatus(deploymentID)
 	if !ok {
 		return nil, fmt.Errorf("deployment not found: %s", deploymentID)
 	}
-	return val.(*DeploymentStatus), nil
+	return status, nil
+}
+
+// DeploymentEntry pairs a deployment ID with its current status
+type DeploymentEntry struct {
+	DeploymentID string
+	Status       DeploymentStatus
+}
+
+// ListDeployments returns known deployments, most recently started first,
+// optionally only those in status and at most limit of them (all when 0)
+func (r *Router) ListDeployments(status string, limit int) []DeploymentEntry {
+	var entries []DeploymentEntry
+	r.statuses.Range(func(key, value interface{}) bool {
+		current := *value.(*DeploymentStatus)
+		if status == "" || current.Status == status {
+			entries = append(entries, DeploymentEntry{DeploymentID: key.(string), Status: current})
+		}
+		return true
+	})
+
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].Status.StartTime.Equal(entries[j].Status.StartTime) {
+			return entries[i].Status.StartTime.After(entries[j].Status.StartTime)
+		}
+		return entries[i].DeploymentID < entries[j].DeploymentID
+	})
+
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries
 }
 
+// safely runs one of a finished deployment's bookkeeping steps, logging a
+// panic in it instead of letting it skip the cleanup that follows
+func (r *Router) safely(deploymentID, step string, fn func()) {
+	defer func() {
+		if p := recover(); p != nil {
+			log.Printf("[ROUTER] Deployment %s panicked recording its %s: %v\n%s", deploymentID, step, p, debug.Stack())
+			metrics.RecordError("router", "deployment_panic")
+		}
+	}()
+	fn()
+}
+
+// recordAnalysis feeds a finished deployment into the global analysis engine
+func (r *Router) recordAnalysis(req *DeploymentRequest) {
+	val, ok := r.statuses.Load(req.DeploymentID)
+	if !ok {
+		return
+	}
+	status := val.(*DeploymentStatus)
+
+	outcome, errorMsg := "failed", status.Message
+	switch status.Status {
+	case "SUCCESS":
+		outcome, errorMsg = "success", ""
+	case "CANCELLED":
+		outcome = "cancelled"
+	}
+
+	metrics.GetGlobalAnalysisEngine().RecordDeployment(req.DeploymentID, req.Strategy, outcome, errorMsg,
+		status.EndTime.Sub(status.StartTime), status.StartTime)
+}
+
+// endDeploymentSpan ends a deployment's trace span with its final status,
+// marking the span failed unless the deployment succeeded
+func (r *Router) endDeploymentSpan(span trace.Span, deploymentID string) {
+	status, ok := r.loadStatus(deploymentID)
+	if !ok {
+		span.End()
+		return
+	}
+	span.SetAttributes(attribute.String("deployment.status", status.Status))
+
+	var err error
+	if status.Status != "SUCCESS" {
+		err = errors.New(status.Message)
+	}
+	tracing.End(span, err)
+}
+
+// GetAPICalls returns the AWS API calls a deployment has made so far, in order
+func (r *Router) GetAPICalls(deploymentID string) ([]metrics.APICall, error) {
+	if _, ok := r.statuses.Load(deploymentID); !ok {
+		return nil, fmt.Errorf("deployment not found: %s", deploymentID)
+	}
+	calls, _ := metrics.GetGlobalAPICallLog().Calls(deploymentID)
+	return calls, nil
+}
+
+// Rollback restores a service to the state captured before deploymentID
+// changed it. Without a snapshot, as for deployments still running or from
+// other tools, it returns the service to its previous deployment instead.
 func (r *Router) Rollback(ctx context.Context, deploymentID, clusterARN, serviceName string) error {
+	if manifest, err := r.GetManifest(deploymentID); err == nil && manifest.Snapshot != nil {
+		if clusterARN == "" && serviceName == "" {
+			clusterARN, serviceName = manifest.ClusterARN, manifest.ServiceName
+		}
+		if manifest.ClusterARN == clusterARN && manifest.ServiceName == serviceName {
+			util.Logf(ctx, "[ROUTER] Restoring %s/%s from the snapshot taken by deployment %s", clusterARN, serviceName, deploymentID)
+			return r.executor.RestoreServiceSnapshot(ctx, clusterARN, serviceName, manifest.Snapshot)
+		}
+	}
 	return r.executor.RollbackService(ctx, clusterARN, serviceName)
 }
 
+// RollbackToRevision rolls a service back to a specific task definition revision
+func (r *Router) RollbackToRevision(ctx context.Context, deploymentID, clusterARN, serviceName, taskDef string) error {
+	return r.executor.RollbackToRevision(ctx, clusterARN, serviceName, taskDef)
+}
+
+// RollbackTargets rolls back each target independently, reporting per-target
+// results so a failure on one service does not hide success on the others
+func (r *Router) RollbackTargets(ctx context.Context, deploymentID string, targets []RollbackTarget) []RollbackTargetResult {
+	results := make([]RollbackTargetResult, 0, len(targets))
+	for _, target := range targets {
+		result := RollbackTargetResult{
+			ClusterARN:  target.ClusterARN,
+			ServiceName: target.ServiceName,
+			Success:     true,
+			Message:     "rollback initiated",
+		}
+
+		var err error
+		if target.TaskDefinition != "" {
+			err = r.RollbackToRevision(ctx, deploymentID, target.ClusterARN, target.ServiceName, target.TaskDefinition)
+		} else {
+			err = r.Rollback(ctx, deploymentID, target.ClusterARN, target.ServiceName)
+		}
+		if err != nil {
+			util.Logf(ctx, "[ROUTER] Rollback of %s/%s failed: %v", target.ClusterARN, target.ServiceName, err)
+			result.Success = false
+			result.Message = err.Error()
+		}
+
+		results = append(results, result)
+	}
+	return results
+}
+
 // CancelDeployment cancels an in-progress deployment
 func (r *Router) CancelDeployment(deploymentID string) error {
 	// Get deployment status
@@ -228,9 +1279,15 @@ func (r *Router) CancelDeployment(deploymentID string) error {
 	}
 
 	status := val.(*DeploymentStatus)
-	if status.Status != "RUNNING" {
+	if IsTerminalStatus(status.Status) {
 		return fmt.Errorf("deployment %s is not running (status: %s)", deploymentID, status.Status)
 	}
+	if status.Status == "QUEUED" && r.cancelQueued(deploymentID, status) {
+		return nil
+	}
+	if status.Status == "SCHEDULED" && r.cancelScheduled(deploymentID, status) {
+		return nil
+	}
 
 	// Cancel the deployment context
 	if cancelFunc, ok := r.cancelFuncs.Load(deploymentID); ok {
@@ -243,6 +1300,83 @@ func (r *Router) CancelDeployment(deploymentID string) error {
 	return fmt.Errorf("cancel function not found for deployment %s", deploymentID)
 }
 
+// PauseDeployment holds a running deployment at its next stage boundary
+func (r *Router) PauseDeployment(deploymentID string) error {
+	gate, err := r.pauseGate(deploymentID)
+	if err != nil {
+		return err
+	}
+	if !gate.Pause() {
+		return fmt.Errorf("deployment %s is already paused", deploymentID)
+	}
+	log.Printf("[ROUTER] Pause requested for deployment %s", deploymentID)
+	return nil
+}
+
+// ResumeDeployment releases a paused deployment
+func (r *Router) ResumeDeployment(deploymentID string) error {
+	gate, err := r.pauseGate(deploymentID)
+	if err != nil {
+		return err
+	}
+	if !gate.Resume() {
+		return fmt.Errorf("deployment %s is not paused", deploymentID)
+	}
+	log.Printf("[ROUTER] Deployment %s resumed", deploymentID)
+	return nil
+}
+
+// pauseGate returns the pause gate of a running deployment
+func (r *Router) pauseGate(deploymentID string) (*strategy.PauseGate, error) {
+	if val, ok := r.pauseGates.Load(deploymentID); ok {
+		return val.(*strategy.PauseGate), nil
+	}
+
+	status, ok := r.loadStatus(deploymentID)
+	if !ok {
+		return nil, fmt.Errorf("deployment not found: %s", deploymentID)
+	}
+	if IsTerminalStatus(status.Status) {
+		return nil, fmt.Errorf("deployment %s already finished (status: %s)", deploymentID, status.Status)
+	}
+	return nil, fmt.Errorf("deployment %s uses strategy %s, which cannot be paused", deploymentID, status.Strategy)
+}
+
+// ForceRelease frees a service whose queue entry is stuck so new deployments
+// can start. The holding deployment is cancelled and, if not already finished,
+// marked FAILED. It returns the ID of the released deployment.
+func (r *Router) ForceRelease(clusterARN, serviceName string) (string, error) {
+	serviceKey := fmt.Sprintf("%s/%s", clusterARN, serviceName)
+	r.queueMu.Lock()
+	val, ok := r.serviceQueue.LoadAndDelete(serviceKey)
+	if !ok {
+		r.queueMu.Unlock()
+		return "", fmt.Errorf("no deployment holds service %s", serviceKey)
+	}
+	deploymentID := val.(string)
+	r.lockHeartbeats.Delete(deploymentID)
+	next := r.handOffLocked(serviceKey)
+	r.queueMu.Unlock()
+
+	if cancelFunc, ok := r.cancelFuncs.Load(deploymentID); ok {
+		cancelFunc.(context.CancelFunc)()
+	}
+
+	if val, ok := r.statuses.Load(deploymentID); ok {
+		status := *val.(*DeploymentStatus)
+		if !IsTerminalStatus(status.Status) {
+			status.Status = "FAILED"
+			status.Message = fmt.Sprintf("service force-released (last message: %s)", status.Message)
+			status.EndTime = time.Now()
+			r.setStatus(deploymentID, &status)
+		}
+	}
+
+	log.Printf("[ROUTER] Service %s force-released from deployment %s", serviceKey, deploymentID)
+	r.runQueued(serviceKey, next)
+	return deploymentID, nil
+}
+
 // ValidateRequest validates deployment request
 func (r *Router) ValidateRequest(req *DeploymentRequest) error {
 	if req.DeploymentID == "" {
@@ -260,22 +1394,57 @@ func (r *Router) ValidateRequest(req *DeploymentRequest) error {
 	if req.Strategy == "" {
 		return fmt.Errorf("strategy is required")
 	}
+	for _, depID := range req.DependsOn {
+		if depID == req.DeploymentID {
+			return fmt.Errorf("deployment cannot depend on itself")
+		}
+	}
+	if value, ok := req.Config["approval_timeout"]; ok {
+		if timeout, err := time.ParseDuration(value); err != nil || timeout <= 0 {
+			return fmt.Errorf("invalid approval_timeout %q: must be a positive duration such as 2h", value)
+		}
+	}
+	if !req.ScheduledAt.IsZero() && !req.ScheduledAt.After(time.Now()) {
+		return fmt.Errorf("invalid scheduled_at %s: must be in the future", req.ScheduledAt.UTC().Format(time.RFC3339))
+	}
 
 	// Validate strategy exists
-	if _, ok := r.strategies[req.Strategy]; !ok {
+	if _, ok := r.strategies.Get(req.Strategy); !ok {
 		return fmt.Errorf("unknown strategy: %s", req.Strategy)
 	}
 
 	return nil
 }
 
-// ListStrategies returns available deployment strategies
-func (r *Router) ListStrategies() []string {
-	strategies := make([]string, 0, len(r.strategies))
-	for name := range r.strategies {
-		strategies = append(strategies, name)
+// ListStrategies describes the available deployment strategies, sorted by name
+func (r *Router) ListStrategies() []StrategyInfo {
+	names := r.strategies.List()
+	infos := make([]StrategyInfo, 0, len(names))
+	for _, name := range names {
+		s, ok := r.strategies.Get(name)
+		if !ok {
+			continue // Unregistered since List
+		}
+		desc := strategy.Describe(s)
+		infos = append(infos, StrategyInfo{
+			Name:          name,
+			Description:   desc.Summary,
+			ConfigKeys:    desc.ConfigKeys,
+			SupportsPause: strategy.SupportsPause(s),
+		})
+	}
+	return infos
+}
+
+// RegisterStrategy makes a custom strategy available under name. Register
+// strategies before serving; names already in use, including the built-in
+// ones, are rejected.
+func (r *Router) RegisterStrategy(name string, s strategy.Strategy) error {
+	if err := r.strategies.Register(name, s); err != nil {
+		return err
 	}
-	return strategies
+	log.Printf("[ROUTER] Registered strategy %s", name)
+	return nil
 }
 
 // ApproveDeployment approves or rejects a deployment
@@ -285,3 +1454,107 @@ func (r *Router) ApproveDeployment(ctx context.Context, deploymentID string, app
 	}
 	return r.approvalManager.RejectDeployment(ctx, deploymentID, approver, reason)
 }
+
+// GetApprovalStatus returns the approval request gating a deployment or approval group
+func (r *Router) GetApprovalStatus(deploymentID string) (executor.ApprovalRequest, error) {
+	return r.approvalManager.GetApprovalRequest(deploymentID)
+}
+
+// ListPendingApprovals returns every approval request awaiting a decision, oldest first
+func (r *Router) ListPendingApprovals() []executor.ApprovalRequest {
+	return r.approvalManager.ListPendingApprovals()
+}
+
+// StartDriftMonitor begins background drift monitoring for a service
+func (r *Router) StartDriftMonitor(cluster, service, expectedTaskDef string, interval time.Duration) error {
+	return r.driftMonitors.Start(cluster, service, expectedTaskDef, interval)
+}
+
+// StopDriftMonitor stops background drift monitoring for a service
+func (r *Router) StopDriftMonitor(cluster, service string) bool {
+	return r.driftMonitors.Stop(cluster, service)
+}
+
+// DiffTaskDefinition reports what deploying taskDefJSON would change in the
+// task definition a service currently runs
+func (r *Router) DiffTaskDefinition(ctx context.Context, cluster, service, taskDefJSON string) (*executor.TaskDefinitionDiff, error) {
+	return r.executor.DiffTaskDefinition(ctx, cluster, service, taskDefJSON)
+}
+
+// SummarizeServices returns the deployment state of every service the router knows about
+func (r *Router) SummarizeServices() []*ServiceSummary {
+	summaries := make(map[string]*ServiceSummary)
+	lastStart := make(map[string]time.Time)
+	deploymentIDs := make(map[string]map[string]bool)
+
+	r.statuses.Range(func(key, value interface{}) bool {
+		deploymentID := key.(string)
+		status := value.(*DeploymentStatus)
+		serviceKey := fmt.Sprintf("%s/%s", status.ClusterARN, status.ServiceName)
+
+		summary, ok := summaries[serviceKey]
+		if !ok {
+			summary = &ServiceSummary{
+				ClusterARN:  status.ClusterARN,
+				ServiceName: status.ServiceName,
+			}
+			summaries[serviceKey] = summary
+			deploymentIDs[serviceKey] = make(map[string]bool)
+		}
+		deploymentIDs[serviceKey][deploymentID] = true
+
+		if status.StartTime.After(lastStart[serviceKey]) || summary.LastDeploymentID == "" {
+			lastStart[serviceKey] = status.StartTime
+			summary.LastDeploymentID = deploymentID
+			summary.LastStatus = status.Status
+			summary.LastMessage = status.Message
+		}
+		return true
+	})
+
+	// Include monitored services that have not been deployed through this server
+	for _, serviceKey := range r.driftMonitors.List() {
+		if _, ok := summaries[serviceKey]; !ok {
+			cluster, service := splitServiceKey(serviceKey)
+			summaries[serviceKey] = &ServiceSummary{ClusterARN: cluster, ServiceName: service}
+		}
+	}
+
+	engine := metrics.GetGlobalAnalysisEngine()
+	result := make([]*ServiceSummary, 0, len(summaries))
+	for serviceKey, summary := range summaries {
+		if val, ok := r.serviceQueue.Load(serviceKey); ok {
+			summary.InFlightDeploymentID = val.(string)
+		}
+		summary.DriftMonitored = r.driftMonitors.IsMonitoring(summary.ClusterARN, summary.ServiceName)
+
+		if ids := deploymentIDs[serviceKey]; len(ids) > 0 {
+			analysis := engine.GetAnalysisFiltered(func(insight metrics.DeploymentInsight) bool {
+				return ids[insight.DeploymentID]
+			})
+			summary.TotalDeployments = analysis.TotalDeployments
+			summary.SuccessRate = analysis.SuccessRate
+		}
+
+		result = append(result, summary)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].ClusterARN != result[j].ClusterARN {
+			return result[i].ClusterARN < result[j].ClusterARN
+		}
+		return result[i].ServiceName < result[j].ServiceName
+	})
+
+	return result
+}
+
+// splitServiceKey splits a "cluster/service" key on its last separator,
+// since cluster ARNs themselves contain slashes
+func splitServiceKey(serviceKey string) (string, string) {
+	idx := strings.LastIndex(serviceKey, "/")
+	if idx < 0 {
+		return "", serviceKey
+	}
+	return serviceKey[:idx], serviceKey[idx+1:]
+}
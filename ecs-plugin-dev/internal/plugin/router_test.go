@@ -0,0 +1,481 @@
+package plugin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"ecs-plugin-dev/internal/config"
+	"ecs-plugin-dev/internal/strategy"
+)
+
+const (
+	testClusterARN     = "arn:aws:ecs:us-east-1:123456789012:cluster/test"
+	testTaskDefinition = `{"family":"web","containerDefinitions":[{"name":"web","image":"nginx"}]}`
+)
+
+func TestMain(m *testing.M) {
+	// Clients read MOCK_MODE when a router builds them
+	os.Setenv("MOCK_MODE", "true")
+	os.Exit(m.Run())
+}
+
+// newTestRouter builds a mock-mode router from the default config as
+// adjusted by configure
+func newTestRouter(t *testing.T, configure func(cfg *config.Config)) *Router {
+	t.Helper()
+	cfg := config.DefaultConfig()
+	if configure != nil {
+		configure(cfg)
+	}
+	return NewRouterWithConfig(cfg)
+}
+
+// testRequest is a quicksync deployment of service with the given ID
+func testRequest(deploymentID, service string) *DeploymentRequest {
+	return &DeploymentRequest{
+		DeploymentID:   deploymentID,
+		ClusterARN:     testClusterARN,
+		ServiceName:    service,
+		TaskDefinition: testTaskDefinition,
+		Strategy:       "quicksync",
+		Config:         map[string]string{},
+	}
+}
+
+// strategyFunc adapts a function to a strategy, for registering test
+// strategies that fail or misbehave on demand
+type strategyFunc func(ctx context.Context, dctx *strategy.DeploymentContext) error
+
+func (f strategyFunc) Execute(ctx context.Context, dctx *strategy.DeploymentContext) error {
+	return f(ctx, dctx)
+}
+
+// route starts req, failing the test if the router refuses it
+func route(t *testing.T, r *Router, req *DeploymentRequest) *DeploymentResult {
+	t.Helper()
+	result, err := r.RouteDeployment(context.Background(), req)
+	if err != nil {
+		t.Fatalf("deployment %s refused: %v", req.DeploymentID, err)
+	}
+	return result
+}
+
+// waitForTerminal waits for a deployment to finish and returns its status
+func waitForTerminal(t *testing.T, r *Router, deploymentID string) *DeploymentStatus {
+	t.Helper()
+	return waitForStatus(t, r, deploymentID, IsTerminalStatus)
+}
+
+// waitForStatus polls a deployment until done accepts its status
+func waitForStatus(t *testing.T, r *Router, deploymentID string, done func(status string) bool) *DeploymentStatus {
+	t.Helper()
+	deadline := time.Now().Add(30 * time.Second)
+	for time.Now().Before(deadline) {
+		if status, err := r.GetDeploymentStatus(context.Background(), deploymentID); err == nil && done(status.Status) {
+			return status
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+	status, err := r.GetDeploymentStatus(context.Background(), deploymentID)
+	t.Fatalf("deployment %s did not reach the expected status: %+v (%v)", deploymentID, status, err)
+	return nil
+}
+
+func TestContentHashDedup(t *testing.T) {
+	tests := []struct {
+		name      string
+		window    time.Duration
+		wait      time.Duration
+		wantDedup bool
+	}{
+		{name: "resent within the window", window: time.Hour, wantDedup: true},
+		{name: "resent after the window", window: 50 * time.Millisecond, wait: 100 * time.Millisecond, wantDedup: false},
+		{name: "dedup disabled", window: 0, wantDedup: false},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRouter(t, func(cfg *config.Config) { cfg.Deployment.DedupWindow = tt.window })
+			service := fmt.Sprintf("dedup-%d", i)
+
+			first := testRequest(service+"-1", service)
+			route(t, r, first)
+			if status := waitForTerminal(t, r, first.DeploymentID); status.Status != "SUCCESS" {
+				t.Fatalf("first deployment ended %s: %s", status.Status, status.Message)
+			}
+			time.Sleep(tt.wait)
+
+			// Same content under a new ID, as a client without stable IDs resends it
+			second := testRequest(service+"-2", service)
+			result := route(t, r, second)
+
+			if tt.wantDedup {
+				if result.DeploymentID != first.DeploymentID {
+					t.Fatalf("expected the existing deployment %s, got %s", first.DeploymentID, result.DeploymentID)
+				}
+				if _, err := r.GetDeploymentStatus(context.Background(), second.DeploymentID); err == nil {
+					t.Fatal("expected no deployment to start for the duplicate")
+				}
+				return
+			}
+			if result.DeploymentID != second.DeploymentID {
+				t.Fatalf("expected a new deployment %s, got %s", second.DeploymentID, result.DeploymentID)
+			}
+			waitForTerminal(t, r, second.DeploymentID)
+		})
+	}
+}
+
+func TestContentHashDedupIgnoresFailedDeployment(t *testing.T) {
+	r := newTestRouter(t, func(cfg *config.Config) { cfg.Deployment.DedupWindow = time.Hour })
+	failing := strategyFunc(func(ctx context.Context, dctx *strategy.DeploymentContext) error {
+		return errors.New("scripted failure")
+	})
+	if err := r.RegisterStrategy("failing", failing); err != nil {
+		t.Fatal(err)
+	}
+
+	first := testRequest("dedup-failed-1", "dedup-failed")
+	first.Strategy = "failing"
+	route(t, r, first)
+	if status := waitForTerminal(t, r, first.DeploymentID); status.Status != "FAILED" {
+		t.Fatalf("first deployment ended %s, expected FAILED", status.Status)
+	}
+
+	// Resending identical content is how a client retries the failure
+	second := testRequest("dedup-failed-2", "dedup-failed")
+	second.Strategy = "failing"
+	if result := route(t, r, second); result.DeploymentID != second.DeploymentID {
+		t.Fatalf("expected the resend to start a new deployment, got %s", result.DeploymentID)
+	}
+	waitForTerminal(t, r, second.DeploymentID)
+}
+
+func TestContentHashDedupDistinguishesContent(t *testing.T) {
+	r := newTestRouter(t, func(cfg *config.Config) { cfg.Deployment.DedupWindow = time.Hour })
+
+	first := testRequest("dedup-content-1", "dedup-content")
+	route(t, r, first)
+	waitForTerminal(t, r, first.DeploymentID)
+
+	second := testRequest("dedup-content-2", "dedup-content")
+	second.Config["circuit_breaker"] = "true"
+	if result := route(t, r, second); result.DeploymentID != second.DeploymentID {
+		t.Fatalf("expected different config to start a new deployment, got %s", result.DeploymentID)
+	}
+	waitForTerminal(t, r, second.DeploymentID)
+}
+
+func TestSummarizeServices(t *testing.T) {
+	r := newTestRouter(t, nil)
+	failing := strategyFunc(func(ctx context.Context, dctx *strategy.DeploymentContext) error {
+		return errors.New("scripted failure")
+	})
+	if err := r.RegisterStrategy("failing", failing); err != nil {
+		t.Fatal(err)
+	}
+
+	// api: a success then a failure; worker: a single success
+	for _, req := range []*DeploymentRequest{
+		testRequest("summary-api-1", "summary-api"),
+		testRequest("summary-api-2", "summary-api"),
+		testRequest("summary-worker-1", "summary-worker"),
+	} {
+		if req.DeploymentID == "summary-api-2" {
+			req.Strategy = "failing"
+		}
+		route(t, r, req)
+		waitForTerminal(t, r, req.DeploymentID)
+	}
+	if err := r.StartDriftMonitor(testClusterARN, "summary-idle", "web:1", time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	defer r.StopDriftMonitor(testClusterARN, "summary-idle")
+
+	summaries := r.SummarizeServices()
+	if len(summaries) != 3 {
+		t.Fatalf("expected 3 services, got %d: %+v", len(summaries), summaries)
+	}
+
+	// Sorted by service name within the cluster
+	api, idle, worker := summaries[0], summaries[1], summaries[2]
+	if api.ServiceName != "summary-api" || idle.ServiceName != "summary-idle" || worker.ServiceName != "summary-worker" {
+		t.Fatalf("unexpected order: %s, %s, %s", api.ServiceName, idle.ServiceName, worker.ServiceName)
+	}
+
+	if api.LastDeploymentID != "summary-api-2" || api.LastStatus != "FAILED" || api.LastMessage != "scripted failure" {
+		t.Errorf("expected api's last deployment to be the failure, got %+v", api)
+	}
+	if api.TotalDeployments != 2 || api.SuccessRate != 50 {
+		t.Errorf("expected api to have 2 deployments at 50%% success, got %d at %v", api.TotalDeployments, api.SuccessRate)
+	}
+	if worker.LastDeploymentID != "summary-worker-1" || worker.LastStatus != "SUCCESS" {
+		t.Errorf("expected worker's deployment to have succeeded, got %+v", worker)
+	}
+	if worker.TotalDeployments != 1 || worker.SuccessRate != 100 {
+		t.Errorf("expected worker to have 1 deployment at 100%% success, got %d at %v", worker.TotalDeployments, worker.SuccessRate)
+	}
+	if !idle.DriftMonitored || idle.LastDeploymentID != "" || idle.TotalDeployments != 0 {
+		t.Errorf("expected the monitored service without deployments, got %+v", idle)
+	}
+	for _, summary := range summaries {
+		if summary.InFlightDeploymentID != "" {
+			t.Errorf("expected nothing in flight for %s, got %s", summary.ServiceName, summary.InFlightDeploymentID)
+		}
+	}
+}
+
+func TestDependsOn(t *testing.T) {
+	tests := []struct {
+		name        string
+		upstreamErr error
+		wantStatus  string
+	}{
+		{name: "upstream success proceeds", wantStatus: "SUCCESS"},
+		{name: "upstream failure aborts", upstreamErr: errors.New("scripted failure"), wantStatus: "FAILED"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			r := newTestRouter(t, nil)
+			release := make(chan struct{})
+			gated := strategyFunc(func(ctx context.Context, dctx *strategy.DeploymentContext) error {
+				<-release
+				return tt.upstreamErr
+			})
+			if err := r.RegisterStrategy("gated", gated); err != nil {
+				t.Fatal(err)
+			}
+
+			upstream := testRequest("upstream", "deps-upstream")
+			upstream.Strategy = "gated"
+			route(t, r, upstream)
+
+			downstream := testRequest("downstream", "deps-downstream")
+			downstream.DependsOn = []string{upstream.DeploymentID}
+			route(t, r, downstream)
+
+			if status, _ := r.GetDeploymentStatus(context.Background(), downstream.DeploymentID); status.Status != "WAITING_DEPENDENCY" {
+				t.Fatalf("expected the downstream deployment to wait, got %s", status.Status)
+			}
+			close(release)
+
+			status := waitForTerminal(t, r, downstream.DeploymentID)
+			if status.Status != tt.wantStatus {
+				t.Fatalf("expected %s, got %s: %s", tt.wantStatus, status.Status, status.Message)
+			}
+			if tt.upstreamErr != nil && !strings.Contains(status.Message, "dependency upstream ended with status FAILED") {
+				t.Errorf("expected the message to name the failed dependency, got %q", status.Message)
+			}
+		})
+	}
+}
+
+func TestDependsOnUnknownDeployment(t *testing.T) {
+	r := newTestRouter(t, nil)
+	req := testRequest("deps-orphan", "deps-orphan")
+	req.DependsOn = []string{"never-started"}
+	route(t, r, req)
+
+	status := waitForTerminal(t, r, req.DeploymentID)
+	if status.Status != "FAILED" || !strings.Contains(status.Message, "dependency never-started not found") {
+		t.Fatalf("expected the unknown dependency to abort the deployment, got %s: %s", status.Status, status.Message)
+	}
+}
+
+func TestGroupApprovalReleasesAllMembers(t *testing.T) {
+	r := newTestRouter(t, nil)
+
+	members := []string{"group-api", "group-worker", "group-web"}
+	for _, id := range members {
+		req := testRequest(id, id)
+		req.Config["approval_group"] = "release-1"
+		route(t, r, req)
+		waitForStatus(t, r, id, func(status string) bool { return status == "PENDING_APPROVAL" })
+	}
+
+	group, err := r.GetApprovalStatus("release-1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(group.Members) != len(members) {
+		t.Fatalf("expected %d members in the group, got %v", len(members), group.Members)
+	}
+
+	// Approving any member approves the whole group
+	if err := r.ApproveDeployment(context.Background(), "group-worker", true, "alice", "release window"); err != nil {
+		t.Fatal(err)
+	}
+	for _, id := range members {
+		if status := waitForTerminal(t, r, id); status.Status != "SUCCESS" {
+			t.Errorf("expected %s to be released and succeed, got %s: %s", id, status.Status, status.Message)
+		}
+	}
+	if err := r.ApproveDeployment(context.Background(), "release-1", true, "bob", ""); err == nil || !strings.Contains(err.Error(), "already approved") {
+		t.Errorf("expected the group to be approved once, got %v", err)
+	}
+}
+
+func TestHookFailureSeverity(t *testing.T) {
+	tests := []struct {
+		name       string
+		bestEffort bool
+		wantStatus string
+	}{
+		{name: "best-effort failure", bestEffort: true, wantStatus: "SUCCESS"},
+		{name: "critical failure", bestEffort: false, wantStatus: "FAILED"},
+	}
+
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRouter(t, func(cfg *config.Config) {
+				cfg.Hooks.PostDeploy = []string{"exit 3"}
+				if tt.bestEffort {
+					cfg.Hooks.BestEffort = []string{"exec:exit 3"}
+				}
+			})
+
+			req := testRequest(fmt.Sprintf("hook-%d", i), fmt.Sprintf("hook-%d", i))
+			route(t, r, req)
+			status := waitForTerminal(t, r, req.DeploymentID)
+			if status.Status != tt.wantStatus {
+				t.Fatalf("expected %s, got %s: %s", tt.wantStatus, status.Status, status.Message)
+			}
+			if tt.wantStatus == "FAILED" && !strings.Contains(status.Message, "post-deploy hook exec:exit 3 failed") {
+				t.Errorf("expected the message to name the hook, got %q", status.Message)
+			}
+		})
+	}
+}
+
+func TestAPICallSequenceForMockCanary(t *testing.T) {
+	r := newTestRouter(t, nil)
+	req := testRequest("apicalls-canary", "apicalls-canary")
+	req.Strategy = "canary"
+	req.Config = map[string]string{"canary_stages": "100", "stage_timeout": "1ms"}
+	route(t, r, req)
+	if status := waitForTerminal(t, r, req.DeploymentID); status.Status != "SUCCESS" {
+		t.Fatalf("canary ended %s: %s", status.Status, status.Message)
+	}
+
+	calls, err := r.GetAPICalls(req.DeploymentID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{
+		"ecs DescribeServices",            // snapshot
+		"ecs RegisterTaskDefinition",      // new revision
+		"ecs DescribeServices",            // primary task set
+		"ecs DescribeServices",            // task set network
+		"ecs CreateTaskSet",               // 100% stage
+		"ecs DescribeServices",            // stage stability
+		"elbv2 DescribeTargetHealth",      // final shift health gate
+		"elbv2 ModifyListener",            // final shift
+		"ecs UpdateServicePrimaryTaskSet", // promote the new task set
+		"ecs DeleteTaskSet",               // old primary
+		"ecs DescribeServices",            // post-deploy health check
+	}
+	got := make([]string, len(calls))
+	for i, call := range calls {
+		got[i] = call.Service + " " + call.Operation
+		if call.Status != "mock" {
+			t.Errorf("call %d (%s): expected status mock, got %s", i, got[i], call.Status)
+		}
+		if i > 0 && call.Timestamp.Before(calls[i-1].Timestamp) {
+			t.Errorf("call %d (%s) recorded out of order", i, got[i])
+		}
+	}
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected call sequence:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+
+	if _, err := r.GetAPICalls("apicalls-unknown"); err == nil {
+		t.Error("expected an error for an unknown deployment")
+	}
+}
+
+func TestStrategyPanicReleasesQueue(t *testing.T) {
+	r := newTestRouter(t, func(cfg *config.Config) { cfg.Deployment.ConcurrentPolicy = ConcurrentPolicyQueue })
+	release := make(chan struct{})
+	panicking := strategyFunc(func(ctx context.Context, dctx *strategy.DeploymentContext) error {
+		<-release
+		panic("scripted panic")
+	})
+	if err := r.RegisterStrategy("panicking", panicking); err != nil {
+		t.Fatal(err)
+	}
+
+	first := testRequest("panic-1", "panic-queue")
+	first.Strategy = "panicking"
+	route(t, r, first)
+	waitForStatus(t, r, first.DeploymentID, func(status string) bool { return status == "RUNNING" })
+
+	second := testRequest("panic-2", "panic-queue")
+	if result := route(t, r, second); result.QueuePosition != 1 {
+		t.Fatalf("expected the second deployment to queue behind the first, got %+v", result)
+	}
+	close(release)
+
+	status := waitForTerminal(t, r, first.DeploymentID)
+	if status.Status != "FAILED" || !strings.Contains(status.Message, "deployment panicked: scripted panic") {
+		t.Fatalf("expected the panic to fail the deployment, got %s: %s", status.Status, status.Message)
+	}
+	if status := waitForTerminal(t, r, second.DeploymentID); status.Status != "SUCCESS" {
+		t.Fatalf("expected the queued deployment to take over the service, got %s: %s", status.Status, status.Message)
+	}
+
+	// Nothing is left holding the service
+	third := testRequest("panic-3", "panic-queue")
+	if result := route(t, r, third); result.QueuePosition != 0 {
+		t.Fatalf("expected the service to be free, got %+v", result)
+	}
+	waitForTerminal(t, r, third.DeploymentID)
+}
+
+func TestCanaryRollsBackOnUnhealthyMockTargets(t *testing.T) {
+	t.Setenv("MOCK_HEALTHY_TARGETS", "0")
+	r := newTestRouter(t, nil)
+
+	req := testRequest("unhealthy-canary", "unhealthy-canary")
+	req.Strategy = "canary"
+	req.Config = map[string]string{"canary_stages": "100", "stage_timeout": "1ms"}
+	route(t, r, req)
+
+	status := waitForTerminal(t, r, req.DeploymentID)
+	if status.Status != "FAILED" || !strings.Contains(status.Message, "refusing traffic shift") {
+		t.Fatalf("expected the unhealthy targets to fail the canary, got %s: %s", status.Status, status.Message)
+	}
+
+	manifest, err := r.GetManifest(req.DeploymentID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shifts := manifest.TrafficShifts
+	if len(shifts) != 2 {
+		t.Fatalf("expected the refused promotion and the rollback, got %+v", shifts)
+	}
+	if shifts[0].CanaryWeight != 100 || shifts[0].Status != "failed" {
+		t.Errorf("expected the promotion to be refused, got %+v", shifts[0])
+	}
+	if shifts[1].PrimaryWeight != 100 || shifts[1].Status != "success" {
+		t.Errorf("expected traffic back on the primary, got %+v", shifts[1])
+	}
+
+	calls, err := r.GetAPICalls(req.DeploymentID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deleted := false
+	for _, call := range calls {
+		deleted = deleted || call.Operation == "DeleteTaskSet"
+	}
+	if !deleted {
+		t.Error("expected the rollback to delete the canary task set")
+	}
+}
@@ -0,0 +1,116 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"time"
+
+	"ecs-plugin-dev/internal/metrics"
+	"ecs-plugin-dev/internal/util"
+)
+
+// scheduledDeployment is a deployment waiting for its scheduled time
+type scheduledDeployment struct {
+	ctx context.Context
+	req *DeploymentRequest
+}
+
+// scheduleDeployment marks a deployment SCHEDULED and starts it at
+// req.ScheduledAt. Scheduled deployments live only in memory: a restart
+// marks them INTERRUPTED like any other unfinished deployment.
+func (r *Router) scheduleDeployment(ctx context.Context, req *DeploymentRequest) (*DeploymentResult, error) {
+	at := req.ScheduledAt.UTC().Format(time.RFC3339)
+	r.rememberRequest(req)
+	r.setStatus(req.DeploymentID, &DeploymentStatus{
+		Status:      "SCHEDULED",
+		Message:     fmt.Sprintf("scheduled for %s", at),
+		StartTime:   time.Now(),
+		ClusterARN:  req.ClusterARN,
+		ServiceName: req.ServiceName,
+		Strategy:    req.Strategy,
+	})
+
+	// The deployment outlives the Deploy call that scheduled it. Cancelling
+	// removes the entry, leaving the timer nothing to start.
+	scheduled := &scheduledDeployment{ctx: context.WithoutCancel(ctx), req: req}
+	r.scheduled.Store(req.DeploymentID, scheduled)
+	time.AfterFunc(time.Until(req.ScheduledAt), func() {
+		r.fireScheduled(scheduled)
+	})
+
+	util.Logf(ctx, "[ROUTER] Deployment %s scheduled for %s", req.DeploymentID, at)
+	return &DeploymentResult{
+		Success:      true,
+		Message:      fmt.Sprintf("deployment scheduled for %s", at),
+		DeploymentID: req.DeploymentID,
+	}, nil
+}
+
+// fireScheduled routes a scheduled deployment whose time has come, unless
+// it was cancelled first, possibly to be scheduled again under the same ID.
+// If it cannot start, or panics starting on the timer's goroutine where no
+// gRPC interceptor would recover it, it is marked FAILED.
+func (r *Router) fireScheduled(scheduled *scheduledDeployment) {
+	deploymentID := scheduled.req.DeploymentID
+	if !r.scheduled.CompareAndDelete(deploymentID, scheduled) {
+		return
+	}
+
+	req := *scheduled.req
+	req.ScheduledAt = time.Time{}
+	req.firing = true
+
+	util.Logf(scheduled.ctx, "[ROUTER] Starting scheduled deployment %s", deploymentID)
+	result, err := r.routeScheduled(scheduled.ctx, &req)
+	if err == nil && result != nil && result.Success {
+		return
+	}
+	if err == nil {
+		err = fmt.Errorf("%s", result.Message)
+	}
+
+	util.Logf(scheduled.ctx, "[ROUTER] Scheduled deployment %s could not start: %v", deploymentID, err)
+	now := time.Now()
+	r.setStatus(deploymentID, &DeploymentStatus{
+		Status:      "FAILED",
+		Message:     fmt.Sprintf("scheduled deployment could not start: %v", err),
+		Progress:    100,
+		StartTime:   now,
+		EndTime:     now,
+		ClusterARN:  req.ClusterARN,
+		ServiceName: req.ServiceName,
+		Strategy:    req.Strategy,
+	})
+}
+
+// routeScheduled routes a firing scheduled deployment, turning a panic into
+// an error
+func (r *Router) routeScheduled(ctx context.Context, req *DeploymentRequest) (result *DeploymentResult, err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			util.Logf(ctx, "[ROUTER] Scheduled deployment %s panicked starting: %v\n%s", req.DeploymentID, p, debug.Stack())
+			metrics.RecordError("router", "deployment_panic")
+			result, err = nil, fmt.Errorf("panicked starting: %v", p)
+		}
+	}()
+	return r.RouteDeployment(ctx, req)
+}
+
+// cancelScheduled removes a scheduled deployment before it fires and marks
+// it CANCELLED. It reports false if the deployment has already fired.
+func (r *Router) cancelScheduled(deploymentID string, status *DeploymentStatus) bool {
+	if _, ok := r.scheduled.LoadAndDelete(deploymentID); !ok {
+		return false
+	}
+
+	cancelled := *status
+	cancelled.Status = "CANCELLED"
+	cancelled.Message = "deployment cancelled before its scheduled time"
+	cancelled.Progress = 100
+	cancelled.EndTime = time.Now()
+	r.setStatus(deploymentID, &cancelled)
+	log.Printf("[ROUTER] Scheduled deployment %s cancelled", deploymentID)
+	return true
+}
@@ -0,0 +1,161 @@
+package plugin
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// StatusStore persists deployment statuses so they survive server restarts
+type StatusStore interface {
+	Save(deploymentID string, status *DeploymentStatus) error
+	Load(deploymentID string) (*DeploymentStatus, bool, error)
+	List() (map[string]*DeploymentStatus, error)
+	Delete(deploymentID string) error
+}
+
+// MemoryStatusStore keeps statuses in memory only; nothing survives a restart
+type MemoryStatusStore struct {
+	statuses sync.Map
+}
+
+func NewMemoryStatusStore() *MemoryStatusStore {
+	return &MemoryStatusStore{}
+}
+
+func (m *MemoryStatusStore) Save(deploymentID string, status *DeploymentStatus) error {
+	m.statuses.Store(deploymentID, status)
+	return nil
+}
+
+func (m *MemoryStatusStore) Load(deploymentID string) (*DeploymentStatus, bool, error) {
+	val, ok := m.statuses.Load(deploymentID)
+	if !ok {
+		return nil, false, nil
+	}
+	return val.(*DeploymentStatus), true, nil
+}
+
+func (m *MemoryStatusStore) List() (map[string]*DeploymentStatus, error) {
+	result := make(map[string]*DeploymentStatus)
+	m.statuses.Range(func(key, value interface{}) bool {
+		result[key.(string)] = value.(*DeploymentStatus)
+		return true
+	})
+	return result, nil
+}
+
+func (m *MemoryStatusStore) Delete(deploymentID string) error {
+	m.statuses.Delete(deploymentID)
+	return nil
+}
+
+// statusFileExt is the extension of per-deployment status files
+const statusFileExt = ".json"
+
+// FileStatusStore writes each deployment status as a JSON file under a directory
+type FileStatusStore struct {
+	mu  sync.Mutex
+	dir string
+}
+
+func NewFileStatusStore(dir string) (*FileStatusStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create status directory: %w", err)
+	}
+	return &FileStatusStore{dir: dir}, nil
+}
+
+func (f *FileStatusStore) Save(deploymentID string, status *DeploymentStatus) error {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("failed to marshal status: %w", err)
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	// Write to a temp file and rename so a crash never leaves a partial file
+	path := f.path(deploymentID)
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return fmt.Errorf("failed to write status file: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to replace status file: %w", err)
+	}
+	return nil
+}
+
+func (f *FileStatusStore) Load(deploymentID string) (*DeploymentStatus, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	data, err := os.ReadFile(f.path(deploymentID))
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read status file: %w", err)
+	}
+
+	var status DeploymentStatus
+	if err := json.Unmarshal(data, &status); err != nil {
+		return nil, false, fmt.Errorf("failed to parse status file: %w", err)
+	}
+	return &status, true, nil
+}
+
+func (f *FileStatusStore) List() (map[string]*DeploymentStatus, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	entries, err := os.ReadDir(f.dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read status directory: %w", err)
+	}
+
+	result := make(map[string]*DeploymentStatus)
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, statusFileExt) {
+			continue
+		}
+
+		deploymentID, err := url.PathUnescape(strings.TrimSuffix(name, statusFileExt))
+		if err != nil {
+			continue
+		}
+
+		data, err := os.ReadFile(filepath.Join(f.dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read status file %s: %w", name, err)
+		}
+
+		var status DeploymentStatus
+		if err := json.Unmarshal(data, &status); err != nil {
+			return nil, fmt.Errorf("failed to parse status file %s: %w", name, err)
+		}
+		result[deploymentID] = &status
+	}
+	return result, nil
+}
+
+func (f *FileStatusStore) Delete(deploymentID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if err := os.Remove(f.path(deploymentID)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove status file: %w", err)
+	}
+	return nil
+}
+
+// path maps a deployment ID to its file, escaping characters such as "/"
+func (f *FileStatusStore) path(deploymentID string) string {
+	return filepath.Join(f.dir, url.PathEscape(deploymentID)+statusFileExt)
+}
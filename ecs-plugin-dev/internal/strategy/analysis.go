@@ -0,0 +1,137 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"ecs-plugin-dev/internal/aws"
+	"ecs-plugin-dev/internal/executor"
+	"ecs-plugin-dev/internal/util"
+)
+
+// analysisKeys are the deployment config keys that override the server's
+// canary analysis thresholds
+var analysisKeys = []string{"canary_analysis", "canary_max_error_increase", "canary_max_latency_increase", "canary_min_requests"}
+
+// AnalysisThresholds bound how much worse than the baseline (primary) target
+// group a canary may perform before its stage fails
+type AnalysisThresholds struct {
+	Enabled bool
+	// MaxErrorRateIncrease is how far, in percent of the baseline error rate,
+	// the canary error rate may rise
+	MaxErrorRateIncrease float64
+	// MaxLatencyIncrease is how far, in percent of the baseline average, the
+	// canary's average target response time may rise
+	MaxLatencyIncrease float64
+	// MinRequests is the traffic each group needs for a verdict; with less
+	// the analysis is inconclusive and the stage passes
+	MinRequests int64
+	// Confidence an error rate increase must be significant at, between 0 and 1
+	Confidence float64
+}
+
+// CanaryAnalyzer compares a canary's target group metrics with the primary's
+// over a stage's bake window
+type CanaryAnalyzer struct {
+	executor   *executor.Executor
+	thresholds AnalysisThresholds
+}
+
+// NewCanaryAnalyzer creates an analyzer applying thresholds unless a
+// deployment overrides them
+func NewCanaryAnalyzer(exec *executor.Executor, thresholds AnalysisThresholds) *CanaryAnalyzer {
+	return &CanaryAnalyzer{executor: exec, thresholds: thresholds}
+}
+
+// Analyze fails when the canary served the window ending now measurably
+// worse than the baseline
+func (a *CanaryAnalyzer) Analyze(ctx context.Context, dctx *DeploymentContext, window time.Duration) error {
+	thresholds := a.thresholdsFor(dctx.Config)
+	if !thresholds.Enabled {
+		return nil
+	}
+
+	routing, err := parseTrafficRouting(dctx.Config)
+	if err != nil {
+		return err
+	}
+	canary, baseline, err := a.executor.CanaryTrafficStats(ctx, dctx.ClusterARN, dctx.ServiceName, routing, window)
+	if err != nil {
+		return fmt.Errorf("canary analysis failed: %w", err)
+	}
+	if dctx.DryRun {
+		return nil
+	}
+	util.Logf(ctx, "[ANALYSIS] Canary: %d requests, %.3f%% errors, %.3fs avg; baseline: %d requests, %.3f%% errors, %.3fs avg",
+		canary.Requests, 100*canary.ErrorRate(), canary.AvgResponseTime,
+		baseline.Requests, 100*baseline.ErrorRate(), baseline.AvgResponseTime)
+	return thresholds.compare(ctx, canary, baseline)
+}
+
+// thresholdsFor applies a deployment's overrides to the server thresholds
+func (a *CanaryAnalyzer) thresholdsFor(config map[string]string) AnalysisThresholds {
+	t := a.thresholds
+	if v, ok := config["canary_analysis"]; ok {
+		t.Enabled = v == "true" || v == "1"
+	}
+	if v, err := strconv.ParseFloat(config["canary_max_error_increase"], 64); err == nil && v >= 0 {
+		t.MaxErrorRateIncrease = v
+	}
+	if v, err := strconv.ParseFloat(config["canary_max_latency_increase"], 64); err == nil && v >= 0 {
+		t.MaxLatencyIncrease = v
+	}
+	if v, err := strconv.ParseInt(config["canary_min_requests"], 10, 64); err == nil && v >= 0 {
+		t.MinRequests = v
+	}
+	return t
+}
+
+// compare applies the thresholds to one window's stats. An error rate above
+// the threshold must also be significant, so a handful of errors on a
+// near-perfect baseline does not fail the stage.
+func (t AnalysisThresholds) compare(ctx context.Context, canary, baseline aws.TargetGroupStats) error {
+	if canary.Requests < t.MinRequests || baseline.Requests < t.MinRequests {
+		util.Logf(ctx, "[ANALYSIS] Inconclusive: fewer than %d requests per target group (canary %d, baseline %d); passing",
+			t.MinRequests, canary.Requests, baseline.Requests)
+		return nil
+	}
+
+	canaryRate, baselineRate := canary.ErrorRate(), baseline.ErrorRate()
+	if limit := baselineRate * (1 + t.MaxErrorRateIncrease/100); canaryRate > limit {
+		if z := errorRateZScore(canary, baseline); z > criticalZ(t.Confidence) {
+			return fmt.Errorf("canary error rate %.3f%% exceeds baseline %.3f%% by more than %.0f%% (z=%.2f)",
+				100*canaryRate, 100*baselineRate, t.MaxErrorRateIncrease, z)
+		}
+		util.Logf(ctx, "[ANALYSIS] Canary error rate %.3f%% above baseline %.3f%% is not significant at %.0f%% confidence",
+			100*canaryRate, 100*baselineRate, 100*t.Confidence)
+	}
+
+	if limit := baseline.AvgResponseTime * (1 + t.MaxLatencyIncrease/100); baseline.AvgResponseTime > 0 && canary.AvgResponseTime > limit {
+		return fmt.Errorf("canary response time %.3fs exceeds baseline %.3fs by more than %.0f%%",
+			canary.AvgResponseTime, baseline.AvgResponseTime, t.MaxLatencyIncrease)
+	}
+
+	util.Logf(ctx, "[ANALYSIS] Canary within thresholds")
+	return nil
+}
+
+// errorRateZScore is the two-proportion z statistic for the canary error
+// rate exceeding the baseline's, using the pooled rate
+func errorRateZScore(canary, baseline aws.TargetGroupStats) float64 {
+	n1, n2 := float64(canary.Requests), float64(baseline.Requests)
+	pooled := float64(canary.Errors+baseline.Errors) / (n1 + n2)
+	se := math.Sqrt(pooled * (1 - pooled) * (1/n1 + 1/n2))
+	if se == 0 {
+		return 0
+	}
+	return (canary.ErrorRate() - baseline.ErrorRate()) / se
+}
+
+// criticalZ is the one-sided critical value of the standard normal
+// distribution at confidence
+func criticalZ(confidence float64) float64 {
+	return math.Sqrt2 * math.Erfinv(2*confidence-1)
+}
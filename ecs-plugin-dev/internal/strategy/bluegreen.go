@@ -4,10 +4,10 @@ package strategy
 import (
 	"context"
 	"fmt"
-	"log"
 	"time"
 
 	"ecs-plugin-dev/internal/executor"
+	"ecs-plugin-dev/internal/util"
 )
 
 type BlueGreenStrategy struct {
@@ -18,88 +18,161 @@ func NewBlueGreenStrategy(exec *executor.Executor) Strategy {
 	return &BlueGreenStrategy{executor: exec}
 }
 
+func (s *BlueGreenStrategy) Describe() Description {
+	return Description{
+		Summary:    "Full green environment, then a complete traffic switch",
+		ConfigKeys: configKeys([]string{"stabilization_time", "cleanup_delay"}, taskSetScaleKeys, taskSetNetworkKeys, trafficRoutingKeys),
+	}
+}
+
 func (s *BlueGreenStrategy) Execute(ctx context.Context, dctx *DeploymentContext) error {
-	log.Println("[BLUEGREEN] Starting blue-green deployment")
+	util.Logf(ctx, "[BLUEGREEN] Starting blue-green deployment")
 
-	// Save previous task definition for rollback
-	if err := s.executor.RollbackService(ctx, dctx.ClusterARN, dctx.ServiceName); err != nil {
-		log.Printf("[BLUEGREEN] Warning: Could not fetch previous task definition: %v", err)
+	if err := validateTaskDefinition(s.executor, dctx); err != nil {
+		return err
+	}
+
+	// Snapshot the service for rollback before changing anything
+	if err := captureServiceState(ctx, s.executor, dctx); err != nil {
+		util.Logf(ctx, "[BLUEGREEN] Warning: Could not snapshot service state: %v", err)
 	}
 
 	// Register new task definition (green)
-	if err := s.executor.RegisterTaskDefinition(ctx, dctx.TaskDefinition); err != nil {
+	arn, err := s.executor.RegisterTaskDefinitionARN(ctx, dctx.TaskDefinition)
+	if err != nil {
 		return fmt.Errorf("failed to register green task definition: %w", err)
 	}
+	dctx.Recorder.SetTaskDefinitionARN(arn)
+	dctx.ReportProgress(10, "green task definition registered")
+
+	// Remember blue so it can be removed once traffic is on green
+	blueID, err := s.executor.PrimaryTaskSetID(ctx, dctx.ClusterARN, dctx.ServiceName)
+	if err != nil {
+		util.Logf(ctx, "[BLUEGREEN] Warning: Could not find blue task set: %v", err)
+	}
+	dctx.PreviousTaskSetID = blueID
 
 	// Create green task set at 100% weight
-	log.Println("[BLUEGREEN] Creating green environment")
-	if err := s.executor.CreateTaskSet(ctx, dctx.ClusterARN, dctx.ServiceName, dctx.TaskDefinition, 100); err != nil {
+	greenCtx := dctx.Recorder.StartStage(ctx, "green")
+	util.Logf(greenCtx, "[BLUEGREEN] Creating green environment")
+	scale, err := s.executor.ResolveTaskSetScale(greenCtx, dctx.ClusterARN, dctx.ServiceName, dctx.Config, 100)
+	if err != nil {
+		dctx.Recorder.RecordStage("green", err)
+		return fmt.Errorf("invalid green task set scale: %w", err)
+	}
+	network, err := s.executor.ResolveTaskSetNetwork(greenCtx, dctx.ClusterARN, dctx.ServiceName, dctx.TaskDefinition, dctx.Config)
+	if err != nil {
+		dctx.Recorder.RecordStage("green", err)
+		return fmt.Errorf("invalid green task set network: %w", err)
+	}
+	greenID, err := s.executor.CreateTaskSetWithScale(greenCtx, dctx.ClusterARN, dctx.ServiceName, dctx.TaskDefinition, scale, network)
+	if err != nil {
+		dctx.Recorder.RecordStage("green", err)
 		return fmt.Errorf("failed to create green task set: %w", err)
 	}
+	dctx.TaskSetIDs = append(dctx.TaskSetIDs, greenID)
 
 	// Wait for green environment to stabilize
-	stabilizationTime := 30 * time.Second
-	if timeoutStr, ok := dctx.Config["stabilization_time"]; ok {
-		if duration, err := time.ParseDuration(timeoutStr); err == nil {
-			stabilizationTime = duration
-		}
-	}
+	stabilizationTime := parseStabilizationTime(dctx.Config)
 
-	log.Printf("[BLUEGREEN] Waiting %v for green environment to stabilize", stabilizationTime)
-	stabilizeCtx, cancel := context.WithTimeout(ctx, stabilizationTime+time.Minute)
+	util.Logf(greenCtx, "[BLUEGREEN] Waiting %v for green environment to stabilize", stabilizationTime)
+	stabilizeCtx, cancel := context.WithTimeout(greenCtx, stabilizationTime+time.Minute)
 	defer cancel()
 
 	if err := s.executor.WaitForServiceStable(stabilizeCtx, dctx.ClusterARN, dctx.ServiceName, stabilizationTime+time.Minute); err != nil {
-		log.Printf("[BLUEGREEN] Green environment failed to stabilize: %v, initiating rollback", err)
-		s.rollback(ctx, dctx)
+		dctx.Recorder.RecordStage("green", err)
+		util.Logf(greenCtx, "[BLUEGREEN] Green environment failed to stabilize: %v, initiating rollback", err)
+		s.rollback(greenCtx, dctx)
 		return fmt.Errorf("green environment stabilization failed: %w", err)
 	}
 
-	log.Println("[BLUEGREEN] Green environment is stable")
+	util.Logf(greenCtx, "[BLUEGREEN] Green environment is stable")
+	dctx.Recorder.RecordStage("green", nil)
+	dctx.ReportProgress(50, "green environment stable")
 
 	// Shift traffic to green (100% to new, 0% to old)
-	log.Println("[BLUEGREEN] Shifting traffic to green environment")
-	if err := s.executor.UpdateTraffic(ctx, dctx.ClusterARN, dctx.ServiceName, 100, 0); err != nil {
-		log.Printf("[BLUEGREEN] Traffic shift failed: %v, initiating rollback", err)
+	util.Logf(ctx, "[BLUEGREEN] Shifting traffic to green environment")
+	err = shiftTraffic(ctx, s.executor, dctx, "bluegreen", 100, 0)
+	if err != nil {
+		util.Logf(ctx, "[BLUEGREEN] Traffic shift failed: %v, initiating rollback", err)
 		s.rollback(ctx, dctx)
 		return fmt.Errorf("traffic shift failed: %w", err)
 	}
 
 	// Wait before cleanup
-	cleanupDelay := 1 * time.Minute
-	if delayStr, ok := dctx.Config["cleanup_delay"]; ok {
-		if duration, err := time.ParseDuration(delayStr); err == nil {
-			cleanupDelay = duration
+	cleanupDelay := parseCleanupDelay(dctx.Config)
+
+	dctx.ReportProgress(75, "traffic shifted to green")
+
+	util.Logf(ctx, "[BLUEGREEN] Waiting %v before cleanup", cleanupDelay)
+	if dctx.DryRun {
+		executor.RecordDryRunStep(ctx, "Wait %v before cleanup", cleanupDelay)
+	} else {
+		select {
+		case <-ctx.Done():
+			// Traffic is already on green; leave blue in place for manual cleanup
+			util.Logf(ctx, "[BLUEGREEN] Context canceled before cleanup, keeping blue environment")
+			return ctx.Err()
+		case <-time.After(cleanupDelay):
 		}
 	}
 
-	log.Printf("[BLUEGREEN] Waiting %v before cleanup", cleanupDelay)
-	time.Sleep(cleanupDelay)
-
 	// Cleanup blue environment
-	log.Println("[BLUEGREEN] Cleaning up blue environment")
-	if err := s.executor.DeleteTaskSet(ctx, dctx.ClusterARN, dctx.ServiceName, "PRIMARY"); err != nil {
-		log.Printf("[BLUEGREEN] Warning: cleanup failed: %v", err)
+	util.Logf(ctx, "[BLUEGREEN] Cleaning up blue environment")
+	dctx.ReportProgress(90, "cleaning up blue environment")
+	if dctx.PreviousTaskSetID == "" {
+		util.Logf(ctx, "[BLUEGREEN] No blue task set to clean up")
+	} else if err := s.executor.DeleteTaskSet(ctx, dctx.ClusterARN, dctx.ServiceName, dctx.PreviousTaskSetID); err != nil {
+		util.Logf(ctx, "[BLUEGREEN] Warning: cleanup failed: %v", err)
 		// Don't fail deployment on cleanup error
 	}
 
-	log.Println("[BLUEGREEN] Deployment completed successfully")
+	util.Logf(ctx, "[BLUEGREEN] Deployment completed successfully")
 	return nil
 }
 
+// PlanStages estimates the green stage as its stabilization time, followed by
+// the cleanup delay
+func (s *BlueGreenStrategy) PlanStages(config map[string]string) []time.Duration {
+	return []time.Duration{parseStabilizationTime(config), parseCleanupDelay(config)}
+}
+
+// parseStabilizationTime extracts how long green is given to stabilize
+func parseStabilizationTime(config map[string]string) time.Duration {
+	if timeoutStr, ok := config["stabilization_time"]; ok {
+		if duration, err := time.ParseDuration(timeoutStr); err == nil {
+			return duration
+		}
+	}
+	return 30 * time.Second
+}
+
+// parseCleanupDelay extracts how long blue is kept after traffic moves to green
+func parseCleanupDelay(config map[string]string) time.Duration {
+	if delayStr, ok := config["cleanup_delay"]; ok {
+		if duration, err := time.ParseDuration(delayStr); err == nil {
+			return duration
+		}
+	}
+	return 1 * time.Minute
+}
+
 // rollback reverts to blue environment
 func (s *BlueGreenStrategy) rollback(ctx context.Context, dctx *DeploymentContext) {
-	log.Println("[BLUEGREEN ROLLBACK] Starting automatic rollback to blue environment")
+	util.Logf(ctx, "[BLUEGREEN ROLLBACK] Starting automatic rollback to blue environment")
 
 	// Shift traffic back to blue (0% to new, 100% to old)
-	if err := s.executor.UpdateTraffic(ctx, dctx.ClusterARN, dctx.ServiceName, 0, 100); err != nil {
-		log.Printf("[BLUEGREEN ROLLBACK] Failed to shift traffic back: %v", err)
+	err := restoreTraffic(ctx, s.executor, dctx, "bluegreen")
+	if err != nil {
+		util.Logf(ctx, "[BLUEGREEN ROLLBACK] Failed to shift traffic back: %v", err)
 	}
 
 	// Delete green task set
-	if err := s.executor.DeleteTaskSet(ctx, dctx.ClusterARN, dctx.ServiceName, "GREEN"); err != nil {
-		log.Printf("[BLUEGREEN ROLLBACK] Failed to delete green task set: %v", err)
+	for _, taskSetID := range dctx.TaskSetIDs {
+		if err := s.executor.DeleteTaskSet(ctx, dctx.ClusterARN, dctx.ServiceName, taskSetID); err != nil {
+			util.Logf(ctx, "[BLUEGREEN ROLLBACK] Failed to delete green task set %s: %v", taskSetID, err)
+		}
 	}
 
-	log.Println("[BLUEGREEN ROLLBACK] Rollback completed")
+	util.Logf(ctx, "[BLUEGREEN ROLLBACK] Rollback completed")
 }
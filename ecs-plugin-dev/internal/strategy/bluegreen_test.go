@@ -0,0 +1,59 @@
+package strategy
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"ecs-plugin-dev/internal/metrics"
+)
+
+func TestBlueGreenCancelDuringCleanupReturnsPromptly(t *testing.T) {
+	dctx := newTestContext(map[string]string{"stabilization_time": "1ms", "cleanup_delay": "1h"})
+	dctx.DeploymentID = "bluegreen-cancel-cleanup"
+	waitingForCleanup := make(chan struct{})
+	dctx.Progress = func(percent int32, message string) {
+		if percent == 75 {
+			close(waitingForCleanup)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(metrics.WithDeploymentID(context.Background(), dctx.DeploymentID))
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- NewBlueGreenStrategy(newMockExecutor(t, "")).Execute(ctx, dctx) }()
+
+	select {
+	case <-waitingForCleanup:
+	case err := <-done:
+		t.Fatalf("deployment ended before the cleanup wait: %v", err)
+	case <-time.After(30 * time.Second):
+		t.Fatal("deployment never shifted traffic to green")
+	}
+	cancel()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("cancel during the cleanup wait did not return promptly")
+	}
+
+	// Traffic is already on green, so blue is left for manual cleanup
+	calls, _ := metrics.GetGlobalAPICallLog().Calls(dctx.DeploymentID)
+	created := false
+	for _, call := range calls {
+		switch call.Operation {
+		case "CreateTaskSet":
+			created = true
+		case "DeleteTaskSet":
+			t.Error("expected the blue task set to be kept after cancellation")
+		}
+	}
+	if !created {
+		t.Errorf("expected the green task set in the recorded calls, got %+v", calls)
+	}
+}
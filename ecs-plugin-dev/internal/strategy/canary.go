@@ -4,102 +4,286 @@ package strategy
 import (
 	"context"
 	"fmt"
-	"log"
 	"strconv"
 	"strings"
 	"time"
 
 	"ecs-plugin-dev/internal/executor"
 	"ecs-plugin-dev/internal/metrics"
+	"ecs-plugin-dev/internal/util"
 )
 
+// defaultMaxCanaryStages bounds the number of stages a canary may run, since
+// each stage adds a full soak period to the deployment
+const defaultMaxCanaryStages = 20
+
 type CanaryStrategy struct {
 	executor *executor.Executor
+	analyzer *CanaryAnalyzer
 }
 
 func NewCanaryStrategy(exec *executor.Executor) Strategy {
 	return &CanaryStrategy{executor: exec}
 }
 
+// NewCanaryStrategyWithAnalysis creates a canary strategy that also compares
+// each stage's canary and primary target group metrics before moving on
+func NewCanaryStrategyWithAnalysis(exec *executor.Executor, analyzer *CanaryAnalyzer) Strategy {
+	return &CanaryStrategy{executor: exec, analyzer: analyzer}
+}
+
+func (s *CanaryStrategy) Describe() Description {
+	return Description{
+		Summary: "Staged rollout on new task sets with soak time, health checks and alarm rollback",
+		ConfigKeys: configKeys(
+			[]string{"canary_stages", "canary_step", "canary_initial", "canary_percent", "canary_max_stages", "stage_timeout", "canary_header", "canary_rule_priority"},
+			rollbackKeys, analysisKeys, taskSetScaleKeys, taskSetNetworkKeys, trafficRoutingKeys),
+	}
+}
+
 func (s *CanaryStrategy) Execute(ctx context.Context, dctx *DeploymentContext) error {
 	// Parse canary configuration
-	stages := parseCanaryStages(dctx.Config)
+	stages, err := parseCanaryStages(dctx.Config)
+	if err != nil {
+		return fmt.Errorf("invalid canary config: %w", err)
+	}
 	stageTimeout := parseStageTimeout(dctx.Config)
 	enableRollback := parseRollbackEnabled(dctx.Config)
 
-	log.Printf("[CANARY] Starting multi-stage deployment with stages: %v (rollback: %v)", stages, enableRollback)
+	if err := validateStageCount(stages, parseMaxStages(dctx.Config)); err != nil {
+		return err
+	}
+
+	headerRule, err := parseCanaryHeaderRule(dctx.Config)
+	if err != nil {
+		return fmt.Errorf("invalid canary config: %w", err)
+	}
+
+	alarms := parseRollbackAlarms(dctx.Config)
+	alarmInterval := parseAlarmPollInterval(dctx.Config)
+	if len(alarms) > 0 {
+		if err := s.executor.ValidateAlarms(ctx, alarms); err != nil {
+			return fmt.Errorf("invalid canary config: %w", err)
+		}
+		util.Logf(ctx, "[CANARY] Watching rollback alarms %v every %v", alarms, alarmInterval)
+	}
+
+	util.Logf(ctx, "[CANARY] Starting multi-stage deployment with stages: %v (rollback: %v)", stages, enableRollback)
+
+	if err := validateTaskDefinition(s.executor, dctx); err != nil {
+		return err
+	}
 
-	// Save previous task definition for rollback
-	if err := s.executor.RollbackService(ctx, dctx.ClusterARN, dctx.ServiceName); err != nil {
-		log.Printf("[CANARY] Warning: Could not fetch previous task definition: %v", err)
+	// Snapshot the service for rollback before changing anything
+	if err := captureServiceState(ctx, s.executor, dctx); err != nil {
+		util.Logf(ctx, "[CANARY] Warning: Could not snapshot service state: %v", err)
 	}
 
-	if err := s.executor.RegisterTaskDefinition(ctx, dctx.TaskDefinition); err != nil {
+	arn, err := s.executor.RegisterTaskDefinitionARN(ctx, dctx.TaskDefinition)
+	if err != nil {
 		return err
 	}
+	dctx.Recorder.SetTaskDefinitionARN(arn)
+	dctx.ReportProgress(5, "task definition registered")
+
+	// Remember the current primary so it can be removed after promotion
+	primaryID, err := s.executor.PrimaryTaskSetID(ctx, dctx.ClusterARN, dctx.ServiceName)
+	if err != nil {
+		util.Logf(ctx, "[CANARY] Warning: Could not find primary task set: %v", err)
+	}
+	dctx.PreviousTaskSetID = primaryID
+
+	network, err := s.executor.ResolveTaskSetNetwork(ctx, dctx.ClusterARN, dctx.ServiceName, dctx.TaskDefinition, dctx.Config)
+	if err != nil {
+		return fmt.Errorf("invalid canary config: %w", err)
+	}
+
+	// Let requests carrying the canary header reach the new version at every
+	// stage, whatever its traffic weight
+	if headerRule != nil {
+		ruleArn, err := s.createHeaderRule(ctx, dctx, headerRule)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			// Dry runs create no rule
+			if ruleArn == "" {
+				return
+			}
+			// Remove the rule even when the deployment was canceled
+			if err := s.executor.DeleteListenerRule(context.WithoutCancel(ctx), ruleArn); err != nil {
+				util.Logf(ctx, "[CANARY] Warning: Could not delete header rule %s: %v", ruleArn, err)
+			}
+		}()
+	}
 
 	// Execute each canary stage
 	for i, percent := range stages {
 		stage := fmt.Sprintf("%d%%", percent)
-		log.Printf("[CANARY] Stage %d/%d: %s", i+1, len(stages), stage)
+		ctx := dctx.Recorder.StartStage(ctx, stage)
+		util.Logf(ctx, "[CANARY] Stage %d/%d: %s", i+1, len(stages), stage)
+
+		scale, err := s.executor.ResolveTaskSetScale(ctx, dctx.ClusterARN, dctx.ServiceName, dctx.Config, percent)
+		if err != nil {
+			dctx.Recorder.RecordStage(stage, err)
+			return fmt.Errorf("stage %s: %w", stage, err)
+		}
 
-		if err := s.executor.CreateTaskSet(ctx, dctx.ClusterARN, dctx.ServiceName, dctx.TaskDefinition, percent); err != nil {
+		taskSetID, err := s.executor.CreateTaskSetWithScale(ctx, dctx.ClusterARN, dctx.ServiceName, dctx.TaskDefinition, scale, network)
+		if err != nil {
 			metrics.CanaryStagesTotal.WithLabelValues(stage, "failed").Inc()
+			dctx.Recorder.RecordStage(stage, err)
 			if enableRollback {
-				log.Printf("[CANARY] Stage %s failed, initiating rollback", stage)
+				util.Logf(ctx, "[CANARY] Stage %s failed, initiating rollback", stage)
 				s.rollback(ctx, dctx)
 			}
 			return fmt.Errorf("stage %s failed: %w", stage, err)
 		}
+		dctx.TaskSetIDs = append(dctx.TaskSetIDs, taskSetID)
 
-		// Wait for stage stabilization
-		log.Printf("[CANARY] Waiting %v for stage %s to stabilize", stageTimeout, stage)
-		select {
-		case <-time.After(stageTimeout):
-			// Validate stage health
-			if err := s.validateStageHealth(ctx, dctx, percent); err != nil {
-				metrics.CanaryStagesTotal.WithLabelValues(stage, "failed").Inc()
-				if enableRollback {
-					log.Printf("[CANARY] Stage %s health check failed: %v, initiating rollback", stage, err)
-					s.rollback(ctx, dctx)
-				}
-				return fmt.Errorf("stage %s health check failed: %w", stage, err)
-			}
-			metrics.CanaryStagesTotal.WithLabelValues(stage, "success").Inc()
-			log.Printf("[CANARY] Stage %s completed successfully", stage)
-		case <-ctx.Done():
+		// Wait for stage stabilization, watching rollback alarms throughout
+		util.Logf(ctx, "[CANARY] Waiting %v for stage %s to stabilize", stageTimeout, stage)
+		err = soakWithAlarms(ctx, s.executor, dctx, stageTimeout, alarms, alarmInterval)
+		if ctx.Err() != nil {
+			dctx.Recorder.RecordStage(stage, ctx.Err())
 			if enableRollback {
-				log.Println("[CANARY] Context canceled, initiating rollback")
+				util.Logf(ctx, "[CANARY] Context canceled, initiating rollback")
 				s.rollback(ctx, dctx)
 			}
 			return ctx.Err()
 		}
+
+		// Validate stage health
+		if err == nil {
+			err = s.validateStageHealth(ctx, dctx, percent, alarms, stageTimeout)
+		}
+		if err != nil {
+			metrics.CanaryStagesTotal.WithLabelValues(stage, "failed").Inc()
+			dctx.Recorder.RecordStage(stage, err)
+			if enableRollback {
+				util.Logf(ctx, "[CANARY] Stage %s health check failed: %v, initiating rollback", stage, err)
+				s.rollback(ctx, dctx)
+			}
+			return fmt.Errorf("stage %s health check failed: %w", stage, err)
+		}
+		metrics.CanaryStagesTotal.WithLabelValues(stage, "success").Inc()
+		dctx.Recorder.RecordStage(stage, nil)
+		progress := 5 + int32(85*(i+1)/len(stages))
+		dctx.ReportProgress(progress, fmt.Sprintf("canary stage %d/%d (%s) complete", i+1, len(stages), stage))
+		util.Logf(ctx, "[CANARY] Stage %s completed successfully", stage)
+
+		// Hold here for manual verification if the deployment was paused
+		if err := dctx.WaitIfPaused(ctx, progress, fmt.Sprintf("paused at canary stage %d/%d (%s)", i+1, len(stages), stage)); err != nil {
+			if enableRollback {
+				util.Logf(ctx, "[CANARY] Context canceled while paused, initiating rollback")
+				s.rollback(ctx, dctx)
+			}
+			return err
+		}
 	}
 
 	// Final traffic shift to 100%
-	log.Println("[CANARY] Shifting all traffic to new version")
-	if err := s.executor.UpdateTraffic(ctx, dctx.ClusterARN, dctx.ServiceName, 0, 100); err != nil {
-		metrics.TrafficShiftsTotal.WithLabelValues("canary", "failed").Inc()
+	util.Logf(ctx, "[CANARY] Shifting all traffic to new version")
+	err = shiftTraffic(ctx, s.executor, dctx, "canary", 100, 0)
+	if err != nil {
 		if enableRollback {
-			log.Println("[CANARY] Traffic shift failed, initiating rollback")
+			util.Logf(ctx, "[CANARY] Traffic shift failed, initiating rollback")
 			s.rollback(ctx, dctx)
 		}
 		return err
 	}
-	metrics.TrafficShiftsTotal.WithLabelValues("canary", "success").Inc()
+	dctx.ReportProgress(95, "all traffic shifted to new version")
 
-	// Cleanup old task set
-	if err := s.executor.DeleteTaskSet(ctx, dctx.ClusterARN, dctx.ServiceName, "PRIMARY"); err != nil {
+	// Promote the final stage's task set before anything it replaces goes away
+	finalID := dctx.TaskSetIDs[len(dctx.TaskSetIDs)-1]
+	if err := s.executor.PromoteTaskSet(ctx, dctx.ClusterARN, dctx.ServiceName, finalID); err != nil {
+		return fmt.Errorf("failed to promote task set %s: %w", finalID, err)
+	}
+
+	// Cleanup the earlier stages' task sets and the old primary
+	for _, taskSetID := range dctx.TaskSetIDs[:len(dctx.TaskSetIDs)-1] {
+		if err := s.executor.DeleteTaskSet(ctx, dctx.ClusterARN, dctx.ServiceName, taskSetID); err != nil {
+			return fmt.Errorf("cleanup failed: %w", err)
+		}
+	}
+	if dctx.PreviousTaskSetID == "" {
+		util.Logf(ctx, "[CANARY] No previous primary task set to remove")
+	} else if err := s.executor.DeleteTaskSet(ctx, dctx.ClusterARN, dctx.ServiceName, dctx.PreviousTaskSetID); err != nil {
 		return fmt.Errorf("cleanup failed: %w", err)
 	}
 
-	log.Println("[CANARY] Deployment completed successfully")
+	util.Logf(ctx, "[CANARY] Deployment completed successfully")
+	return nil
+}
+
+// PlanStages estimates each stage as its soak period
+func (s *CanaryStrategy) PlanStages(config map[string]string) []time.Duration {
+	stages, err := parseCanaryStages(config)
+	if err != nil {
+		return nil
+	}
+	stageTimeout := parseStageTimeout(config)
+	plan := make([]time.Duration, len(stages))
+	for i := range plan {
+		plan[i] = stageTimeout
+	}
+	return plan
+}
+
+// soakWithAlarms holds a stage for its soak period, aborting early if any
+// rollback alarm enters ALARM state
+func soakWithAlarms(ctx context.Context, exec *executor.Executor, dctx *DeploymentContext, duration time.Duration, alarms []string, interval time.Duration) error {
+	if dctx.DryRun {
+		executor.RecordDryRunStep(ctx, "Wait %v for stage to soak", duration)
+		return nil
+	}
+
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	var tick <-chan time.Time
+	if len(alarms) > 0 {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-timer.C:
+			return nil
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-tick:
+			if err := checkAlarms(ctx, exec, alarms); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// checkAlarms returns an error if any rollback alarm is firing. Failures to
+// read alarm state are logged and retried on the next poll.
+func checkAlarms(ctx context.Context, exec *executor.Executor, alarms []string) error {
+	if len(alarms) == 0 {
+		return nil
+	}
+
+	firing, err := exec.FiringAlarms(ctx, alarms)
+	if err != nil {
+		util.Logf(ctx, "[ALARMS] Warning: could not read alarm state: %v", err)
+		return nil
+	}
+	if len(firing) > 0 {
+		return fmt.Errorf("rollback alarms in ALARM state: %s", strings.Join(firing, ", "))
+	}
 	return nil
 }
 
-// validateStageHealth checks service health at current canary stage
-func (s *CanaryStrategy) validateStageHealth(ctx context.Context, dctx *DeploymentContext, percent int) error {
-	log.Printf("[CANARY] Validating health for stage %d%%", percent)
+// validateStageHealth checks service health at current canary stage, and
+// canary metrics against the baseline over the stage's soak window
+func (s *CanaryStrategy) validateStageHealth(ctx context.Context, dctx *DeploymentContext, percent int, alarms []string, window time.Duration) error {
+	util.Logf(ctx, "[CANARY] Validating health for stage %d%%", percent)
 
 	// Wait for service to stabilize at this stage
 	stabilizeCtx, cancel := context.WithTimeout(ctx, 2*time.Minute)
@@ -109,30 +293,44 @@ func (s *CanaryStrategy) validateStageHealth(ctx context.Context, dctx *Deployme
 		return fmt.Errorf("service did not stabilize: %w", err)
 	}
 
-	log.Printf("[CANARY] Health check passed for stage %d%%", percent)
+	if err := checkAlarms(ctx, s.executor, alarms); err != nil {
+		return err
+	}
+
+	if s.analyzer != nil {
+		if err := s.analyzer.Analyze(ctx, dctx, window); err != nil {
+			return err
+		}
+	}
+
+	util.Logf(ctx, "[CANARY] Health check passed for stage %d%%", percent)
 	return nil
 }
 
 // rollback reverts to previous task definition
 func (s *CanaryStrategy) rollback(ctx context.Context, dctx *DeploymentContext) {
-	log.Println("[CANARY ROLLBACK] Starting automatic rollback")
+	util.Logf(ctx, "[CANARY ROLLBACK] Starting automatic rollback")
 
 	// Shift traffic back to 100% primary
-	if err := s.executor.UpdateTraffic(ctx, dctx.ClusterARN, dctx.ServiceName, 0, 100); err != nil {
-		log.Printf("[CANARY ROLLBACK] Failed to shift traffic back: %v", err)
+	err := restoreTraffic(ctx, s.executor, dctx, "canary")
+	if err != nil {
+		util.Logf(ctx, "[CANARY ROLLBACK] Failed to shift traffic back: %v", err)
 	}
 
-	// Delete canary task set
-	if err := s.executor.DeleteTaskSet(ctx, dctx.ClusterARN, dctx.ServiceName, "CANARY"); err != nil {
-		log.Printf("[CANARY ROLLBACK] Failed to delete canary task set: %v", err)
+	// Delete the canary task sets created so far
+	for _, taskSetID := range dctx.TaskSetIDs {
+		if err := s.executor.DeleteTaskSet(ctx, dctx.ClusterARN, dctx.ServiceName, taskSetID); err != nil {
+			util.Logf(ctx, "[CANARY ROLLBACK] Failed to delete canary task set %s: %v", taskSetID, err)
+		}
 	}
 
-	log.Println("[CANARY ROLLBACK] Rollback completed")
+	util.Logf(ctx, "[CANARY ROLLBACK] Rollback completed")
 	metrics.RecordError("strategy", "canary_rollback")
 }
 
-// parseCanaryStages extracts canary stages from config
-func parseCanaryStages(config map[string]string) []int {
+// parseCanaryStages extracts canary stages from config. An explicit
+// canary_stages list wins; otherwise canary_step generates a linear schedule.
+func parseCanaryStages(config map[string]string) ([]int, error) {
 	if stagesStr, ok := config["canary_stages"]; ok {
 		parts := strings.Split(stagesStr, ",")
 		stages := make([]int, 0, len(parts))
@@ -142,19 +340,152 @@ func parseCanaryStages(config map[string]string) []int {
 			}
 		}
 		if len(stages) > 0 {
-			return stages
+			return stages, validateStageOrder(stages)
 		}
 	}
 
+	if _, ok := config["canary_step"]; ok {
+		return linearCanaryStages(config)
+	}
+
 	// Fallback to single stage
 	if percentStr, ok := config["canary_percent"]; ok {
 		if percent, err := strconv.Atoi(percentStr); err == nil {
-			return []int{percent, 100}
+			stages := []int{percent, 100}
+			return stages, validateStageOrder(stages)
 		}
 	}
 
 	// Default multi-stage canary
-	return []int{20, 50, 100}
+	return []int{20, 50, 100}, nil
+}
+
+// linearCanaryStages generates canary_initial, canary_initial+canary_step, ...
+// up to 100. canary_initial defaults to the step, and the step must land
+// exactly on 100 so the final stage is always full traffic.
+func linearCanaryStages(config map[string]string) ([]int, error) {
+	step, err := strconv.Atoi(config["canary_step"])
+	if err != nil || step <= 0 || step > 100 {
+		return nil, fmt.Errorf("canary_step %q must be an integer between 1 and 100", config["canary_step"])
+	}
+
+	initial := step
+	if initialStr, ok := config["canary_initial"]; ok {
+		initial, err = strconv.Atoi(initialStr)
+		if err != nil || initial <= 0 || initial > 100 {
+			return nil, fmt.Errorf("canary_initial %q must be an integer between 1 and 100", initialStr)
+		}
+	}
+
+	if (100-initial)%step != 0 {
+		return nil, fmt.Errorf("canary_step %d does not evenly divide the range from canary_initial %d to 100", step, initial)
+	}
+
+	stages := make([]int, 0, (100-initial)/step+1)
+	for percent := initial; percent <= 100; percent += step {
+		stages = append(stages, percent)
+	}
+	return stages, nil
+}
+
+// validateStageOrder rejects stage lists that are not strictly increasing
+// percentages between 1 and 100
+func validateStageOrder(stages []int) error {
+	for i, percent := range stages {
+		if percent <= 0 || percent > 100 {
+			return fmt.Errorf("stage %d%% is outside 1-100", percent)
+		}
+		if i > 0 && percent <= stages[i-1] {
+			return fmt.Errorf("stages must be strictly increasing, got %d%% after %d%%", percent, stages[i-1])
+		}
+	}
+	return nil
+}
+
+// canaryHeaderRule routes requests carrying a header to the canary
+type canaryHeaderRule struct {
+	header   string
+	value    string
+	priority int
+}
+
+// parseCanaryHeaderRule extracts the canary_header ("Name=value") whose
+// requests are sent to the canary, and the canary_rule_priority of the
+// listener rule doing so. It returns nil when canary_header is unset.
+func parseCanaryHeaderRule(config map[string]string) (*canaryHeaderRule, error) {
+	header, ok := config["canary_header"]
+	if !ok {
+		return nil, nil
+	}
+	name, value, found := strings.Cut(header, "=")
+	name, value = strings.TrimSpace(name), strings.TrimSpace(value)
+	if !found || name == "" || value == "" {
+		return nil, fmt.Errorf("invalid canary_header %q: must be Name=value", header)
+	}
+
+	rule := &canaryHeaderRule{header: name, value: value}
+	if v, ok := config["canary_rule_priority"]; ok {
+		priority, err := strconv.Atoi(v)
+		if err != nil || priority < 1 {
+			return nil, fmt.Errorf("invalid canary_rule_priority %q: must be a positive integer", v)
+		}
+		rule.priority = priority
+	}
+	return rule, nil
+}
+
+// createHeaderRule adds rule to the deployment's listener, returning its ARN
+func (s *CanaryStrategy) createHeaderRule(ctx context.Context, dctx *DeploymentContext, rule *canaryHeaderRule) (string, error) {
+	routing, err := parseTrafficRouting(dctx.Config)
+	if err != nil {
+		return "", fmt.Errorf("invalid canary config: %w", err)
+	}
+	ruleArn, err := s.executor.CreateCanaryHeaderRule(ctx, dctx.ClusterARN, dctx.ServiceName, routing, rule.header, rule.value, rule.priority)
+	if err != nil {
+		return "", fmt.Errorf("failed to create canary header rule: %w", err)
+	}
+	util.Logf(ctx, "[CANARY] Requests with %s: %s go to the new version (rule %s)", rule.header, rule.value, ruleArn)
+	return ruleArn, nil
+}
+
+// parseMaxStages extracts the maximum canary stage count from config
+func parseMaxStages(config map[string]string) int {
+	if maxStr, ok := config["canary_max_stages"]; ok {
+		if limit, err := strconv.Atoi(maxStr); err == nil && limit > 0 {
+			return limit
+		}
+	}
+	return defaultMaxCanaryStages
+}
+
+// validateStageCount rejects stage lists longer than maxStages
+func validateStageCount(stages []int, maxStages int) error {
+	if len(stages) > maxStages {
+		return fmt.Errorf("canary config produces %d stages, exceeding the maximum of %d (raise canary_max_stages or use fewer stages)",
+			len(stages), maxStages)
+	}
+	return nil
+}
+
+// parseRollbackAlarms extracts CloudWatch alarm names that trigger rollback
+func parseRollbackAlarms(config map[string]string) []string {
+	var alarms []string
+	for _, name := range strings.Split(config["rollback_alarms"], ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			alarms = append(alarms, name)
+		}
+	}
+	return alarms
+}
+
+// parseAlarmPollInterval extracts how often rollback alarms are polled
+func parseAlarmPollInterval(config map[string]string) time.Duration {
+	if intervalStr, ok := config["alarm_poll_interval"]; ok {
+		if duration, err := time.ParseDuration(intervalStr); err == nil && duration > 0 {
+			return duration
+		}
+	}
+	return 30 * time.Second
 }
 
 // parseStageTimeout extracts stage timeout from config
@@ -0,0 +1,123 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"ecs-plugin-dev/internal/metrics"
+)
+
+func TestCanaryStageCap(t *testing.T) {
+	tests := []struct {
+		name       string
+		config     map[string]string
+		wantStages int
+		wantErr    bool
+	}{
+		{name: "step within the default cap", config: map[string]string{"canary_step": "5"}, wantStages: 20},
+		{name: "step one stage over a lowered cap", config: map[string]string{"canary_step": "5", "canary_max_stages": "19"}, wantErr: true},
+		{name: "step over the default cap", config: map[string]string{"canary_step": "4"}, wantErr: true},
+		{name: "step within a raised cap", config: map[string]string{"canary_step": "4", "canary_max_stages": "25"}, wantStages: 25},
+		{name: "step over a lowered cap", config: map[string]string{"canary_step": "25", "canary_max_stages": "3"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stages, err := parseCanaryStages(tt.config)
+			if err == nil {
+				err = validateStageCount(stages, parseMaxStages(tt.config))
+			}
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected %d stages to be rejected", len(stages))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(stages) != tt.wantStages {
+				t.Errorf("expected %d stages, got %d", tt.wantStages, len(stages))
+			}
+		})
+	}
+}
+
+func TestParseCanaryStagesValidatesEveryForm(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  map[string]string
+		want    []int
+		wantErr string
+	}{
+		{name: "canary_percent", config: map[string]string{"canary_percent": "30"}, want: []int{30, 100}},
+		{name: "canary_percent over 100", config: map[string]string{"canary_percent": "120"}, wantErr: "stage 120% is outside 1-100"},
+		{name: "canary_percent of 100", config: map[string]string{"canary_percent": "100"}, wantErr: "strictly increasing"},
+		{name: "negative canary_percent", config: map[string]string{"canary_percent": "-10"}, wantErr: "stage -10% is outside 1-100"},
+		{name: "stage list over 100", config: map[string]string{"canary_stages": "50,120"}, wantErr: "stage 120% is outside 1-100"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stages, err := parseCanaryStages(tt.config)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected an error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if fmt.Sprint(stages) != fmt.Sprint(tt.want) {
+				t.Errorf("expected stages %v, got %v", tt.want, stages)
+			}
+		})
+	}
+}
+
+func TestCanaryStageCapRejectedBeforeAWS(t *testing.T) {
+	dctx := newTestContext(map[string]string{"canary_step": "1"})
+
+	err := NewCanaryStrategy(newMockExecutor(t, "")).Execute(context.Background(), dctx)
+	if err == nil || !strings.Contains(err.Error(), "exceeding the maximum of 20") {
+		t.Fatalf("expected the 100-stage canary to be rejected, got %v", err)
+	}
+	if arn := dctx.Recorder.TaskDefinitionARN(); arn != "" {
+		t.Errorf("expected nothing registered, got %s", arn)
+	}
+}
+
+func TestCanaryWithoutPrimaryTaskSet(t *testing.T) {
+	scenario := filepath.Join(t.TempDir(), "scenario.json")
+	if err := os.WriteFile(scenario, []byte(`{"primary_task_set": ""}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("MOCK_SCENARIO_FILE", scenario)
+
+	dctx := newTestContext(map[string]string{"canary_stages": "50,100", "stage_timeout": "1ms"})
+	dctx.DeploymentID = "canary-no-primary"
+	ctx := metrics.WithDeploymentID(context.Background(), dctx.DeploymentID)
+	if err := NewCanaryStrategy(newMockExecutor(t, "")).Execute(ctx, dctx); err != nil {
+		t.Fatalf("expected a service without a primary task set to deploy, got %v", err)
+	}
+	if dctx.PreviousTaskSetID != "" || len(dctx.TaskSetIDs) != 2 {
+		t.Fatalf("expected two stage task sets and no previous primary, got %q and %v", dctx.PreviousTaskSetID, dctx.TaskSetIDs)
+	}
+
+	// The final task set is promoted, then only the 50% stage's is removed
+	calls, _ := metrics.GetGlobalAPICallLog().Calls(dctx.DeploymentID)
+	var ops []string
+	for _, call := range calls {
+		if call.Operation == "UpdateServicePrimaryTaskSet" || call.Operation == "DeleteTaskSet" {
+			ops = append(ops, call.Operation)
+		}
+	}
+	if strings.Join(ops, ",") != "UpdateServicePrimaryTaskSet,DeleteTaskSet" {
+		t.Errorf("expected a promotion followed by one delete, got %v", ops)
+	}
+}
@@ -0,0 +1,39 @@
+package strategy
+
+// Description summarizes a strategy for clients choosing one
+type Description struct {
+	Summary    string
+	ConfigKeys []string // Deployment config keys the strategy reads
+}
+
+// Describer is implemented by strategies that can describe themselves
+type Describer interface {
+	Describe() Description
+}
+
+// Config keys shared by several strategies
+var (
+	deploymentOptionKeys = []string{"circuit_breaker", "minimum_healthy_percent", "maximum_percent"}
+	trafficRoutingKeys   = []string{"listener_arn", "target_group_tag", "min_healthy_targets_percent", "lenient_target_health"}
+	taskSetScaleKeys     = []string{"task_set_scale_unit", "task_set_count"}
+	taskSetNetworkKeys   = []string{"subnets", "security_groups", "assign_public_ip"}
+	rollbackKeys         = []string{"enable_rollback", "rollback_alarms", "alarm_poll_interval"}
+)
+
+// Describe returns s's description, or an empty one if it has none
+func Describe(s Strategy) Description {
+	if d, ok := s.(Describer); ok {
+		return d.Describe()
+	}
+	return Description{}
+}
+
+// configKeys joins strategy-specific keys with shared key groups. Every
+// built-in strategy honours strict_taskdef, so it is always included.
+func configKeys(keys []string, groups ...[]string) []string {
+	all := append([]string{}, keys...)
+	for _, group := range groups {
+		all = append(all, group...)
+	}
+	return append(all, "strict_taskdef")
+}
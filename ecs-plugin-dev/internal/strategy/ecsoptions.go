@@ -0,0 +1,62 @@
+package strategy
+
+import (
+	"fmt"
+	"strconv"
+
+	"ecs-plugin-dev/internal/aws"
+)
+
+// parseDeploymentOptions extracts the ECS-native deployment settings from
+// config: circuit_breaker, minimum_healthy_percent and maximum_percent
+func parseDeploymentOptions(config map[string]string) (aws.DeploymentOptions, error) {
+	var opts aws.DeploymentOptions
+
+	if v, ok := config["circuit_breaker"]; ok {
+		opts.CircuitBreaker = v == "true" || v == "1"
+	}
+
+	if v, ok := config["minimum_healthy_percent"]; ok {
+		percent, err := strconv.Atoi(v)
+		if err != nil || percent < 0 || percent > 100 {
+			return opts, fmt.Errorf("invalid minimum_healthy_percent %q: must be between 0 and 100", v)
+		}
+		opts.MinimumHealthyPercent = int32(percent)
+	}
+
+	if v, ok := config["maximum_percent"]; ok {
+		percent, err := strconv.Atoi(v)
+		if err != nil || percent < 100 || percent > 200 {
+			return opts, fmt.Errorf("invalid maximum_percent %q: must be between 100 and 200", v)
+		}
+		opts.MaximumPercent = int32(percent)
+	}
+
+	return opts, nil
+}
+
+// parseTrafficRouting extracts the listener_arn and target_group_tag used to
+// select where traffic shifts apply, and the min_healthy_targets_percent
+// target groups must meet before receiving traffic unless
+// lenient_target_health is set
+func parseTrafficRouting(config map[string]string) (aws.TrafficRouting, error) {
+	routing := aws.TrafficRouting{
+		ListenerARN:    config["listener_arn"],
+		TargetGroupTag: config["target_group_tag"],
+	}
+	if v, ok := config["min_healthy_targets_percent"]; ok {
+		percent, err := strconv.Atoi(v)
+		if err != nil || percent < 0 || percent > 100 {
+			return routing, fmt.Errorf("invalid min_healthy_targets_percent %q: must be between 0 and 100", v)
+		}
+		routing.MinHealthyPercent = percent
+	}
+	if v, ok := config["lenient_target_health"]; ok {
+		lenient, err := strconv.ParseBool(v)
+		if err != nil {
+			return routing, fmt.Errorf("invalid lenient_target_health %q: must be true or false", v)
+		}
+		routing.LenientHealth = lenient
+	}
+	return routing, nil
+}
@@ -0,0 +1,75 @@
+package strategy
+
+import (
+	"context"
+	"sync"
+)
+
+// PauseGate holds a deployment between stages while an operator verifies it
+type PauseGate struct {
+	mu     sync.Mutex
+	resume chan struct{} // Non-nil while paused; closed on resume
+}
+
+func NewPauseGate() *PauseGate {
+	return &PauseGate{}
+}
+
+// Pause holds the deployment at its next stage boundary. It returns false if
+// the deployment is already paused.
+func (g *PauseGate) Pause() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.resume != nil {
+		return false
+	}
+	g.resume = make(chan struct{})
+	return true
+}
+
+// Resume releases a paused deployment. It returns false if it was not paused.
+func (g *PauseGate) Resume() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.resume == nil {
+		return false
+	}
+	close(g.resume)
+	g.resume = nil
+	return true
+}
+
+// Paused reports whether the deployment is paused
+func (g *PauseGate) Paused() bool {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.resume != nil
+}
+
+// Wait blocks while the deployment is paused, until it is resumed or ctx is done
+func (g *PauseGate) Wait(ctx context.Context) error {
+	g.mu.Lock()
+	resume := g.resume
+	g.mu.Unlock()
+
+	if resume == nil {
+		return nil
+	}
+	select {
+	case <-resume:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// SupportsPause reports whether a strategy honours DeploymentContext.Pause
+func SupportsPause(s Strategy) bool {
+	switch s.(type) {
+	case *CanaryStrategy, *ProgressiveStrategy:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,235 @@
+package strategy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"ecs-plugin-dev/internal/executor"
+	"ecs-plugin-dev/internal/metrics"
+	"ecs-plugin-dev/internal/util"
+)
+
+// ProgressiveStrategy shifts traffic to the new version in steps like
+// rolling, but gates every step the way canary gates its stages: each step
+// bakes while rollback alarms are watched, then must pass a health check
+// before the next one starts.
+type ProgressiveStrategy struct {
+	executor *executor.Executor
+}
+
+func NewProgressiveStrategy(exec *executor.Executor) Strategy {
+	return &ProgressiveStrategy{executor: exec}
+}
+
+func (s *ProgressiveStrategy) Describe() Description {
+	return Description{
+		Summary: "Traffic steps that each bake and pass health and alarm checks before the next",
+		ConfigKeys: configKeys(
+			[]string{"progressive_steps", "batch_size", "bake_time", "canary_max_stages"},
+			rollbackKeys, deploymentOptionKeys, trafficRoutingKeys),
+	}
+}
+
+func (s *ProgressiveStrategy) Execute(ctx context.Context, dctx *DeploymentContext) error {
+	steps, err := parseProgressiveSteps(dctx.Config)
+	if err != nil {
+		return fmt.Errorf("invalid progressive config: %w", err)
+	}
+	if err := validateStageCount(steps, parseMaxStages(dctx.Config)); err != nil {
+		return err
+	}
+	bakeTime := parseBakeTime(dctx.Config)
+	enableRollback := parseRollbackEnabled(dctx.Config)
+	opts, err := parseDeploymentOptions(dctx.Config)
+	if err != nil {
+		return err
+	}
+
+	alarms := parseRollbackAlarms(dctx.Config)
+	alarmInterval := parseAlarmPollInterval(dctx.Config)
+	if len(alarms) > 0 {
+		if err := s.executor.ValidateAlarms(ctx, alarms); err != nil {
+			return fmt.Errorf("invalid progressive config: %w", err)
+		}
+		util.Logf(ctx, "[PROGRESSIVE] Watching rollback alarms %v every %v", alarms, alarmInterval)
+	}
+
+	util.Logf(ctx, "[PROGRESSIVE] Starting deployment with steps: %v, bake time: %v (rollback: %v)", steps, bakeTime, enableRollback)
+
+	// Snapshot the service for rollback before changing anything
+	if err := captureServiceState(ctx, s.executor, dctx); err != nil {
+		util.Logf(ctx, "[PROGRESSIVE] Warning: Could not snapshot service state: %v", err)
+	}
+
+	if err := validateTaskDefinition(s.executor, dctx); err != nil {
+		return err
+	}
+
+	arn, err := s.executor.RegisterTaskDefinitionARN(ctx, dctx.TaskDefinition)
+	if err != nil {
+		return fmt.Errorf("failed to register task definition: %w", err)
+	}
+	dctx.Recorder.SetTaskDefinitionARN(arn)
+	dctx.ReportProgress(5, "task definition registered")
+
+	abort := func(err error) error {
+		if enableRollback {
+			s.rollback(ctx, dctx)
+		}
+		return err
+	}
+
+	for i, percent := range steps {
+		step := fmt.Sprintf("step %d (%d%%)", i+1, percent)
+		ctx := dctx.Recorder.StartStage(ctx, step)
+		util.Logf(ctx, "[PROGRESSIVE] Step %d/%d: shifting %d%% of traffic to new version", i+1, len(steps), percent)
+
+		err := shiftTraffic(ctx, s.executor, dctx, "progressive", percent, 100-percent)
+		if err != nil {
+			dctx.Recorder.RecordStage(step, err)
+			util.Logf(ctx, "[PROGRESSIVE] Traffic shift failed at %s: %v", step, err)
+			return abort(fmt.Errorf("%s traffic shift failed: %w", step, err))
+		}
+
+		// Bake, watching rollback alarms throughout
+		util.Logf(ctx, "[PROGRESSIVE] Baking %s for %v", step, bakeTime)
+		err = soakWithAlarms(ctx, s.executor, dctx, bakeTime, alarms, alarmInterval)
+		if ctx.Err() != nil {
+			dctx.Recorder.RecordStage(step, ctx.Err())
+			util.Logf(ctx, "[PROGRESSIVE] Context canceled at %s", step)
+			return abort(ctx.Err())
+		}
+
+		if err == nil {
+			err = s.validateStepHealth(ctx, dctx, alarms)
+		}
+		if err != nil {
+			dctx.Recorder.RecordStage(step, err)
+			util.Logf(ctx, "[PROGRESSIVE] Step %d/%d health check failed: %v", i+1, len(steps), err)
+			return abort(fmt.Errorf("%s health check failed: %w", step, err))
+		}
+
+		dctx.Recorder.RecordStage(step, nil)
+		progress := 5 + int32(85*(i+1)/len(steps))
+		dctx.ReportProgress(progress, fmt.Sprintf("progressive step %d/%d (%d%%) complete", i+1, len(steps), percent))
+		util.Logf(ctx, "[PROGRESSIVE] Step %d/%d completed successfully", i+1, len(steps))
+
+		if err := dctx.WaitIfPaused(ctx, progress, fmt.Sprintf("paused at progressive step %d/%d (%d%%)", i+1, len(steps), percent)); err != nil {
+			util.Logf(ctx, "[PROGRESSIVE] Context canceled while paused")
+			return abort(err)
+		}
+	}
+
+	// All traffic is on the new version; make it the service's task definition
+	util.Logf(ctx, "[PROGRESSIVE] Finalizing deployment")
+	if err := s.executor.UpdateServiceWithOptions(ctx, dctx.ClusterARN, dctx.ServiceName, dctx.TaskDefinition, opts); err != nil {
+		return abort(fmt.Errorf("final update failed: %w", err))
+	}
+	dctx.ReportProgress(95, "service updated, waiting for stabilization")
+
+	if err := s.executor.WaitForServiceStable(ctx, dctx.ClusterARN, dctx.ServiceName, 5*time.Minute); err != nil {
+		if errors.Is(err, executor.ErrRolloutFailed) {
+			// With the circuit breaker enabled ECS has already rolled the service back
+			if enableRollback && !opts.CircuitBreaker {
+				s.rollback(ctx, dctx)
+			}
+			return err
+		}
+		util.Logf(ctx, "[PROGRESSIVE] Warning: Service did not stabilize: %v", err)
+	}
+
+	util.Logf(ctx, "[PROGRESSIVE] Deployment completed successfully")
+	return nil
+}
+
+// validateStepHealth waits for the service to stabilize at the current step
+// and confirms no rollback alarm is firing
+func (s *ProgressiveStrategy) validateStepHealth(ctx context.Context, dctx *DeploymentContext, alarms []string) error {
+	stabilizeCtx, cancel := context.WithTimeout(ctx, 2*time.Minute)
+	defer cancel()
+
+	if err := s.executor.WaitForServiceStable(stabilizeCtx, dctx.ClusterARN, dctx.ServiceName, 2*time.Minute); err != nil {
+		return fmt.Errorf("service did not stabilize: %w", err)
+	}
+	return checkAlarms(ctx, s.executor, alarms)
+}
+
+// rollback shifts all traffic back to the previous version and restores its
+// task definition
+func (s *ProgressiveStrategy) rollback(ctx context.Context, dctx *DeploymentContext) {
+	util.Logf(ctx, "[PROGRESSIVE ROLLBACK] Starting automatic rollback")
+
+	err := restoreTraffic(ctx, s.executor, dctx, "progressive")
+	if err != nil {
+		util.Logf(ctx, "[PROGRESSIVE ROLLBACK] Failed to shift traffic back: %v", err)
+	}
+
+	if snapshot := dctx.Snapshot; snapshot != nil && snapshot.TaskDefinition != "" {
+		if err := s.executor.RestoreServiceSnapshot(ctx, dctx.ClusterARN, dctx.ServiceName, snapshot); err != nil {
+			util.Logf(ctx, "[PROGRESSIVE ROLLBACK] Failed to restore %s: %v", snapshot.TaskDefinition, err)
+		}
+	} else {
+		util.Logf(ctx, "[PROGRESSIVE ROLLBACK] No previous task definition available")
+	}
+
+	util.Logf(ctx, "[PROGRESSIVE ROLLBACK] Rollback completed")
+	metrics.RecordError("strategy", "progressive_rollback")
+}
+
+// PlanStages estimates each step as its bake time
+func (s *ProgressiveStrategy) PlanStages(config map[string]string) []time.Duration {
+	steps, err := parseProgressiveSteps(config)
+	if err != nil {
+		return nil
+	}
+	bakeTime := parseBakeTime(config)
+	plan := make([]time.Duration, len(steps))
+	for i := range plan {
+		plan[i] = bakeTime
+	}
+	return plan
+}
+
+// parseProgressiveSteps extracts the traffic percentages to step through. An
+// explicit progressive_steps list wins; otherwise batch_size splits traffic
+// into equal steps as rolling does. The last step is always 100%.
+func parseProgressiveSteps(config map[string]string) ([]int, error) {
+	var steps []int
+	if stepsStr, ok := config["progressive_steps"]; ok {
+		for _, part := range strings.Split(stepsStr, ",") {
+			percent, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil {
+				return nil, fmt.Errorf("progressive_steps %q must be comma-separated percentages", stepsStr)
+			}
+			steps = append(steps, percent)
+		}
+		if err := validateStageOrder(steps); err != nil {
+			return nil, err
+		}
+	} else {
+		batchSize := parseBatchSize(config)
+		for percent := batchSize; percent < 100; percent += batchSize {
+			steps = append(steps, percent)
+		}
+	}
+
+	if len(steps) == 0 || steps[len(steps)-1] != 100 {
+		steps = append(steps, 100)
+	}
+	return steps, nil
+}
+
+// parseBakeTime extracts how long each progressive step runs before its
+// health check
+func parseBakeTime(config map[string]string) time.Duration {
+	if bakeStr, ok := config["bake_time"]; ok {
+		if duration, err := time.ParseDuration(bakeStr); err == nil && duration >= 0 {
+			return duration
+		}
+	}
+	return 2 * time.Minute
+}
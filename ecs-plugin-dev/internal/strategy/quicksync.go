@@ -2,21 +2,51 @@
 package strategy
 
 import (
-    "context"
-    "ecs-plugin-dev/internal/executor"
+	"context"
+
+	"ecs-plugin-dev/internal/executor"
+	"ecs-plugin-dev/internal/util"
 )
 
 type QuickSyncStrategy struct {
-    executor *executor.Executor
+	executor *executor.Executor
 }
 
 func NewQuickSyncStrategy(exec *executor.Executor) Strategy {
-    return &QuickSyncStrategy{executor: exec}
+	return &QuickSyncStrategy{executor: exec}
+}
+
+func (s *QuickSyncStrategy) Describe() Description {
+	return Description{
+		Summary:    "Immediate service update; ECS replaces tasks itself",
+		ConfigKeys: configKeys(nil, deploymentOptionKeys),
+	}
 }
 
 func (s *QuickSyncStrategy) Execute(ctx context.Context, dctx *DeploymentContext) error {
-    if err := s.executor.RegisterTaskDefinition(ctx, dctx.TaskDefinition); err != nil {
-        return err
-    }
-    return s.executor.UpdateService(ctx, dctx.ClusterARN, dctx.ServiceName, dctx.TaskDefinition)
-}
\ No newline at end of file
+	opts, err := parseDeploymentOptions(dctx.Config)
+	if err != nil {
+		return err
+	}
+
+	if err := validateTaskDefinition(s.executor, dctx); err != nil {
+		return err
+	}
+
+	// Snapshot the service so a later Rollback can restore it
+	if err := captureServiceState(ctx, s.executor, dctx); err != nil {
+		util.Logf(ctx, "[QUICKSYNC] Warning: Could not snapshot service state: %v", err)
+	}
+
+	arn, err := s.executor.RegisterTaskDefinitionARN(ctx, dctx.TaskDefinition)
+	if err != nil {
+		return err
+	}
+	dctx.Recorder.SetTaskDefinitionARN(arn)
+	dctx.ReportProgress(50, "task definition registered")
+
+	stageCtx := dctx.Recorder.StartStage(ctx, "update-service")
+	err = s.executor.UpdateServiceWithOptions(stageCtx, dctx.ClusterARN, dctx.ServiceName, dctx.TaskDefinition, opts)
+	dctx.Recorder.RecordStage("update-service", err)
+	return err
+}
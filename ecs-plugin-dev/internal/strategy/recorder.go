@@ -0,0 +1,165 @@
+package strategy
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"ecs-plugin-dev/internal/executor"
+	"ecs-plugin-dev/internal/tracing"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// StageRecord captures the outcome of one strategy stage or batch
+type StageRecord struct {
+	Name      string    `json:"name"`
+	Status    string    `json:"status"`
+	Error     string    `json:"error,omitempty"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// TrafficShiftRecord captures a single traffic weight change
+type TrafficShiftRecord struct {
+	CanaryWeight  int       `json:"canary_weight"`
+	PrimaryWeight int       `json:"primary_weight"`
+	Status        string    `json:"status"`
+	Error         string    `json:"error,omitempty"`
+	Timestamp     time.Time `json:"timestamp"`
+}
+
+// DeploymentRecorder collects what a strategy actually did during a deployment.
+// All methods are safe to call on a nil recorder.
+type DeploymentRecorder struct {
+	mu                sync.Mutex
+	taskDefinitionARN string
+	snapshot          *executor.ServiceSnapshot
+	stages            []StageRecord
+	trafficShifts     []TrafficShiftRecord
+
+	// stageSpans holds the trace spans of stages started but not yet recorded
+	stageSpans map[string]trace.Span
+}
+
+func NewDeploymentRecorder() *DeploymentRecorder {
+	return &DeploymentRecorder{}
+}
+
+// SetTaskDefinitionARN records the task definition revision that was registered
+func (r *DeploymentRecorder) SetTaskDefinitionARN(arn string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.taskDefinitionARN = arn
+}
+
+// SetSnapshot records the service state captured before the deployment
+func (r *DeploymentRecorder) SetSnapshot(snapshot *executor.ServiceSnapshot) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.snapshot = snapshot
+}
+
+// StartStage opens a trace span for a stage, returning a context for the
+// stage's work; RecordStage ends the span
+func (r *DeploymentRecorder) StartStage(ctx context.Context, name string) context.Context {
+	if r == nil {
+		return ctx
+	}
+	ctx, span := tracing.Start(ctx, "stage "+name, attribute.String("deployment.stage", name))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.stageSpans == nil {
+		r.stageSpans = make(map[string]trace.Span)
+	}
+	r.stageSpans[name] = span
+	return ctx
+}
+
+// RecordStage records a stage outcome; err marks the stage failed
+func (r *DeploymentRecorder) RecordStage(name string, err error) {
+	if r == nil {
+		return
+	}
+	record := StageRecord{Name: name, Status: "success", Timestamp: time.Now()}
+	if err != nil {
+		record.Status = "failed"
+		record.Error = err.Error()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.stages = append(r.stages, record)
+	if span, ok := r.stageSpans[name]; ok {
+		delete(r.stageSpans, name)
+		tracing.End(span, err)
+	}
+}
+
+// RecordTrafficShift records a traffic weight change; err marks it failed
+func (r *DeploymentRecorder) RecordTrafficShift(canaryWeight, primaryWeight int, err error) {
+	if r == nil {
+		return
+	}
+	record := TrafficShiftRecord{
+		CanaryWeight:  canaryWeight,
+		PrimaryWeight: primaryWeight,
+		Status:        "success",
+		Timestamp:     time.Now(),
+	}
+	if err != nil {
+		record.Status = "failed"
+		record.Error = err.Error()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.trafficShifts = append(r.trafficShifts, record)
+}
+
+// TaskDefinitionARN returns the recorded task definition ARN
+func (r *DeploymentRecorder) TaskDefinitionARN() string {
+	if r == nil {
+		return ""
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.taskDefinitionARN
+}
+
+// Snapshot returns the recorded pre-deployment service state, if any
+func (r *DeploymentRecorder) Snapshot() *executor.ServiceSnapshot {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.snapshot
+}
+
+// Stages returns a copy of the recorded stages
+func (r *DeploymentRecorder) Stages() []StageRecord {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]StageRecord(nil), r.stages...)
+}
+
+// TrafficShifts returns a copy of the recorded traffic shifts
+func (r *DeploymentRecorder) TrafficShifts() []TrafficShiftRecord {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]TrafficShiftRecord(nil), r.trafficShifts...)
+}
@@ -2,56 +2,68 @@ package strategy
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log"
 	"strconv"
 	"time"
 
-	"ecs-plugin-dev/internal/aws"
 	"ecs-plugin-dev/internal/executor"
+	"ecs-plugin-dev/internal/util"
 )
 
 type RollingStrategy struct {
-	executor  *executor.Executor
-	ecsClient *aws.ECSClient
+	executor *executor.Executor
 }
 
 func NewRollingStrategy(exec *executor.Executor) Strategy {
-	return &RollingStrategy{
-		executor:  exec,
-		ecsClient: aws.NewECSClient(),
+	return &RollingStrategy{executor: exec}
+}
+
+func (s *RollingStrategy) Describe() Description {
+	return Description{
+		Summary:    "Traffic shifted in equal batches with a delay between them",
+		ConfigKeys: configKeys([]string{"batch_size", "batch_delay"}, deploymentOptionKeys, trafficRoutingKeys),
 	}
 }
 
 func (s *RollingStrategy) Execute(ctx context.Context, dctx *DeploymentContext) error {
-	log.Println("[ROLLING] Starting rolling deployment")
+	util.Logf(ctx, "[ROLLING] Starting rolling deployment")
 
 	// Parse configuration
-	batchSize := s.parseBatchSize(dctx.Config)
-	batchDelay := s.parseBatchDelay(dctx.Config)
+	batchSize := parseBatchSize(dctx.Config)
+	batchDelay := parseBatchDelay(dctx.Config)
+	opts, err := parseDeploymentOptions(dctx.Config)
+	if err != nil {
+		return err
+	}
 
-	log.Printf("[ROLLING] Batch size: %d%%, Delay: %v", batchSize, batchDelay)
+	util.Logf(ctx, "[ROLLING] Batch size: %d%%, Delay: %v", batchSize, batchDelay)
 
-	// Save previous task definition for rollback
-	prevTaskDef, err := s.ecsClient.GetPreviousTaskDefinition(ctx, dctx.ClusterARN, dctx.ServiceName)
-	if err != nil {
-		log.Printf("[ROLLING] Warning: Could not get previous task definition: %v", err)
+	// Snapshot the service for rollback before changing anything
+	if err := captureServiceState(ctx, s.executor, dctx); err != nil {
+		util.Logf(ctx, "[ROLLING] Warning: Could not snapshot service state: %v", err)
+	}
+
+	if err := validateTaskDefinition(s.executor, dctx); err != nil {
+		return err
 	}
-	dctx.Config["previous_taskdef"] = prevTaskDef
 
 	// Register new task definition
-	if err := s.executor.RegisterTaskDefinition(ctx, dctx.TaskDefinition); err != nil {
+	arn, err := s.executor.RegisterTaskDefinitionARN(ctx, dctx.TaskDefinition)
+	if err != nil {
 		return fmt.Errorf("failed to register task definition: %w", err)
 	}
+	dctx.Recorder.SetTaskDefinitionARN(arn)
+	dctx.ReportProgress(5, "task definition registered")
 
 	// Execute rolling update in batches
 	totalBatches := 100 / batchSize
-	log.Printf("[ROLLING] Executing %d batches", totalBatches)
+	util.Logf(ctx, "[ROLLING] Executing %d batches", totalBatches)
 
 	for batch := 1; batch <= totalBatches; batch++ {
 		select {
 		case <-ctx.Done():
-			log.Printf("[ROLLING] Context canceled at batch %d, initiating rollback", batch)
+			util.Logf(ctx, "[ROLLING] Context canceled at batch %d, initiating rollback", batch)
 			s.rollback(ctx, dctx)
 			return ctx.Err()
 		default:
@@ -62,87 +74,118 @@ func (s *RollingStrategy) Execute(ctx context.Context, dctx *DeploymentContext)
 			currentWeight = 100
 		}
 
-		log.Printf("[ROLLING] Batch %d/%d: Shifting to %d%% new version", batch, totalBatches, currentWeight)
+		util.Logf(ctx, "[ROLLING] Batch %d/%d: Shifting to %d%% new version", batch, totalBatches, currentWeight)
 
 		// Shift traffic gradually
-		if err := s.executor.UpdateTraffic(ctx, dctx.ClusterARN, dctx.ServiceName, currentWeight, 100-currentWeight); err != nil {
-			log.Printf("[ROLLING] Failed to shift traffic: %v, initiating rollback", err)
+		batchName := fmt.Sprintf("batch %d (%d%%)", batch, currentWeight)
+		ctx := dctx.Recorder.StartStage(ctx, batchName)
+		err := shiftTraffic(ctx, s.executor, dctx, "rolling", currentWeight, 100-currentWeight)
+		if err != nil {
+			dctx.Recorder.RecordStage(batchName, err)
+			util.Logf(ctx, "[ROLLING] Failed to shift traffic: %v, initiating rollback", err)
 			s.rollback(ctx, dctx)
 			return fmt.Errorf("traffic shift failed: %w", err)
 		}
 
 		// Wait for stabilization
-		log.Printf("[ROLLING] Waiting %v for batch %d to stabilize", batchDelay, batch)
-		select {
-		case <-ctx.Done():
-			log.Printf("[ROLLING] Context canceled during stabilization, initiating rollback")
-			s.rollback(ctx, dctx)
-			return ctx.Err()
-		case <-time.After(batchDelay):
+		util.Logf(ctx, "[ROLLING] Waiting %v for batch %d to stabilize", batchDelay, batch)
+		if dctx.DryRun {
+			executor.RecordDryRunStep(ctx, "Wait %v for batch %d to stabilize", batchDelay, batch)
+		} else {
+			select {
+			case <-ctx.Done():
+				dctx.Recorder.RecordStage(batchName, ctx.Err())
+				util.Logf(ctx, "[ROLLING] Context canceled during stabilization, initiating rollback")
+				s.rollback(ctx, dctx)
+				return ctx.Err()
+			case <-time.After(batchDelay):
+			}
 		}
 
 		// Validate batch health
 		if err := s.validateBatchHealth(ctx, dctx); err != nil {
-			log.Printf("[ROLLING] Batch %d health check failed: %v, initiating rollback", batch, err)
+			dctx.Recorder.RecordStage(batchName, err)
+			util.Logf(ctx, "[ROLLING] Batch %d health check failed: %v, initiating rollback", batch, err)
 			s.rollback(ctx, dctx)
 			return fmt.Errorf("batch health check failed: %w", err)
 		}
 
-		log.Printf("[ROLLING] Batch %d completed successfully", batch)
+		dctx.Recorder.RecordStage(batchName, nil)
+		dctx.ReportProgress(5+int32(85*batch/totalBatches), fmt.Sprintf("batch %d/%d complete", batch, totalBatches))
+		util.Logf(ctx, "[ROLLING] Batch %d completed successfully", batch)
 	}
 
 	// Final update to 100%
-	log.Println("[ROLLING] Finalizing rolling deployment to 100%")
-	if err := s.executor.UpdateService(ctx, dctx.ClusterARN, dctx.ServiceName, dctx.TaskDefinition); err != nil {
+	util.Logf(ctx, "[ROLLING] Finalizing rolling deployment to 100%%")
+	if err := s.executor.UpdateServiceWithOptions(ctx, dctx.ClusterARN, dctx.ServiceName, dctx.TaskDefinition, opts); err != nil {
 		s.rollback(ctx, dctx)
 		return fmt.Errorf("final update failed: %w", err)
 	}
 
+	dctx.ReportProgress(95, "service updated, waiting for stabilization")
+
 	// Wait for final stabilization
 	if err := s.executor.WaitForServiceStable(ctx, dctx.ClusterARN, dctx.ServiceName, 5*time.Minute); err != nil {
-		log.Printf("[ROLLING] Warning: Service did not stabilize: %v", err)
+		if errors.Is(err, executor.ErrRolloutFailed) {
+			// With the circuit breaker enabled ECS has already rolled the service back
+			if !opts.CircuitBreaker {
+				s.rollback(ctx, dctx)
+			}
+			return err
+		}
+		util.Logf(ctx, "[ROLLING] Warning: Service did not stabilize: %v", err)
 	}
 
-	log.Println("[ROLLING] Rolling deployment completed successfully")
+	util.Logf(ctx, "[ROLLING] Rolling deployment completed successfully")
 	return nil
 }
 
 func (s *RollingStrategy) validateBatchHealth(ctx context.Context, dctx *DeploymentContext) error {
 	// Get service status
-	_, err := s.ecsClient.DescribeService(ctx, dctx.ClusterARN, dctx.ServiceName)
-	if err != nil {
+	if err := s.executor.DescribeService(ctx, dctx.ClusterARN, dctx.ServiceName); err != nil {
 		return fmt.Errorf("failed to validate service: %w", err)
 	}
 
-	log.Printf("[ROLLING] Batch health check passed")
+	util.Logf(ctx, "[ROLLING] Batch health check passed")
 	return nil
 }
 
 func (s *RollingStrategy) rollback(ctx context.Context, dctx *DeploymentContext) {
-	log.Println("[ROLLING] Initiating rollback to previous version")
+	util.Logf(ctx, "[ROLLING] Initiating rollback to previous version")
 
-	prevTaskDef := dctx.Config["previous_taskdef"]
-	if prevTaskDef == "" {
-		log.Println("[ROLLING] No previous task definition available for rollback")
+	if dctx.Snapshot == nil || dctx.Snapshot.TaskDefinition == "" {
+		util.Logf(ctx, "[ROLLING] No previous task definition available for rollback")
 		return
 	}
 
 	// Shift traffic back to old version
-	if err := s.executor.UpdateTraffic(ctx, dctx.ClusterARN, dctx.ServiceName, 0, 100); err != nil {
-		log.Printf("[ROLLING] Rollback traffic shift failed: %v", err)
+	err := restoreTraffic(ctx, s.executor, dctx, "rolling")
+	if err != nil {
+		util.Logf(ctx, "[ROLLING] Rollback traffic shift failed: %v", err)
 		return
 	}
 
-	// Update service to previous task definition
-	if err := s.executor.UpdateService(ctx, dctx.ClusterARN, dctx.ServiceName, prevTaskDef); err != nil {
-		log.Printf("[ROLLING] Rollback service update failed: %v", err)
+	// Restore the service's task definition and desired count
+	if err := s.executor.RestoreServiceSnapshot(ctx, dctx.ClusterARN, dctx.ServiceName, dctx.Snapshot); err != nil {
+		util.Logf(ctx, "[ROLLING] Rollback service update failed: %v", err)
 		return
 	}
 
-	log.Println("[ROLLING] Rollback completed")
+	util.Logf(ctx, "[ROLLING] Rollback completed")
+}
+
+// PlanStages estimates each batch as its stabilization delay
+func (s *RollingStrategy) PlanStages(config map[string]string) []time.Duration {
+	batchDelay := parseBatchDelay(config)
+	plan := make([]time.Duration, 100/parseBatchSize(config))
+	for i := range plan {
+		plan[i] = batchDelay
+	}
+	return plan
 }
 
-func (s *RollingStrategy) parseBatchSize(config map[string]string) int {
+// parseBatchSize extracts the percentage of traffic moved per batch
+func parseBatchSize(config map[string]string) int {
 	if batchSize, ok := config["batch_size"]; ok {
 		if size, err := strconv.Atoi(batchSize); err == nil && size > 0 && size <= 100 {
 			return size
@@ -151,7 +194,8 @@ func (s *RollingStrategy) parseBatchSize(config map[string]string) int {
 	return 25 // Default 25% per batch
 }
 
-func (s *RollingStrategy) parseBatchDelay(config map[string]string) time.Duration {
+// parseBatchDelay extracts the stabilization delay between batches
+func parseBatchDelay(config map[string]string) time.Duration {
 	if delay, ok := config["batch_delay"]; ok {
 		if d, err := time.ParseDuration(delay); err == nil {
 			return d
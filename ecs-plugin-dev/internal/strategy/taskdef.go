@@ -0,0 +1,44 @@
+package strategy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"ecs-plugin-dev/internal/executor"
+	"ecs-plugin-dev/internal/util"
+)
+
+// validateTaskDefinition runs strict task definition validation before
+// registration when the deployment config sets strict_taskdef
+func validateTaskDefinition(exec *executor.Executor, dctx *DeploymentContext) error {
+	if v := dctx.Config["strict_taskdef"]; v != "true" && v != "1" {
+		return nil
+	}
+
+	errs := exec.ValidateTaskDefinitionStrict(dctx.TaskDefinition)
+	if len(errs) == 0 {
+		return nil
+	}
+
+	problems := make([]string, len(errs))
+	for i, err := range errs {
+		problems[i] = err.Error()
+	}
+	return fmt.Errorf("task definition validation failed: %s", strings.Join(problems, "; "))
+}
+
+// captureServiceState snapshots the service into dctx, and the manifest,
+// before the deployment changes anything
+func captureServiceState(ctx context.Context, exec *executor.Executor, dctx *DeploymentContext) error {
+	snapshot, err := exec.SnapshotServiceState(ctx, dctx.ClusterARN, dctx.ServiceName)
+	if err != nil {
+		return err
+	}
+	if snapshot.TaskDefinition == "" {
+		util.Logf(ctx, "[STRATEGY] Service %s has no previous deployment; rollback will not restore a task definition", dctx.ServiceName)
+	}
+	dctx.Snapshot = snapshot
+	dctx.Recorder.SetSnapshot(snapshot)
+	return nil
+}
@@ -0,0 +1,48 @@
+package strategy
+
+import (
+	"context"
+	"time"
+
+	"ecs-plugin-dev/internal/aws"
+	"ecs-plugin-dev/internal/executor"
+	"ecs-plugin-dev/internal/metrics"
+)
+
+// shiftTraffic moves traffic to canaryWeight/primaryWeight on the
+// deployment's listener, recording the shift for the manifest and its
+// outcome and latency under strategyName in metrics
+func shiftTraffic(ctx context.Context, exec *executor.Executor, dctx *DeploymentContext, strategyName string, canaryWeight, primaryWeight int) error {
+	routing, err := parseTrafficRouting(dctx.Config)
+	if err != nil {
+		return err
+	}
+	return shiftTrafficWithRouting(ctx, exec, dctx, strategyName, routing, canaryWeight, primaryWeight)
+}
+
+// restoreTraffic moves all traffic back to the primary target group when
+// rolling back. Its target health is only warned about: refusing the shift
+// would leave traffic on the version being rolled back.
+func restoreTraffic(ctx context.Context, exec *executor.Executor, dctx *DeploymentContext, strategyName string) error {
+	// A malformed health setting must not block the rollback; the routing
+	// keys themselves are parsed before it
+	routing, _ := parseTrafficRouting(dctx.Config)
+	routing.LenientHealth = true
+	return shiftTrafficWithRouting(ctx, exec, dctx, strategyName, routing, 0, 100)
+}
+
+// shiftTrafficWithRouting is shiftTraffic with the routing already resolved
+func shiftTrafficWithRouting(ctx context.Context, exec *executor.Executor, dctx *DeploymentContext, strategyName string, routing aws.TrafficRouting, canaryWeight, primaryWeight int) error {
+	start := time.Now()
+	err := exec.UpdateTrafficWithRouting(ctx, dctx.ClusterARN, dctx.ServiceName, routing, canaryWeight, primaryWeight)
+	dctx.Recorder.RecordTrafficShift(canaryWeight, primaryWeight, err)
+
+	if !dctx.DryRun {
+		status := "success"
+		if err != nil {
+			status = "failed"
+		}
+		metrics.RecordTrafficShiftContext(ctx, strategyName, status, time.Since(start))
+	}
+	return err
+}
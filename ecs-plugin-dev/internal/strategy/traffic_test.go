@@ -0,0 +1,106 @@
+package strategy
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	"ecs-plugin-dev/internal/aws"
+	"ecs-plugin-dev/internal/executor"
+)
+
+const testTaskDefinition = `{"family":"web","containerDefinitions":[{"name":"web","image":"nginx"}]}`
+
+func TestMain(m *testing.M) {
+	// Clients read MOCK_MODE when an executor builds them
+	os.Setenv("MOCK_MODE", "true")
+	os.Exit(m.Run())
+}
+
+// newMockExecutor builds an executor whose mock target groups report the
+// given MOCK_HEALTHY_TARGETS script
+func newMockExecutor(t *testing.T, healthyTargets string) *executor.Executor {
+	t.Helper()
+	t.Setenv("MOCK_HEALTHY_TARGETS", healthyTargets)
+	return executor.NewExecutor()
+}
+
+func newTestContext(config map[string]string) *DeploymentContext {
+	return &DeploymentContext{
+		DeploymentID:   "test",
+		ClusterARN:     "arn:aws:ecs:us-east-1:123456789012:cluster/test",
+		ServiceName:    "web",
+		TaskDefinition: testTaskDefinition,
+		Config:         config,
+		Recorder:       NewDeploymentRecorder(),
+	}
+}
+
+func TestTrafficShiftHealthGate(t *testing.T) {
+	unhealthyCanary := aws.MockCanaryTargetGroupARN + "=0"
+
+	tests := []struct {
+		name          string
+		lenient       bool
+		canaryWeight  int
+		primaryWeight int
+		wantErr       bool
+	}{
+		{name: "strict refuses traffic to an unhealthy group", canaryWeight: 100, primaryWeight: 0, wantErr: true},
+		{name: "strict refuses a partial shift to an unhealthy group", canaryWeight: 10, primaryWeight: 90, wantErr: true},
+		{name: "lenient shifts to an unhealthy group anyway", lenient: true, canaryWeight: 100, primaryWeight: 0},
+		{name: "unhealthy group weighted to zero is not checked", canaryWeight: 0, primaryWeight: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exec := newMockExecutor(t, unhealthyCanary)
+			config := map[string]string{}
+			if tt.lenient {
+				config["lenient_target_health"] = "true"
+			}
+
+			err := shiftTraffic(context.Background(), exec, newTestContext(config), "test", tt.canaryWeight, tt.primaryWeight)
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "refusing traffic shift") {
+					t.Fatalf("expected the shift to be refused, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestRestoreTrafficIgnoresUnhealthyPrimary(t *testing.T) {
+	exec := newMockExecutor(t, aws.MockPrimaryTargetGroupARN+"=0")
+	if err := restoreTraffic(context.Background(), exec, newTestContext(map[string]string{}), "test"); err != nil {
+		t.Fatalf("expected the rollback shift to go ahead, got %v", err)
+	}
+}
+
+func TestCanaryFinalShiftGatesOnCanaryHealth(t *testing.T) {
+	exec := newMockExecutor(t, aws.MockCanaryTargetGroupARN+"=0")
+	dctx := newTestContext(map[string]string{"canary_stages": "100", "stage_timeout": "1ms"})
+
+	err := NewCanaryStrategy(exec).Execute(context.Background(), dctx)
+	if err == nil || !strings.Contains(err.Error(), "refusing traffic shift") {
+		t.Fatalf("expected the final shift to be refused, got %v", err)
+	}
+
+	// The refused promotion sent everything to the canary group, and the
+	// rollback then returned it to the primary
+	shifts := dctx.Recorder.TrafficShifts()
+	if len(shifts) != 2 {
+		t.Fatalf("expected the final shift and the rollback, got %+v", shifts)
+	}
+	if final := shifts[0]; final.CanaryWeight != 100 || final.PrimaryWeight != 0 || final.Status == "success" {
+		t.Errorf("expected a failed 100/0 final shift, got %+v", final)
+	}
+	if restore := shifts[1]; restore.CanaryWeight != 0 || restore.PrimaryWeight != 100 || restore.Status != "success" {
+		t.Errorf("expected a successful 0/100 rollback shift, got %+v", restore)
+	}
+}
@@ -1,16 +1,72 @@
 // internal/strategy/types.go
 package strategy
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"ecs-plugin-dev/internal/executor"
+)
 
 type DeploymentContext struct {
-    DeploymentID   string
-    ClusterARN     string
-    ServiceName    string
-    TaskDefinition string
-    Config         map[string]string
+	DeploymentID   string
+	ClusterARN     string
+	ServiceName    string
+	TaskDefinition string
+	Config         map[string]string
+	Recorder       *DeploymentRecorder // Optional; collects stages and traffic shifts for the manifest
+	Progress       ProgressReporter    // Optional; receives intermediate progress
+	DryRun         bool                // Record AWS operations without performing them; waits are skipped
+
+	// TaskSetIDs are the task sets created by this deployment, oldest first
+	TaskSetIDs []string
+	// PreviousTaskSetID is the service's primary task set before the deployment
+	PreviousTaskSetID string
+	// Snapshot is the service's state before the deployment changed
+	// anything; rollbacks restore it
+	Snapshot *executor.ServiceSnapshot
+
+	// Pause holds the deployment between stages while paused; optional.
+	// Paused receives the progress at which the deployment is being held.
+	Pause  *PauseGate
+	Paused ProgressReporter
+}
+
+// ProgressReporter receives a strategy's progress (0-100) and a short
+// description of the milestone reached
+type ProgressReporter func(percent int32, message string)
+
+// ReportProgress forwards progress to the context's reporter, if any
+func (d *DeploymentContext) ReportProgress(percent int32, message string) {
+	if d.Progress != nil {
+		d.Progress(percent, message)
+	}
+}
+
+// WaitIfPaused blocks at a stage boundary while the deployment is paused,
+// reporting message as the held state, and reports progress again on resume
+func (d *DeploymentContext) WaitIfPaused(ctx context.Context, percent int32, message string) error {
+	if d.Pause == nil || !d.Pause.Paused() {
+		return nil
+	}
+
+	if d.Paused != nil {
+		d.Paused(percent, message)
+	}
+	if err := d.Pause.Wait(ctx); err != nil {
+		return err
+	}
+	d.ReportProgress(percent, "deployment resumed")
+	return nil
 }
 
 type Strategy interface {
-    Execute(ctx context.Context, dctx *DeploymentContext) error
-}
\ No newline at end of file
+	Execute(ctx context.Context, dctx *DeploymentContext) error
+}
+
+// Planner is implemented by strategies that can estimate how long each of
+// their stages takes. Durations are listed in the order stages are recorded;
+// entries beyond the recorded stages cover trailing work such as cleanup.
+type Planner interface {
+	PlanStages(config map[string]string) []time.Duration
+}
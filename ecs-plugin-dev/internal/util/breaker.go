@@ -0,0 +1,131 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// BreakerState is the state of a CircuitBreaker
+type BreakerState int
+
+const (
+	// BreakerClosed lets every call through
+	BreakerClosed BreakerState = iota
+	// BreakerHalfOpen lets a single probe call through after the cooldown
+	BreakerHalfOpen
+	// BreakerOpen fails calls fast until the cooldown ends
+	BreakerOpen
+)
+
+func (s BreakerState) String() string {
+	switch s {
+	case BreakerHalfOpen:
+		return "half-open"
+	case BreakerOpen:
+		return "open"
+	default:
+		return "closed"
+	}
+}
+
+// ErrCircuitOpen is returned, wrapped, for calls an open breaker refuses.
+// It is not retryable.
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// CircuitBreaker fails calls fast once Threshold consecutive calls have
+// failed. After Cooldown one probe call is let through: its success closes
+// the breaker, its failure opens it for another cooldown.
+type CircuitBreaker struct {
+	threshold     int
+	cooldown      time.Duration
+	onStateChange func(BreakerState)
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool
+}
+
+// NewCircuitBreaker creates a closed breaker. onStateChange, when set, is
+// called with each new state while the breaker's lock is held.
+func NewCircuitBreaker(threshold int, cooldown time.Duration, onStateChange func(BreakerState)) *CircuitBreaker {
+	return &CircuitBreaker{threshold: threshold, cooldown: cooldown, onStateChange: onStateChange}
+}
+
+// State returns the breaker's current state
+func (b *CircuitBreaker) State() BreakerState {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.state
+}
+
+// Allow reports whether a call may go ahead. Every allowed call must be
+// followed by Success, Failure or Abandon.
+func (b *CircuitBreaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		remaining := b.cooldown - time.Since(b.openedAt)
+		if remaining > 0 {
+			return fmt.Errorf("%w after %d consecutive failures, retrying in %v", ErrCircuitOpen, b.failures, remaining.Round(time.Second))
+		}
+		b.setState(BreakerHalfOpen)
+		b.probing = true
+		return nil
+	case BreakerHalfOpen:
+		if b.probing {
+			return fmt.Errorf("%w while a probe call is in flight", ErrCircuitOpen)
+		}
+		b.probing = true
+	}
+	return nil
+}
+
+// Success records an allowed call that succeeded, closing the breaker
+func (b *CircuitBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures = 0
+	b.probing = false
+	if b.state != BreakerClosed {
+		b.setState(BreakerClosed)
+	}
+}
+
+// Failure records an allowed call that failed, opening the breaker when it
+// reaches the threshold or was the half-open probe
+func (b *CircuitBreaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+	if b.state == BreakerOpen {
+		return // Allowed before the breaker opened; the cooldown already runs
+	}
+	b.failures++
+	if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+		b.openedAt = time.Now()
+		b.setState(BreakerOpen)
+	}
+}
+
+// Abandon records an allowed call whose outcome says nothing about the
+// remote service, such as one the caller cancelled
+func (b *CircuitBreaker) Abandon() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.probing = false
+}
+
+func (b *CircuitBreaker) setState(state BreakerState) {
+	b.state = state
+	if b.onStateChange != nil {
+		b.onStateChange(state)
+	}
+}
@@ -0,0 +1,44 @@
+package util
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log"
+)
+
+type correlationIDKey struct{}
+
+// WithCorrelationID attaches the ID tying together everything done for one
+// request, from the gRPC call down to the AWS calls it makes
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, id)
+}
+
+// CorrelationIDFromContext returns the correlation ID attached to ctx, or ""
+func CorrelationIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(correlationIDKey{}).(string)
+	return id
+}
+
+// NewCorrelationID mints a random 16-character correlation ID
+func NewCorrelationID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
+
+// Logf logs like log.Printf, appending ctx's correlation ID when it has one
+func Logf(ctx context.Context, format string, args ...interface{}) {
+	msg := fmt.Sprintf(format, args...)
+	if id := CorrelationIDFromContext(ctx); id != "" {
+		msg += " correlation_id=" + id
+	}
+	log.Output(2, msg)
+}
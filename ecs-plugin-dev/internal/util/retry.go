@@ -2,16 +2,39 @@ package util
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
+	"math/rand"
+	"strings"
 	"time"
 )
 
+// JitterMode controls how retry delays are randomized
+type JitterMode string
+
+const (
+	// JitterNone waits exactly the computed backoff delay
+	JitterNone JitterMode = "none"
+	// JitterFull waits a random delay in [0, computed]
+	JitterFull JitterMode = "full"
+	// JitterEqual waits a random delay in [computed/2, computed]
+	JitterEqual JitterMode = "equal"
+)
+
 // RetryConfig holds retry configuration
 type RetryConfig struct {
 	MaxAttempts int
 	BaseDelay   time.Duration
 	MaxDelay    time.Duration
+	// Jitter randomizes delays so concurrent callers do not retry in lockstep;
+	// empty behaves like JitterNone
+	Jitter JitterMode
+
+	// BreakerThreshold consecutive failed calls open a client's circuit
+	// breaker, which then fails calls fast for BreakerCooldown; 0 disables it
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
 }
 
 // DefaultRetryConfig returns sensible defaults
@@ -20,10 +43,68 @@ func DefaultRetryConfig() RetryConfig {
 		MaxAttempts: 3,
 		BaseDelay:   time.Second,
 		MaxDelay:    30 * time.Second,
+		Jitter:      JitterNone,
+	}
+}
+
+// BackoffDelay returns the delay before the given retry attempt (1-based),
+// capped at MaxDelay and randomized according to Jitter
+func (c RetryConfig) BackoffDelay(attempt int) time.Duration {
+	delay := time.Duration(float64(c.BaseDelay) * math.Pow(2, float64(attempt-1)))
+	if delay > c.MaxDelay {
+		delay = c.MaxDelay
+	}
+	if delay <= 0 {
+		return 0
+	}
+
+	switch c.Jitter {
+	case JitterFull:
+		return time.Duration(rand.Int63n(int64(delay) + 1))
+	case JitterEqual:
+		half := delay / 2
+		return half + time.Duration(rand.Int63n(int64(delay-half)+1))
+	default:
+		return delay
+	}
+}
+
+// RetryAfterError wraps an error with the delay the server asked for before
+// the next attempt, such as a throttling response's Retry-After. The hint only
+// sets the delay; whether to retry at all depends on the wrapped error.
+type RetryAfterError struct {
+	Err   error
+	Delay time.Duration
+}
+
+func (e *RetryAfterError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *RetryAfterError) Unwrap() error {
+	return e.Err
+}
+
+// RetryAfter attaches a suggested retry delay to err; nil stays nil
+func RetryAfter(err error, delay time.Duration) error {
+	if err == nil {
+		return nil
 	}
+	return &RetryAfterError{Err: err, Delay: delay}
 }
 
-// ExponentialBackoff executes fn with exponential backoff retry
+// SuggestedDelay returns the retry delay carried by err, if any
+func SuggestedDelay(err error) (time.Duration, bool) {
+	var retryAfter *RetryAfterError
+	if errors.As(err, &retryAfter) && retryAfter.Delay > 0 {
+		return retryAfter.Delay, true
+	}
+	return 0, false
+}
+
+// ExponentialBackoff executes fn with exponential backoff retry. When fn's
+// error suggests a delay (see RetryAfter), the next attempt waits at least
+// that long, up to MaxDelay.
 func ExponentialBackoff(ctx context.Context, config RetryConfig, fn func() error) error {
 	var lastErr error
 
@@ -32,9 +113,12 @@ func ExponentialBackoff(ctx context.Context, config RetryConfig, fn func() error
 
 	for attempt := 0; attempt < config.MaxAttempts; attempt++ {
 		if attempt > 0 {
-			delay := time.Duration(float64(config.BaseDelay) * math.Pow(2, float64(attempt-1)))
-			if delay > config.MaxDelay {
-				delay = config.MaxDelay
+			delay := config.BackoffDelay(attempt)
+			if suggested, ok := SuggestedDelay(lastErr); ok && suggested > delay {
+				delay = suggested
+				if config.MaxDelay > 0 && delay > config.MaxDelay {
+					delay = config.MaxDelay
+				}
 			}
 
 			// Check if delay would exceed context deadline
@@ -66,7 +150,9 @@ func ExponentialBackoff(ctx context.Context, config RetryConfig, fn func() error
 	return fmt.Errorf("max retry attempts reached: %w", lastErr)
 }
 
-// IsRetryable determines if error should be retried
+// IsRetryable determines if error should be retried. A RetryAfterError is
+// classified by the error it wraps, since a Retry-After header can accompany
+// errors that will never succeed.
 func IsRetryable(err error) bool {
 	if err == nil {
 		return false
@@ -84,16 +170,10 @@ func IsRetryable(err error) bool {
 	}
 
 	for _, retryable := range retryableErrors {
-		if contains(errMsg, retryable) {
+		if strings.Contains(errMsg, retryable) {
 			return true
 		}
 	}
 
 	return false
 }
-
-func contains(s, substr string) bool {
-	return len(s) >= len(substr) && (s == substr || len(s) > len(substr) &&
-		(s[:len(substr)] == substr || s[len(s)-len(substr):] == substr ||
-			len(s) > len(substr)*2))
-}
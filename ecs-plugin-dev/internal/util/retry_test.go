@@ -0,0 +1,123 @@
+package util
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "nil", err: nil, want: false},
+		{name: "request timeout", err: errors.New("operation error ECS: RequestTimeout: request timed out"), want: true},
+		{name: "service unavailable", err: errors.New("ServiceUnavailable: please try again"), want: true},
+		{name: "throttling", err: errors.New("Throttling: Rate exceeded"), want: true},
+		{name: "throttling exception", err: errors.New("api error ThrottlingException: Rate exceeded"), want: true},
+		{name: "too many requests", err: errors.New("TooManyRequests"), want: true},
+		{name: "connection reset", err: errors.New("read tcp 10.0.0.1:443: connection reset by peer"), want: true},
+		{name: "connection refused", err: errors.New("dial tcp 127.0.0.1:4566: connection refused"), want: true},
+		{name: "wrapped retryable", err: fmt.Errorf("failed to update service: %w", errors.New("ThrottlingException")), want: true},
+		{name: "retry after on throttling", err: RetryAfter(errors.New("ThrottlingException: Rate exceeded"), time.Second), want: true},
+		{name: "retry after on access denied", err: RetryAfter(errors.New("AccessDeniedException: User is not authorized"), time.Second), want: false},
+		{name: "access denied", err: errors.New("AccessDeniedException: User is not authorized to perform ecs:UpdateService"), want: false},
+		{name: "invalid parameter", err: errors.New("InvalidParameterException: Task definition does not exist"), want: false},
+		{name: "service not found", err: errors.New("ServiceNotFoundException: Service not found"), want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsRetryable(tt.err); got != tt.want {
+				t.Errorf("IsRetryable(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBackoffDelayJitterBounds(t *testing.T) {
+	tests := []struct {
+		name    string
+		jitter  JitterMode
+		attempt int
+		min     time.Duration
+		max     time.Duration
+	}{
+		{name: "none", jitter: JitterNone, attempt: 3, min: 400 * time.Millisecond, max: 400 * time.Millisecond},
+		{name: "unset", jitter: "", attempt: 1, min: 100 * time.Millisecond, max: 100 * time.Millisecond},
+		{name: "full", jitter: JitterFull, attempt: 3, min: 0, max: 400 * time.Millisecond},
+		{name: "equal", jitter: JitterEqual, attempt: 3, min: 200 * time.Millisecond, max: 400 * time.Millisecond},
+		{name: "none capped", jitter: JitterNone, attempt: 10, min: time.Second, max: time.Second},
+		{name: "full capped", jitter: JitterFull, attempt: 10, min: 0, max: time.Second},
+		{name: "equal capped", jitter: JitterEqual, attempt: 10, min: 500 * time.Millisecond, max: time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := RetryConfig{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second, Jitter: tt.jitter}
+			for i := 0; i < 1000; i++ {
+				delay := config.BackoffDelay(tt.attempt)
+				if delay < tt.min || delay > tt.max {
+					t.Fatalf("BackoffDelay(%d) = %v, want within [%v, %v]", tt.attempt, delay, tt.min, tt.max)
+				}
+			}
+		})
+	}
+}
+
+func TestDefaultRetryConfigHasFixedDelays(t *testing.T) {
+	config := DefaultRetryConfig()
+	for attempt, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 6: 30 * time.Second} {
+		for i := 0; i < 10; i++ {
+			if got := config.BackoffDelay(attempt); got != want {
+				t.Fatalf("BackoffDelay(%d) = %v, want a fixed %v", attempt, got, want)
+			}
+		}
+	}
+}
+
+func TestBackoffDelayJitterVaries(t *testing.T) {
+	for _, jitter := range []JitterMode{JitterFull, JitterEqual} {
+		config := RetryConfig{BaseDelay: time.Second, MaxDelay: time.Minute, Jitter: jitter}
+		seen := make(map[time.Duration]bool)
+		for i := 0; i < 100; i++ {
+			seen[config.BackoffDelay(2)] = true
+		}
+		if len(seen) < 2 {
+			t.Errorf("%s jitter returned the same delay 100 times", jitter)
+		}
+	}
+}
+
+func TestExponentialBackoffRetryAfter(t *testing.T) {
+	config := RetryConfig{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 20 * time.Millisecond}
+	tests := []struct {
+		name         string
+		err          error
+		wantAttempts int
+	}{
+		{name: "retryable error waits for the hint", err: RetryAfter(errors.New("ThrottlingException"), 10*time.Millisecond), wantAttempts: 3},
+		{name: "hint does not make an error retryable", err: RetryAfter(errors.New("AccessDeniedException"), 10*time.Millisecond), wantAttempts: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			start := time.Now()
+			err := ExponentialBackoff(context.Background(), config, func() error {
+				attempts++
+				return tt.err
+			})
+			if err == nil || attempts != tt.wantAttempts {
+				t.Fatalf("expected %d attempts ending in an error, got %d (%v)", tt.wantAttempts, attempts, err)
+			}
+			if minWait := time.Duration(tt.wantAttempts-1) * 10 * time.Millisecond; time.Since(start) < minWait {
+				t.Errorf("expected at least %v of suggested delay, took %v", minWait, time.Since(start))
+			}
+		})
+	}
+}
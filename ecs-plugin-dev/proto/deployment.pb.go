@@ -32,6 +32,15 @@ type DeployRequest struct {
 	Strategy        string                 `protobuf:"bytes,5,opt,name=strategy,proto3" json:"strategy,omitempty"`
 	Config          map[string]string      `protobuf:"bytes,6,rep,name=config,proto3" json:"config,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	RequireApproval bool                   `protobuf:"varint,7,opt,name=require_approval,json=requireApproval,proto3" json:"require_approval,omitempty"`
+	DependsOn       []string               `protobuf:"bytes,8,rep,name=depends_on,json=dependsOn,proto3" json:"depends_on,omitempty"`
+	// Return the AWS operations the strategy would perform without running them
+	DryRun bool `protobuf:"varint,9,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// Run again when deployment_id names a finished deployment, instead of
+	// returning it
+	Force bool `protobuf:"varint,10,opt,name=force,proto3" json:"force,omitempty"`
+	// Hold the deployment in SCHEDULED until this Unix time, then start it.
+	// Must be in the future; zero starts it right away.
+	ScheduledAtUnix int64 `protobuf:"varint,11,opt,name=scheduled_at_unix,json=scheduledAtUnix,proto3" json:"scheduled_at_unix,omitempty"`
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -115,6 +124,34 @@ func (x *DeployRequest) GetRequireApproval() bool {
 	return false
 }
 
+func (x *DeployRequest) GetDependsOn() []string {
+	if x != nil {
+		return x.DependsOn
+	}
+	return nil
+}
+
+func (x *DeployRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *DeployRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+func (x *DeployRequest) GetScheduledAtUnix() int64 {
+	if x != nil {
+		return x.ScheduledAtUnix
+	}
+	return 0
+}
+
 type DeployResponse struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
 	Success         bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -123,8 +160,16 @@ type DeployResponse struct {
 	ErrorCode       string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
 	ErrorDetails    string                 `protobuf:"bytes,5,opt,name=error_details,json=errorDetails,proto3" json:"error_details,omitempty"`
 	PendingApproval bool                   `protobuf:"varint,6,opt,name=pending_approval,json=pendingApproval,proto3" json:"pending_approval,omitempty"`
-	unknownFields   protoimpl.UnknownFields
-	sizeCache       protoimpl.SizeCache
+	// Set for dry runs: the planned AWS operations, in order
+	PlannedSteps []string `protobuf:"bytes,7,rep,name=planned_steps,json=plannedSteps,proto3" json:"planned_steps,omitempty"`
+	// 1-based place in the service's queue when the deployment was queued
+	// behind another one; 0 when it started right away
+	QueuePosition int32 `protobuf:"varint,8,opt,name=queue_position,json=queuePosition,proto3" json:"queue_position,omitempty"`
+	// Set when deployment_id was already in use: the status of that
+	// deployment, returned instead of starting a new one
+	ExistingStatus string `protobuf:"bytes,9,opt,name=existing_status,json=existingStatus,proto3" json:"existing_status,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *DeployResponse) Reset() {
@@ -199,6 +244,27 @@ func (x *DeployResponse) GetPendingApproval() bool {
 	return false
 }
 
+func (x *DeployResponse) GetPlannedSteps() []string {
+	if x != nil {
+		return x.PlannedSteps
+	}
+	return nil
+}
+
+func (x *DeployResponse) GetQueuePosition() int32 {
+	if x != nil {
+		return x.QueuePosition
+	}
+	return 0
+}
+
+func (x *DeployResponse) GetExistingStatus() string {
+	if x != nil {
+		return x.ExistingStatus
+	}
+	return ""
+}
+
 type StatusRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
@@ -244,14 +310,21 @@ func (x *StatusRequest) GetDeploymentId() string {
 }
 
 type StatusResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	Progress      int32                  `protobuf:"varint,3,opt,name=progress,proto3" json:"progress,omitempty"`
-	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
-	ErrorDetails  string                 `protobuf:"bytes,5,opt,name=error_details,json=errorDetails,proto3" json:"error_details,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Status          string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Message         string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Progress        int32                  `protobuf:"varint,3,opt,name=progress,proto3" json:"progress,omitempty"`
+	ErrorCode       string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	ErrorDetails    string                 `protobuf:"bytes,5,opt,name=error_details,json=errorDetails,proto3" json:"error_details,omitempty"`
+	EtaSeconds      int64                  `protobuf:"varint,6,opt,name=eta_seconds,json=etaSeconds,proto3" json:"eta_seconds,omitempty"` // Estimated seconds remaining; only set while running
+	Strategy        string                 `protobuf:"bytes,7,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	ClusterArn      string                 `protobuf:"bytes,8,opt,name=cluster_arn,json=clusterArn,proto3" json:"cluster_arn,omitempty"`
+	ServiceName     string                 `protobuf:"bytes,9,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	StartTimeUnix   int64                  `protobuf:"varint,10,opt,name=start_time_unix,json=startTimeUnix,proto3" json:"start_time_unix,omitempty"`
+	EndTimeUnix     int64                  `protobuf:"varint,11,opt,name=end_time_unix,json=endTimeUnix,proto3" json:"end_time_unix,omitempty"`            // 0 until the deployment finishes
+	DurationSeconds float64                `protobuf:"fixed64,12,opt,name=duration_seconds,json=durationSeconds,proto3" json:"duration_seconds,omitempty"` // Elapsed so far while running
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *StatusResponse) Reset() {
@@ -319,13 +392,64 @@ func (x *StatusResponse) GetErrorDetails() string {
 	return ""
 }
 
+func (x *StatusResponse) GetEtaSeconds() int64 {
+	if x != nil {
+		return x.EtaSeconds
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetClusterArn() string {
+	if x != nil {
+		return x.ClusterArn
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *StatusResponse) GetStartTimeUnix() int64 {
+	if x != nil {
+		return x.StartTimeUnix
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetEndTimeUnix() int64 {
+	if x != nil {
+		return x.EndTimeUnix
+	}
+	return 0
+}
+
+func (x *StatusResponse) GetDurationSeconds() float64 {
+	if x != nil {
+		return x.DurationSeconds
+	}
+	return 0
+}
+
 type RollbackRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
-	ClusterArn    string                 `protobuf:"bytes,2,opt,name=cluster_arn,json=clusterArn,proto3" json:"cluster_arn,omitempty"`
-	ServiceName   string                 `protobuf:"bytes,3,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId   string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	ClusterArn     string                 `protobuf:"bytes,2,opt,name=cluster_arn,json=clusterArn,proto3" json:"cluster_arn,omitempty"`
+	ServiceName    string                 `protobuf:"bytes,3,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	Targets        []*RollbackTarget      `protobuf:"bytes,4,rep,name=targets,proto3" json:"targets,omitempty"`
+	TaskDefinition string                 `protobuf:"bytes,5,opt,name=task_definition,json=taskDefinition,proto3" json:"task_definition,omitempty"` // Roll back to this revision instead of the previous one
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *RollbackRequest) Reset() {
@@ -379,19 +503,95 @@ func (x *RollbackRequest) GetServiceName() string {
 	return ""
 }
 
+func (x *RollbackRequest) GetTargets() []*RollbackTarget {
+	if x != nil {
+		return x.Targets
+	}
+	return nil
+}
+
+func (x *RollbackRequest) GetTaskDefinition() string {
+	if x != nil {
+		return x.TaskDefinition
+	}
+	return ""
+}
+
+type RollbackTarget struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ClusterArn     string                 `protobuf:"bytes,1,opt,name=cluster_arn,json=clusterArn,proto3" json:"cluster_arn,omitempty"`
+	ServiceName    string                 `protobuf:"bytes,2,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	TaskDefinition string                 `protobuf:"bytes,3,opt,name=task_definition,json=taskDefinition,proto3" json:"task_definition,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *RollbackTarget) Reset() {
+	*x = RollbackTarget{}
+	mi := &file_proto_deployment_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollbackTarget) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollbackTarget) ProtoMessage() {}
+
+func (x *RollbackTarget) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollbackTarget.ProtoReflect.Descriptor instead.
+func (*RollbackTarget) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RollbackTarget) GetClusterArn() string {
+	if x != nil {
+		return x.ClusterArn
+	}
+	return ""
+}
+
+func (x *RollbackTarget) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *RollbackTarget) GetTaskDefinition() string {
+	if x != nil {
+		return x.TaskDefinition
+	}
+	return ""
+}
+
 type RollbackResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
-	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
-	ErrorDetails  string                 `protobuf:"bytes,4,opt,name=error_details,json=errorDetails,proto3" json:"error_details,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState  `protogen:"open.v1"`
+	Success        bool                    `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message        string                  `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ErrorCode      string                  `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	ErrorDetails   string                  `protobuf:"bytes,4,opt,name=error_details,json=errorDetails,proto3" json:"error_details,omitempty"`
+	Results        []*RollbackTargetResult `protobuf:"bytes,5,rep,name=results,proto3" json:"results,omitempty"`
+	PartialSuccess bool                    `protobuf:"varint,6,opt,name=partial_success,json=partialSuccess,proto3" json:"partial_success,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *RollbackResponse) Reset() {
 	*x = RollbackResponse{}
-	mi := &file_proto_deployment_proto_msgTypes[5]
+	mi := &file_proto_deployment_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -403,7 +603,7 @@ func (x *RollbackResponse) String() string {
 func (*RollbackResponse) ProtoMessage() {}
 
 func (x *RollbackResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_deployment_proto_msgTypes[5]
+	mi := &file_proto_deployment_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -416,7 +616,7 @@ func (x *RollbackResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RollbackResponse.ProtoReflect.Descriptor instead.
 func (*RollbackResponse) Descriptor() ([]byte, []int) {
-	return file_proto_deployment_proto_rawDescGZIP(), []int{5}
+	return file_proto_deployment_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *RollbackResponse) GetSuccess() bool {
@@ -447,31 +647,45 @@ func (x *RollbackResponse) GetErrorDetails() string {
 	return ""
 }
 
-type ApprovalRequest struct {
+func (x *RollbackResponse) GetResults() []*RollbackTargetResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *RollbackResponse) GetPartialSuccess() bool {
+	if x != nil {
+		return x.PartialSuccess
+	}
+	return false
+}
+
+type RollbackTargetResult struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
-	Approved      bool                   `protobuf:"varint,2,opt,name=approved,proto3" json:"approved,omitempty"`
-	Approver      string                 `protobuf:"bytes,3,opt,name=approver,proto3" json:"approver,omitempty"`
-	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	ClusterArn    string                 `protobuf:"bytes,1,opt,name=cluster_arn,json=clusterArn,proto3" json:"cluster_arn,omitempty"`
+	ServiceName   string                 `protobuf:"bytes,2,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	Success       bool                   `protobuf:"varint,3,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,4,opt,name=message,proto3" json:"message,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ApprovalRequest) Reset() {
-	*x = ApprovalRequest{}
-	mi := &file_proto_deployment_proto_msgTypes[6]
+func (x *RollbackTargetResult) Reset() {
+	*x = RollbackTargetResult{}
+	mi := &file_proto_deployment_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ApprovalRequest) String() string {
+func (x *RollbackTargetResult) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ApprovalRequest) ProtoMessage() {}
+func (*RollbackTargetResult) ProtoMessage() {}
 
-func (x *ApprovalRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_deployment_proto_msgTypes[6]
+func (x *RollbackTargetResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -482,62 +696,109 @@ func (x *ApprovalRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ApprovalRequest.ProtoReflect.Descriptor instead.
-func (*ApprovalRequest) Descriptor() ([]byte, []int) {
-	return file_proto_deployment_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use RollbackTargetResult.ProtoReflect.Descriptor instead.
+func (*RollbackTargetResult) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *ApprovalRequest) GetDeploymentId() string {
+func (x *RollbackTargetResult) GetClusterArn() string {
 	if x != nil {
-		return x.DeploymentId
+		return x.ClusterArn
 	}
 	return ""
 }
 
-func (x *ApprovalRequest) GetApproved() bool {
+func (x *RollbackTargetResult) GetServiceName() string {
 	if x != nil {
-		return x.Approved
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *RollbackTargetResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
 	}
 	return false
 }
 
-func (x *ApprovalRequest) GetApprover() string {
+func (x *RollbackTargetResult) GetMessage() string {
 	if x != nil {
-		return x.Approver
+		return x.Message
 	}
 	return ""
 }
 
-func (x *ApprovalRequest) GetReason() string {
+type CancelRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelRequest) Reset() {
+	*x = CancelRequest{}
+	mi := &file_proto_deployment_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelRequest) ProtoMessage() {}
+
+func (x *CancelRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[8]
 	if x != nil {
-		return x.Reason
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelRequest.ProtoReflect.Descriptor instead.
+func (*CancelRequest) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *CancelRequest) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
 	}
 	return ""
 }
 
-type ApprovalResponse struct {
+type CancelResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
 	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	ErrorDetails  string                 `protobuf:"bytes,5,opt,name=error_details,json=errorDetails,proto3" json:"error_details,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ApprovalResponse) Reset() {
-	*x = ApprovalResponse{}
-	mi := &file_proto_deployment_proto_msgTypes[7]
+func (x *CancelResponse) Reset() {
+	*x = CancelResponse{}
+	mi := &file_proto_deployment_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ApprovalResponse) String() string {
+func (x *CancelResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ApprovalResponse) ProtoMessage() {}
+func (*CancelResponse) ProtoMessage() {}
 
-func (x *ApprovalResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_deployment_proto_msgTypes[7]
+func (x *CancelResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -548,31 +809,2994 @@ func (x *ApprovalResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ApprovalResponse.ProtoReflect.Descriptor instead.
-func (*ApprovalResponse) Descriptor() ([]byte, []int) {
-	return file_proto_deployment_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use CancelResponse.ProtoReflect.Descriptor instead.
+func (*CancelResponse) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *ApprovalResponse) GetSuccess() bool {
+func (x *CancelResponse) GetSuccess() bool {
 	if x != nil {
 		return x.Success
 	}
 	return false
 }
 
-func (x *ApprovalResponse) GetMessage() string {
+func (x *CancelResponse) GetMessage() string {
 	if x != nil {
 		return x.Message
 	}
 	return ""
 }
 
-var File_proto_deployment_proto protoreflect.FileDescriptor
+func (x *CancelResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
 
-const file_proto_deployment_proto_rawDesc = "" +
-	"\n" +
-	"\x16proto/deployment.proto\x12\n" +
-	"deployment\"\xe2\x02\n" +
+func (x *CancelResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *CancelResponse) GetErrorDetails() string {
+	if x != nil {
+		return x.ErrorDetails
+	}
+	return ""
+}
+
+type RetryRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The FAILED or CANCELLED deployment to run again
+	DeploymentId string `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	// ID for the new deployment; empty picks <deployment_id>-retry-<n>
+	NewDeploymentId string `protobuf:"bytes,2,opt,name=new_deployment_id,json=newDeploymentId,proto3" json:"new_deployment_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *RetryRequest) Reset() {
+	*x = RetryRequest{}
+	mi := &file_proto_deployment_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryRequest) ProtoMessage() {}
+
+func (x *RetryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryRequest.ProtoReflect.Descriptor instead.
+func (*RetryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *RetryRequest) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *RetryRequest) GetNewDeploymentId() string {
+	if x != nil {
+		return x.NewDeploymentId
+	}
+	return ""
+}
+
+type RetryResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Success bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// ID of the new deployment
+	DeploymentId string `protobuf:"bytes,3,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	// The deployment that was retried
+	RetriedFrom  string `protobuf:"bytes,4,opt,name=retried_from,json=retriedFrom,proto3" json:"retried_from,omitempty"`
+	ErrorCode    string `protobuf:"bytes,5,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	ErrorDetails string `protobuf:"bytes,6,opt,name=error_details,json=errorDetails,proto3" json:"error_details,omitempty"`
+	// 1-based place in the service's queue when the retry was queued
+	QueuePosition int32 `protobuf:"varint,7,opt,name=queue_position,json=queuePosition,proto3" json:"queue_position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RetryResponse) Reset() {
+	*x = RetryResponse{}
+	mi := &file_proto_deployment_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RetryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RetryResponse) ProtoMessage() {}
+
+func (x *RetryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RetryResponse.ProtoReflect.Descriptor instead.
+func (*RetryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *RetryResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *RetryResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RetryResponse) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *RetryResponse) GetRetriedFrom() string {
+	if x != nil {
+		return x.RetriedFrom
+	}
+	return ""
+}
+
+func (x *RetryResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *RetryResponse) GetErrorDetails() string {
+	if x != nil {
+		return x.ErrorDetails
+	}
+	return ""
+}
+
+func (x *RetryResponse) GetQueuePosition() int32 {
+	if x != nil {
+		return x.QueuePosition
+	}
+	return 0
+}
+
+type PauseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseRequest) Reset() {
+	*x = PauseRequest{}
+	mi := &file_proto_deployment_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseRequest) ProtoMessage() {}
+
+func (x *PauseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseRequest.ProtoReflect.Descriptor instead.
+func (*PauseRequest) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *PauseRequest) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+type PauseResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	ErrorDetails  string                 `protobuf:"bytes,5,opt,name=error_details,json=errorDetails,proto3" json:"error_details,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PauseResponse) Reset() {
+	*x = PauseResponse{}
+	mi := &file_proto_deployment_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PauseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PauseResponse) ProtoMessage() {}
+
+func (x *PauseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PauseResponse.ProtoReflect.Descriptor instead.
+func (*PauseResponse) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *PauseResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *PauseResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *PauseResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *PauseResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *PauseResponse) GetErrorDetails() string {
+	if x != nil {
+		return x.ErrorDetails
+	}
+	return ""
+}
+
+type ResumeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeRequest) Reset() {
+	*x = ResumeRequest{}
+	mi := &file_proto_deployment_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeRequest) ProtoMessage() {}
+
+func (x *ResumeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeRequest.ProtoReflect.Descriptor instead.
+func (*ResumeRequest) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ResumeRequest) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+type ResumeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Status        string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	ErrorDetails  string                 `protobuf:"bytes,5,opt,name=error_details,json=errorDetails,proto3" json:"error_details,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResumeResponse) Reset() {
+	*x = ResumeResponse{}
+	mi := &file_proto_deployment_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResumeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResumeResponse) ProtoMessage() {}
+
+func (x *ResumeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResumeResponse.ProtoReflect.Descriptor instead.
+func (*ResumeResponse) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ResumeResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ResumeResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ResumeResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ResumeResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *ResumeResponse) GetErrorDetails() string {
+	if x != nil {
+		return x.ErrorDetails
+	}
+	return ""
+}
+
+type ApprovalRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	Approved      bool                   `protobuf:"varint,2,opt,name=approved,proto3" json:"approved,omitempty"`
+	Approver      string                 `protobuf:"bytes,3,opt,name=approver,proto3" json:"approver,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApprovalRequest) Reset() {
+	*x = ApprovalRequest{}
+	mi := &file_proto_deployment_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApprovalRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApprovalRequest) ProtoMessage() {}
+
+func (x *ApprovalRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApprovalRequest.ProtoReflect.Descriptor instead.
+func (*ApprovalRequest) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ApprovalRequest) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *ApprovalRequest) GetApproved() bool {
+	if x != nil {
+		return x.Approved
+	}
+	return false
+}
+
+func (x *ApprovalRequest) GetApprover() string {
+	if x != nil {
+		return x.Approver
+	}
+	return ""
+}
+
+func (x *ApprovalRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ApprovalResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,3,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	ErrorDetails  string                 `protobuf:"bytes,4,opt,name=error_details,json=errorDetails,proto3" json:"error_details,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApprovalResponse) Reset() {
+	*x = ApprovalResponse{}
+	mi := &file_proto_deployment_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApprovalResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApprovalResponse) ProtoMessage() {}
+
+func (x *ApprovalResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApprovalResponse.ProtoReflect.Descriptor instead.
+func (*ApprovalResponse) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *ApprovalResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ApprovalResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ApprovalResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *ApprovalResponse) GetErrorDetails() string {
+	if x != nil {
+		return x.ErrorDetails
+	}
+	return ""
+}
+
+type ApprovalInfo struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId    string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"` // The approval group ID for group approvals
+	ClusterArn      string                 `protobuf:"bytes,2,opt,name=cluster_arn,json=clusterArn,proto3" json:"cluster_arn,omitempty"`
+	ServiceName     string                 `protobuf:"bytes,3,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	Strategy        string                 `protobuf:"bytes,4,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	RequestedAtUnix int64                  `protobuf:"varint,5,opt,name=requested_at_unix,json=requestedAtUnix,proto3" json:"requested_at_unix,omitempty"`
+	Status          string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"` // pending, approved, rejected or expired
+	Approver        string                 `protobuf:"bytes,7,opt,name=approver,proto3" json:"approver,omitempty"`
+	Reason          string                 `protobuf:"bytes,8,opt,name=reason,proto3" json:"reason,omitempty"`
+	GroupId         string                 `protobuf:"bytes,9,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	Members         []string               `protobuf:"bytes,10,rep,name=members,proto3" json:"members,omitempty"`                                     // Deployments released by a group approval
+	ExpiresAtUnix   int64                  `protobuf:"varint,11,opt,name=expires_at_unix,json=expiresAtUnix,proto3" json:"expires_at_unix,omitempty"` // A pending request expires at this time
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ApprovalInfo) Reset() {
+	*x = ApprovalInfo{}
+	mi := &file_proto_deployment_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApprovalInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApprovalInfo) ProtoMessage() {}
+
+func (x *ApprovalInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApprovalInfo.ProtoReflect.Descriptor instead.
+func (*ApprovalInfo) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ApprovalInfo) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *ApprovalInfo) GetClusterArn() string {
+	if x != nil {
+		return x.ClusterArn
+	}
+	return ""
+}
+
+func (x *ApprovalInfo) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *ApprovalInfo) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *ApprovalInfo) GetRequestedAtUnix() int64 {
+	if x != nil {
+		return x.RequestedAtUnix
+	}
+	return 0
+}
+
+func (x *ApprovalInfo) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ApprovalInfo) GetApprover() string {
+	if x != nil {
+		return x.Approver
+	}
+	return ""
+}
+
+func (x *ApprovalInfo) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *ApprovalInfo) GetGroupId() string {
+	if x != nil {
+		return x.GroupId
+	}
+	return ""
+}
+
+func (x *ApprovalInfo) GetMembers() []string {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *ApprovalInfo) GetExpiresAtUnix() int64 {
+	if x != nil {
+		return x.ExpiresAtUnix
+	}
+	return 0
+}
+
+type ApprovalStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"` // A deployment ID or approval group ID
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApprovalStatusRequest) Reset() {
+	*x = ApprovalStatusRequest{}
+	mi := &file_proto_deployment_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApprovalStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApprovalStatusRequest) ProtoMessage() {}
+
+func (x *ApprovalStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApprovalStatusRequest.ProtoReflect.Descriptor instead.
+func (*ApprovalStatusRequest) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ApprovalStatusRequest) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+type ApprovalStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Approval      *ApprovalInfo          `protobuf:"bytes,3,opt,name=approval,proto3" json:"approval,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	ErrorDetails  string                 `protobuf:"bytes,5,opt,name=error_details,json=errorDetails,proto3" json:"error_details,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApprovalStatusResponse) Reset() {
+	*x = ApprovalStatusResponse{}
+	mi := &file_proto_deployment_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApprovalStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApprovalStatusResponse) ProtoMessage() {}
+
+func (x *ApprovalStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApprovalStatusResponse.ProtoReflect.Descriptor instead.
+func (*ApprovalStatusResponse) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ApprovalStatusResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ApprovalStatusResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ApprovalStatusResponse) GetApproval() *ApprovalInfo {
+	if x != nil {
+		return x.Approval
+	}
+	return nil
+}
+
+func (x *ApprovalStatusResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *ApprovalStatusResponse) GetErrorDetails() string {
+	if x != nil {
+		return x.ErrorDetails
+	}
+	return ""
+}
+
+type PendingApprovalsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PendingApprovalsRequest) Reset() {
+	*x = PendingApprovalsRequest{}
+	mi := &file_proto_deployment_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PendingApprovalsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PendingApprovalsRequest) ProtoMessage() {}
+
+func (x *PendingApprovalsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PendingApprovalsRequest.ProtoReflect.Descriptor instead.
+func (*PendingApprovalsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{21}
+}
+
+type PendingApprovalsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Approvals     []*ApprovalInfo        `protobuf:"bytes,1,rep,name=approvals,proto3" json:"approvals,omitempty"` // Oldest first
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PendingApprovalsResponse) Reset() {
+	*x = PendingApprovalsResponse{}
+	mi := &file_proto_deployment_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PendingApprovalsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PendingApprovalsResponse) ProtoMessage() {}
+
+func (x *PendingApprovalsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PendingApprovalsResponse.ProtoReflect.Descriptor instead.
+func (*PendingApprovalsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PendingApprovalsResponse) GetApprovals() []*ApprovalInfo {
+	if x != nil {
+		return x.Approvals
+	}
+	return nil
+}
+
+type SummaryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SummaryRequest) Reset() {
+	*x = SummaryRequest{}
+	mi := &file_proto_deployment_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SummaryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SummaryRequest) ProtoMessage() {}
+
+func (x *SummaryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SummaryRequest.ProtoReflect.Descriptor instead.
+func (*SummaryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{23}
+}
+
+type ServiceSummary struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	ClusterArn           string                 `protobuf:"bytes,1,opt,name=cluster_arn,json=clusterArn,proto3" json:"cluster_arn,omitempty"`
+	ServiceName          string                 `protobuf:"bytes,2,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	LastDeploymentId     string                 `protobuf:"bytes,3,opt,name=last_deployment_id,json=lastDeploymentId,proto3" json:"last_deployment_id,omitempty"`
+	LastStatus           string                 `protobuf:"bytes,4,opt,name=last_status,json=lastStatus,proto3" json:"last_status,omitempty"`
+	LastMessage          string                 `protobuf:"bytes,5,opt,name=last_message,json=lastMessage,proto3" json:"last_message,omitempty"`
+	InFlightDeploymentId string                 `protobuf:"bytes,6,opt,name=in_flight_deployment_id,json=inFlightDeploymentId,proto3" json:"in_flight_deployment_id,omitempty"`
+	DriftMonitored       bool                   `protobuf:"varint,7,opt,name=drift_monitored,json=driftMonitored,proto3" json:"drift_monitored,omitempty"`
+	TotalDeployments     int64                  `protobuf:"varint,8,opt,name=total_deployments,json=totalDeployments,proto3" json:"total_deployments,omitempty"`
+	SuccessRate          float64                `protobuf:"fixed64,9,opt,name=success_rate,json=successRate,proto3" json:"success_rate,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ServiceSummary) Reset() {
+	*x = ServiceSummary{}
+	mi := &file_proto_deployment_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceSummary) ProtoMessage() {}
+
+func (x *ServiceSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceSummary.ProtoReflect.Descriptor instead.
+func (*ServiceSummary) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ServiceSummary) GetClusterArn() string {
+	if x != nil {
+		return x.ClusterArn
+	}
+	return ""
+}
+
+func (x *ServiceSummary) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *ServiceSummary) GetLastDeploymentId() string {
+	if x != nil {
+		return x.LastDeploymentId
+	}
+	return ""
+}
+
+func (x *ServiceSummary) GetLastStatus() string {
+	if x != nil {
+		return x.LastStatus
+	}
+	return ""
+}
+
+func (x *ServiceSummary) GetLastMessage() string {
+	if x != nil {
+		return x.LastMessage
+	}
+	return ""
+}
+
+func (x *ServiceSummary) GetInFlightDeploymentId() string {
+	if x != nil {
+		return x.InFlightDeploymentId
+	}
+	return ""
+}
+
+func (x *ServiceSummary) GetDriftMonitored() bool {
+	if x != nil {
+		return x.DriftMonitored
+	}
+	return false
+}
+
+func (x *ServiceSummary) GetTotalDeployments() int64 {
+	if x != nil {
+		return x.TotalDeployments
+	}
+	return 0
+}
+
+func (x *ServiceSummary) GetSuccessRate() float64 {
+	if x != nil {
+		return x.SuccessRate
+	}
+	return 0
+}
+
+type SummaryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Services      []*ServiceSummary      `protobuf:"bytes,1,rep,name=services,proto3" json:"services,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SummaryResponse) Reset() {
+	*x = SummaryResponse{}
+	mi := &file_proto_deployment_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SummaryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SummaryResponse) ProtoMessage() {}
+
+func (x *SummaryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SummaryResponse.ProtoReflect.Descriptor instead.
+func (*SummaryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SummaryResponse) GetServices() []*ServiceSummary {
+	if x != nil {
+		return x.Services
+	}
+	return nil
+}
+
+type ManifestRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ManifestRequest) Reset() {
+	*x = ManifestRequest{}
+	mi := &file_proto_deployment_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ManifestRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ManifestRequest) ProtoMessage() {}
+
+func (x *ManifestRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ManifestRequest.ProtoReflect.Descriptor instead.
+func (*ManifestRequest) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ManifestRequest) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+type ManifestResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ManifestJson  string                 `protobuf:"bytes,3,opt,name=manifest_json,json=manifestJson,proto3" json:"manifest_json,omitempty"` // JSON-encoded deployment manifest
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	ErrorDetails  string                 `protobuf:"bytes,5,opt,name=error_details,json=errorDetails,proto3" json:"error_details,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ManifestResponse) Reset() {
+	*x = ManifestResponse{}
+	mi := &file_proto_deployment_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ManifestResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ManifestResponse) ProtoMessage() {}
+
+func (x *ManifestResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ManifestResponse.ProtoReflect.Descriptor instead.
+func (*ManifestResponse) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ManifestResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ManifestResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ManifestResponse) GetManifestJson() string {
+	if x != nil {
+		return x.ManifestJson
+	}
+	return ""
+}
+
+func (x *ManifestResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *ManifestResponse) GetErrorDetails() string {
+	if x != nil {
+		return x.ErrorDetails
+	}
+	return ""
+}
+
+type ForceReleaseRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClusterArn    string                 `protobuf:"bytes,1,opt,name=cluster_arn,json=clusterArn,proto3" json:"cluster_arn,omitempty"`
+	ServiceName   string                 `protobuf:"bytes,2,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	Operator      string                 `protobuf:"bytes,3,opt,name=operator,proto3" json:"operator,omitempty"`
+	Reason        string                 `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ForceReleaseRequest) Reset() {
+	*x = ForceReleaseRequest{}
+	mi := &file_proto_deployment_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForceReleaseRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForceReleaseRequest) ProtoMessage() {}
+
+func (x *ForceReleaseRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForceReleaseRequest.ProtoReflect.Descriptor instead.
+func (*ForceReleaseRequest) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *ForceReleaseRequest) GetClusterArn() string {
+	if x != nil {
+		return x.ClusterArn
+	}
+	return ""
+}
+
+func (x *ForceReleaseRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *ForceReleaseRequest) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *ForceReleaseRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ForceReleaseResponse struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	Success              bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message              string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	ReleasedDeploymentId string                 `protobuf:"bytes,3,opt,name=released_deployment_id,json=releasedDeploymentId,proto3" json:"released_deployment_id,omitempty"`
+	ErrorCode            string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	ErrorDetails         string                 `protobuf:"bytes,5,opt,name=error_details,json=errorDetails,proto3" json:"error_details,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ForceReleaseResponse) Reset() {
+	*x = ForceReleaseResponse{}
+	mi := &file_proto_deployment_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ForceReleaseResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ForceReleaseResponse) ProtoMessage() {}
+
+func (x *ForceReleaseResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ForceReleaseResponse.ProtoReflect.Descriptor instead.
+func (*ForceReleaseResponse) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ForceReleaseResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *ForceReleaseResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *ForceReleaseResponse) GetReleasedDeploymentId() string {
+	if x != nil {
+		return x.ReleasedDeploymentId
+	}
+	return ""
+}
+
+func (x *ForceReleaseResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *ForceReleaseResponse) GetErrorDetails() string {
+	if x != nil {
+		return x.ErrorDetails
+	}
+	return ""
+}
+
+type APICallsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APICallsRequest) Reset() {
+	*x = APICallsRequest{}
+	mi := &file_proto_deployment_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APICallsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APICallsRequest) ProtoMessage() {}
+
+func (x *APICallsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APICallsRequest.ProtoReflect.Descriptor instead.
+func (*APICallsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *APICallsRequest) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+type APICall struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Service         string                 `protobuf:"bytes,1,opt,name=service,proto3" json:"service,omitempty"`
+	Operation       string                 `protobuf:"bytes,2,opt,name=operation,proto3" json:"operation,omitempty"`
+	Status          string                 `protobuf:"bytes,3,opt,name=status,proto3" json:"status,omitempty"`
+	DurationMs      int64                  `protobuf:"varint,4,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	TimestampUnixMs int64                  `protobuf:"varint,5,opt,name=timestamp_unix_ms,json=timestampUnixMs,proto3" json:"timestamp_unix_ms,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *APICall) Reset() {
+	*x = APICall{}
+	mi := &file_proto_deployment_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APICall) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APICall) ProtoMessage() {}
+
+func (x *APICall) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APICall.ProtoReflect.Descriptor instead.
+func (*APICall) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *APICall) GetService() string {
+	if x != nil {
+		return x.Service
+	}
+	return ""
+}
+
+func (x *APICall) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *APICall) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *APICall) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *APICall) GetTimestampUnixMs() int64 {
+	if x != nil {
+		return x.TimestampUnixMs
+	}
+	return 0
+}
+
+type APICallsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	Calls         []*APICall             `protobuf:"bytes,3,rep,name=calls,proto3" json:"calls,omitempty"` // In the order they were made
+	ErrorCode     string                 `protobuf:"bytes,4,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	ErrorDetails  string                 `protobuf:"bytes,5,opt,name=error_details,json=errorDetails,proto3" json:"error_details,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *APICallsResponse) Reset() {
+	*x = APICallsResponse{}
+	mi := &file_proto_deployment_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *APICallsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*APICallsResponse) ProtoMessage() {}
+
+func (x *APICallsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use APICallsResponse.ProtoReflect.Descriptor instead.
+func (*APICallsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *APICallsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *APICallsResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *APICallsResponse) GetCalls() []*APICall {
+	if x != nil {
+		return x.Calls
+	}
+	return nil
+}
+
+func (x *APICallsResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *APICallsResponse) GetErrorDetails() string {
+	if x != nil {
+		return x.ErrorDetails
+	}
+	return ""
+}
+
+type AnalyticsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Strategy      string                 `protobuf:"bytes,1,opt,name=strategy,proto3" json:"strategy,omitempty"` // Only count deployments using this strategy when set
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnalyticsRequest) Reset() {
+	*x = AnalyticsRequest{}
+	mi := &file_proto_deployment_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalyticsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalyticsRequest) ProtoMessage() {}
+
+func (x *AnalyticsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalyticsRequest.ProtoReflect.Descriptor instead.
+func (*AnalyticsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *AnalyticsRequest) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+type AnalyticsResponse struct {
+	state                         protoimpl.MessageState `protogen:"open.v1"`
+	TotalDeployments              int64                  `protobuf:"varint,1,opt,name=total_deployments,json=totalDeployments,proto3" json:"total_deployments,omitempty"`
+	SuccessfulDeployments         int64                  `protobuf:"varint,2,opt,name=successful_deployments,json=successfulDeployments,proto3" json:"successful_deployments,omitempty"`
+	FailedDeployments             int64                  `protobuf:"varint,3,opt,name=failed_deployments,json=failedDeployments,proto3" json:"failed_deployments,omitempty"`
+	CancelledDeployments          int64                  `protobuf:"varint,4,opt,name=cancelled_deployments,json=cancelledDeployments,proto3" json:"cancelled_deployments,omitempty"`
+	SuccessRate                   float64                `protobuf:"fixed64,5,opt,name=success_rate,json=successRate,proto3" json:"success_rate,omitempty"`
+	AverageDurationSeconds        float64                `protobuf:"fixed64,6,opt,name=average_duration_seconds,json=averageDurationSeconds,proto3" json:"average_duration_seconds,omitempty"`
+	TrimmedAverageDurationSeconds float64                `protobuf:"fixed64,7,opt,name=trimmed_average_duration_seconds,json=trimmedAverageDurationSeconds,proto3" json:"trimmed_average_duration_seconds,omitempty"`
+	FastestDurationSeconds        float64                `protobuf:"fixed64,8,opt,name=fastest_duration_seconds,json=fastestDurationSeconds,proto3" json:"fastest_duration_seconds,omitempty"`
+	SlowestDurationSeconds        float64                `protobuf:"fixed64,9,opt,name=slowest_duration_seconds,json=slowestDurationSeconds,proto3" json:"slowest_duration_seconds,omitempty"`
+	RawSlowestDurationSeconds     float64                `protobuf:"fixed64,10,opt,name=raw_slowest_duration_seconds,json=rawSlowestDurationSeconds,proto3" json:"raw_slowest_duration_seconds,omitempty"`
+	StrategyBreakdown             map[string]int64       `protobuf:"bytes,11,rep,name=strategy_breakdown,json=strategyBreakdown,proto3" json:"strategy_breakdown,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	ErrorBreakdown                map[string]int64       `protobuf:"bytes,12,rep,name=error_breakdown,json=errorBreakdown,proto3" json:"error_breakdown,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	LastDeploymentUnix            int64                  `protobuf:"varint,13,opt,name=last_deployment_unix,json=lastDeploymentUnix,proto3" json:"last_deployment_unix,omitempty"`
+	unknownFields                 protoimpl.UnknownFields
+	sizeCache                     protoimpl.SizeCache
+}
+
+func (x *AnalyticsResponse) Reset() {
+	*x = AnalyticsResponse{}
+	mi := &file_proto_deployment_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnalyticsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnalyticsResponse) ProtoMessage() {}
+
+func (x *AnalyticsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnalyticsResponse.ProtoReflect.Descriptor instead.
+func (*AnalyticsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *AnalyticsResponse) GetTotalDeployments() int64 {
+	if x != nil {
+		return x.TotalDeployments
+	}
+	return 0
+}
+
+func (x *AnalyticsResponse) GetSuccessfulDeployments() int64 {
+	if x != nil {
+		return x.SuccessfulDeployments
+	}
+	return 0
+}
+
+func (x *AnalyticsResponse) GetFailedDeployments() int64 {
+	if x != nil {
+		return x.FailedDeployments
+	}
+	return 0
+}
+
+func (x *AnalyticsResponse) GetCancelledDeployments() int64 {
+	if x != nil {
+		return x.CancelledDeployments
+	}
+	return 0
+}
+
+func (x *AnalyticsResponse) GetSuccessRate() float64 {
+	if x != nil {
+		return x.SuccessRate
+	}
+	return 0
+}
+
+func (x *AnalyticsResponse) GetAverageDurationSeconds() float64 {
+	if x != nil {
+		return x.AverageDurationSeconds
+	}
+	return 0
+}
+
+func (x *AnalyticsResponse) GetTrimmedAverageDurationSeconds() float64 {
+	if x != nil {
+		return x.TrimmedAverageDurationSeconds
+	}
+	return 0
+}
+
+func (x *AnalyticsResponse) GetFastestDurationSeconds() float64 {
+	if x != nil {
+		return x.FastestDurationSeconds
+	}
+	return 0
+}
+
+func (x *AnalyticsResponse) GetSlowestDurationSeconds() float64 {
+	if x != nil {
+		return x.SlowestDurationSeconds
+	}
+	return 0
+}
+
+func (x *AnalyticsResponse) GetRawSlowestDurationSeconds() float64 {
+	if x != nil {
+		return x.RawSlowestDurationSeconds
+	}
+	return 0
+}
+
+func (x *AnalyticsResponse) GetStrategyBreakdown() map[string]int64 {
+	if x != nil {
+		return x.StrategyBreakdown
+	}
+	return nil
+}
+
+func (x *AnalyticsResponse) GetErrorBreakdown() map[string]int64 {
+	if x != nil {
+		return x.ErrorBreakdown
+	}
+	return nil
+}
+
+func (x *AnalyticsResponse) GetLastDeploymentUnix() int64 {
+	if x != nil {
+		return x.LastDeploymentUnix
+	}
+	return 0
+}
+
+// Key Prometheus counters as plain numbers, for clients that cannot scrape
+// the metrics endpoint. Counters run from server start.
+type MetricsResponse struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentsByStatus   map[string]int64       `protobuf:"bytes,1,rep,name=deployments_by_status,json=deploymentsByStatus,proto3" json:"deployments_by_status,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"` // success, failed, cancelled
+	DeploymentsTotal      int64                  `protobuf:"varint,2,opt,name=deployments_total,json=deploymentsTotal,proto3" json:"deployments_total,omitempty"`
+	DeploymentsInProgress int64                  `protobuf:"varint,3,opt,name=deployments_in_progress,json=deploymentsInProgress,proto3" json:"deployments_in_progress,omitempty"`
+	AwsApiCalls           int64                  `protobuf:"varint,4,opt,name=aws_api_calls,json=awsApiCalls,proto3" json:"aws_api_calls,omitempty"`
+	AwsApiErrors          int64                  `protobuf:"varint,5,opt,name=aws_api_errors,json=awsApiErrors,proto3" json:"aws_api_errors,omitempty"`
+	ErrorsByComponent     map[string]int64       `protobuf:"bytes,6,rep,name=errors_by_component,json=errorsByComponent,proto3" json:"errors_by_component,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *MetricsResponse) Reset() {
+	*x = MetricsResponse{}
+	mi := &file_proto_deployment_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MetricsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MetricsResponse) ProtoMessage() {}
+
+func (x *MetricsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MetricsResponse.ProtoReflect.Descriptor instead.
+func (*MetricsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *MetricsResponse) GetDeploymentsByStatus() map[string]int64 {
+	if x != nil {
+		return x.DeploymentsByStatus
+	}
+	return nil
+}
+
+func (x *MetricsResponse) GetDeploymentsTotal() int64 {
+	if x != nil {
+		return x.DeploymentsTotal
+	}
+	return 0
+}
+
+func (x *MetricsResponse) GetDeploymentsInProgress() int64 {
+	if x != nil {
+		return x.DeploymentsInProgress
+	}
+	return 0
+}
+
+func (x *MetricsResponse) GetAwsApiCalls() int64 {
+	if x != nil {
+		return x.AwsApiCalls
+	}
+	return 0
+}
+
+func (x *MetricsResponse) GetAwsApiErrors() int64 {
+	if x != nil {
+		return x.AwsApiErrors
+	}
+	return 0
+}
+
+func (x *MetricsResponse) GetErrorsByComponent() map[string]int64 {
+	if x != nil {
+		return x.ErrorsByComponent
+	}
+	return nil
+}
+
+type ListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"` // Only list deployments in this status when set
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`  // Most recent deployments first; 0 returns all
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRequest) Reset() {
+	*x = ListRequest{}
+	mi := &file_proto_deployment_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRequest) ProtoMessage() {}
+
+func (x *ListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRequest.ProtoReflect.Descriptor instead.
+func (*ListRequest) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListRequest) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *ListRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type DeploymentSummary struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Strategy      string                 `protobuf:"bytes,3,opt,name=strategy,proto3" json:"strategy,omitempty"`
+	StartTimeUnix int64                  `protobuf:"varint,4,opt,name=start_time_unix,json=startTimeUnix,proto3" json:"start_time_unix,omitempty"`
+	EndTimeUnix   int64                  `protobuf:"varint,5,opt,name=end_time_unix,json=endTimeUnix,proto3" json:"end_time_unix,omitempty"` // 0 while running
+	Progress      int32                  `protobuf:"varint,6,opt,name=progress,proto3" json:"progress,omitempty"`
+	ClusterArn    string                 `protobuf:"bytes,7,opt,name=cluster_arn,json=clusterArn,proto3" json:"cluster_arn,omitempty"`
+	ServiceName   string                 `protobuf:"bytes,8,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	Message       string                 `protobuf:"bytes,9,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeploymentSummary) Reset() {
+	*x = DeploymentSummary{}
+	mi := &file_proto_deployment_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeploymentSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeploymentSummary) ProtoMessage() {}
+
+func (x *DeploymentSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeploymentSummary.ProtoReflect.Descriptor instead.
+func (*DeploymentSummary) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *DeploymentSummary) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *DeploymentSummary) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *DeploymentSummary) GetStrategy() string {
+	if x != nil {
+		return x.Strategy
+	}
+	return ""
+}
+
+func (x *DeploymentSummary) GetStartTimeUnix() int64 {
+	if x != nil {
+		return x.StartTimeUnix
+	}
+	return 0
+}
+
+func (x *DeploymentSummary) GetEndTimeUnix() int64 {
+	if x != nil {
+		return x.EndTimeUnix
+	}
+	return 0
+}
+
+func (x *DeploymentSummary) GetProgress() int32 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *DeploymentSummary) GetClusterArn() string {
+	if x != nil {
+		return x.ClusterArn
+	}
+	return ""
+}
+
+func (x *DeploymentSummary) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *DeploymentSummary) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type ListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Deployments   []*DeploymentSummary   `protobuf:"bytes,1,rep,name=deployments,proto3" json:"deployments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListResponse) Reset() {
+	*x = ListResponse{}
+	mi := &file_proto_deployment_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListResponse) ProtoMessage() {}
+
+func (x *ListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListResponse.ProtoReflect.Descriptor instead.
+func (*ListResponse) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListResponse) GetDeployments() []*DeploymentSummary {
+	if x != nil {
+		return x.Deployments
+	}
+	return nil
+}
+
+type Empty struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Empty) Reset() {
+	*x = Empty{}
+	mi := &file_proto_deployment_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Empty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Empty) ProtoMessage() {}
+
+func (x *Empty) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{39}
+}
+
+type StrategyInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	ConfigKeys    []string               `protobuf:"bytes,3,rep,name=config_keys,json=configKeys,proto3" json:"config_keys,omitempty"` // Deployment config keys the strategy reads
+	SupportsPause bool                   `protobuf:"varint,4,opt,name=supports_pause,json=supportsPause,proto3" json:"supports_pause,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StrategyInfo) Reset() {
+	*x = StrategyInfo{}
+	mi := &file_proto_deployment_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StrategyInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StrategyInfo) ProtoMessage() {}
+
+func (x *StrategyInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StrategyInfo.ProtoReflect.Descriptor instead.
+func (*StrategyInfo) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *StrategyInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *StrategyInfo) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *StrategyInfo) GetConfigKeys() []string {
+	if x != nil {
+		return x.ConfigKeys
+	}
+	return nil
+}
+
+func (x *StrategyInfo) GetSupportsPause() bool {
+	if x != nil {
+		return x.SupportsPause
+	}
+	return false
+}
+
+type StrategiesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Strategies    []*StrategyInfo        `protobuf:"bytes,1,rep,name=strategies,proto3" json:"strategies,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StrategiesResponse) Reset() {
+	*x = StrategiesResponse{}
+	mi := &file_proto_deployment_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StrategiesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StrategiesResponse) ProtoMessage() {}
+
+func (x *StrategiesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StrategiesResponse.ProtoReflect.Descriptor instead.
+func (*StrategiesResponse) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *StrategiesResponse) GetStrategies() []*StrategyInfo {
+	if x != nil {
+		return x.Strategies
+	}
+	return nil
+}
+
+type BatchDeployRequest struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	BatchId     string                 `protobuf:"bytes,1,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+	Deployments []*DeployRequest       `protobuf:"bytes,2,rep,name=deployments,proto3" json:"deployments,omitempty"`
+	// Validate every deployment before starting any, stop at the first one
+	// that fails to start, and roll back the already-deployed services if
+	// any deployment in the batch fails
+	AllOrNothing  bool `protobuf:"varint,3,opt,name=all_or_nothing,json=allOrNothing,proto3" json:"all_or_nothing,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchDeployRequest) Reset() {
+	*x = BatchDeployRequest{}
+	mi := &file_proto_deployment_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchDeployRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchDeployRequest) ProtoMessage() {}
+
+func (x *BatchDeployRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchDeployRequest.ProtoReflect.Descriptor instead.
+func (*BatchDeployRequest) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *BatchDeployRequest) GetBatchId() string {
+	if x != nil {
+		return x.BatchId
+	}
+	return ""
+}
+
+func (x *BatchDeployRequest) GetDeployments() []*DeployRequest {
+	if x != nil {
+		return x.Deployments
+	}
+	return nil
+}
+
+func (x *BatchDeployRequest) GetAllOrNothing() bool {
+	if x != nil {
+		return x.AllOrNothing
+	}
+	return false
+}
+
+type BatchDeployResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeploymentId  string                 `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`
+	ClusterArn    string                 `protobuf:"bytes,2,opt,name=cluster_arn,json=clusterArn,proto3" json:"cluster_arn,omitempty"`
+	ServiceName   string                 `protobuf:"bytes,3,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	Success       bool                   `protobuf:"varint,4,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,5,opt,name=message,proto3" json:"message,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,6,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchDeployResult) Reset() {
+	*x = BatchDeployResult{}
+	mi := &file_proto_deployment_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchDeployResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchDeployResult) ProtoMessage() {}
+
+func (x *BatchDeployResult) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchDeployResult.ProtoReflect.Descriptor instead.
+func (*BatchDeployResult) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *BatchDeployResult) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *BatchDeployResult) GetClusterArn() string {
+	if x != nil {
+		return x.ClusterArn
+	}
+	return ""
+}
+
+func (x *BatchDeployResult) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *BatchDeployResult) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *BatchDeployResult) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *BatchDeployResult) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type BatchDeployResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message       string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	BatchId       string                 `protobuf:"bytes,3,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+	Results       []*BatchDeployResult   `protobuf:"bytes,4,rep,name=results,proto3" json:"results,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,5,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	ErrorDetails  string                 `protobuf:"bytes,6,opt,name=error_details,json=errorDetails,proto3" json:"error_details,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchDeployResponse) Reset() {
+	*x = BatchDeployResponse{}
+	mi := &file_proto_deployment_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchDeployResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchDeployResponse) ProtoMessage() {}
+
+func (x *BatchDeployResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchDeployResponse.ProtoReflect.Descriptor instead.
+func (*BatchDeployResponse) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *BatchDeployResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *BatchDeployResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *BatchDeployResponse) GetBatchId() string {
+	if x != nil {
+		return x.BatchId
+	}
+	return ""
+}
+
+func (x *BatchDeployResponse) GetResults() []*BatchDeployResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *BatchDeployResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *BatchDeployResponse) GetErrorDetails() string {
+	if x != nil {
+		return x.ErrorDetails
+	}
+	return ""
+}
+
+type BatchStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BatchId       string                 `protobuf:"bytes,1,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchStatusRequest) Reset() {
+	*x = BatchStatusRequest{}
+	mi := &file_proto_deployment_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchStatusRequest) ProtoMessage() {}
+
+func (x *BatchStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchStatusRequest.ProtoReflect.Descriptor instead.
+func (*BatchStatusRequest) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *BatchStatusRequest) GetBatchId() string {
+	if x != nil {
+		return x.BatchId
+	}
+	return ""
+}
+
+type BatchStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	BatchId       string                 `protobuf:"bytes,1,opt,name=batch_id,json=batchId,proto3" json:"batch_id,omitempty"`
+	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"` // RUNNING, SUCCESS, FAILED or ROLLED_BACK
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	AllOrNothing  bool                   `protobuf:"varint,4,opt,name=all_or_nothing,json=allOrNothing,proto3" json:"all_or_nothing,omitempty"`
+	Deployments   []*DeploymentSummary   `protobuf:"bytes,5,rep,name=deployments,proto3" json:"deployments,omitempty"`
+	ErrorCode     string                 `protobuf:"bytes,6,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchStatusResponse) Reset() {
+	*x = BatchStatusResponse{}
+	mi := &file_proto_deployment_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BatchStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BatchStatusResponse) ProtoMessage() {}
+
+func (x *BatchStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BatchStatusResponse.ProtoReflect.Descriptor instead.
+func (*BatchStatusResponse) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *BatchStatusResponse) GetBatchId() string {
+	if x != nil {
+		return x.BatchId
+	}
+	return ""
+}
+
+func (x *BatchStatusResponse) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *BatchStatusResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *BatchStatusResponse) GetAllOrNothing() bool {
+	if x != nil {
+		return x.AllOrNothing
+	}
+	return false
+}
+
+func (x *BatchStatusResponse) GetDeployments() []*DeploymentSummary {
+	if x != nil {
+		return x.Deployments
+	}
+	return nil
+}
+
+func (x *BatchStatusResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+type DiffRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ClusterArn     string                 `protobuf:"bytes,1,opt,name=cluster_arn,json=clusterArn,proto3" json:"cluster_arn,omitempty"`
+	ServiceName    string                 `protobuf:"bytes,2,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	TaskDefinition string                 `protobuf:"bytes,3,opt,name=task_definition,json=taskDefinition,proto3" json:"task_definition,omitempty"` // Task definition JSON about to be deployed
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *DiffRequest) Reset() {
+	*x = DiffRequest{}
+	mi := &file_proto_deployment_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffRequest) ProtoMessage() {}
+
+func (x *DiffRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffRequest.ProtoReflect.Descriptor instead.
+func (*DiffRequest) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *DiffRequest) GetClusterArn() string {
+	if x != nil {
+		return x.ClusterArn
+	}
+	return ""
+}
+
+func (x *DiffRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *DiffRequest) GetTaskDefinition() string {
+	if x != nil {
+		return x.TaskDefinition
+	}
+	return ""
+}
+
+type FieldChange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`         // e.g. image, cpu, env.LOG_LEVEL, secret.DB_PASSWORD, port_mappings
+	Container     string                 `protobuf:"bytes,2,opt,name=container,proto3" json:"container,omitempty"` // Empty for task-level fields
+	Change        string                 `protobuf:"bytes,3,opt,name=change,proto3" json:"change,omitempty"`       // added, removed or modified
+	OldValue      string                 `protobuf:"bytes,4,opt,name=old_value,json=oldValue,proto3" json:"old_value,omitempty"`
+	NewValue      string                 `protobuf:"bytes,5,opt,name=new_value,json=newValue,proto3" json:"new_value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldChange) Reset() {
+	*x = FieldChange{}
+	mi := &file_proto_deployment_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldChange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldChange) ProtoMessage() {}
+
+func (x *FieldChange) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldChange.ProtoReflect.Descriptor instead.
+func (*FieldChange) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *FieldChange) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *FieldChange) GetContainer() string {
+	if x != nil {
+		return x.Container
+	}
+	return ""
+}
+
+func (x *FieldChange) GetChange() string {
+	if x != nil {
+		return x.Change
+	}
+	return ""
+}
+
+func (x *FieldChange) GetOldValue() string {
+	if x != nil {
+		return x.OldValue
+	}
+	return ""
+}
+
+func (x *FieldChange) GetNewValue() string {
+	if x != nil {
+		return x.NewValue
+	}
+	return ""
+}
+
+type DiffResponse struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Success               bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message               string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	CurrentTaskDefinition string                 `protobuf:"bytes,3,opt,name=current_task_definition,json=currentTaskDefinition,proto3" json:"current_task_definition,omitempty"`
+	Changes               []*FieldChange         `protobuf:"bytes,4,rep,name=changes,proto3" json:"changes,omitempty"`
+	ErrorCode             string                 `protobuf:"bytes,5,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	ErrorDetails          string                 `protobuf:"bytes,6,opt,name=error_details,json=errorDetails,proto3" json:"error_details,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *DiffResponse) Reset() {
+	*x = DiffResponse{}
+	mi := &file_proto_deployment_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DiffResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DiffResponse) ProtoMessage() {}
+
+func (x *DiffResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DiffResponse.ProtoReflect.Descriptor instead.
+func (*DiffResponse) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *DiffResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DiffResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *DiffResponse) GetCurrentTaskDefinition() string {
+	if x != nil {
+		return x.CurrentTaskDefinition
+	}
+	return ""
+}
+
+func (x *DiffResponse) GetChanges() []*FieldChange {
+	if x != nil {
+		return x.Changes
+	}
+	return nil
+}
+
+func (x *DiffResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *DiffResponse) GetErrorDetails() string {
+	if x != nil {
+		return x.ErrorDetails
+	}
+	return ""
+}
+
+type DriftRequest struct {
+	state                  protoimpl.MessageState `protogen:"open.v1"`
+	ClusterArn             string                 `protobuf:"bytes,1,opt,name=cluster_arn,json=clusterArn,proto3" json:"cluster_arn,omitempty"`
+	ServiceName            string                 `protobuf:"bytes,2,opt,name=service_name,json=serviceName,proto3" json:"service_name,omitempty"`
+	ExpectedTaskDefinition string                 `protobuf:"bytes,3,opt,name=expected_task_definition,json=expectedTaskDefinition,proto3" json:"expected_task_definition,omitempty"` // ARN or family:revision the service should run
+	Operator               string                 `protobuf:"bytes,4,opt,name=operator,proto3" json:"operator,omitempty"`                                                             // Recorded in the audit log by ReconcileDrift
+	unknownFields          protoimpl.UnknownFields
+	sizeCache              protoimpl.SizeCache
+}
+
+func (x *DriftRequest) Reset() {
+	*x = DriftRequest{}
+	mi := &file_proto_deployment_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriftRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriftRequest) ProtoMessage() {}
+
+func (x *DriftRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriftRequest.ProtoReflect.Descriptor instead.
+func (*DriftRequest) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *DriftRequest) GetClusterArn() string {
+	if x != nil {
+		return x.ClusterArn
+	}
+	return ""
+}
+
+func (x *DriftRequest) GetServiceName() string {
+	if x != nil {
+		return x.ServiceName
+	}
+	return ""
+}
+
+func (x *DriftRequest) GetExpectedTaskDefinition() string {
+	if x != nil {
+		return x.ExpectedTaskDefinition
+	}
+	return ""
+}
+
+func (x *DriftRequest) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+type DriftDetail struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`         // e.g. task_definition, image, cpu, env.LOG_LEVEL, running_count
+	Container     string                 `protobuf:"bytes,2,opt,name=container,proto3" json:"container,omitempty"` // Empty for service- and task-level fields
+	Expected      string                 `protobuf:"bytes,3,opt,name=expected,proto3" json:"expected,omitempty"`
+	Actual        string                 `protobuf:"bytes,4,opt,name=actual,proto3" json:"actual,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DriftDetail) Reset() {
+	*x = DriftDetail{}
+	mi := &file_proto_deployment_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriftDetail) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriftDetail) ProtoMessage() {}
+
+func (x *DriftDetail) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriftDetail.ProtoReflect.Descriptor instead.
+func (*DriftDetail) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *DriftDetail) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *DriftDetail) GetContainer() string {
+	if x != nil {
+		return x.Container
+	}
+	return ""
+}
+
+func (x *DriftDetail) GetExpected() string {
+	if x != nil {
+		return x.Expected
+	}
+	return ""
+}
+
+func (x *DriftDetail) GetActual() string {
+	if x != nil {
+		return x.Actual
+	}
+	return ""
+}
+
+type DriftResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Success          bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	Message          string                 `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	DriftStatus      string                 `protobuf:"bytes,3,opt,name=drift_status,json=driftStatus,proto3" json:"drift_status,omitempty"` // none, detected or fixed
+	Drifts           []*DriftDetail         `protobuf:"bytes,4,rep,name=drifts,proto3" json:"drifts,omitempty"`
+	DetectedAtUnix   int64                  `protobuf:"varint,5,opt,name=detected_at_unix,json=detectedAtUnix,proto3" json:"detected_at_unix,omitempty"`
+	ReconciledAtUnix int64                  `protobuf:"varint,6,opt,name=reconciled_at_unix,json=reconciledAtUnix,proto3" json:"reconciled_at_unix,omitempty"` // 0 unless ReconcileDrift changed the service
+	ReconcileAction  string                 `protobuf:"bytes,7,opt,name=reconcile_action,json=reconcileAction,proto3" json:"reconcile_action,omitempty"`
+	ErrorCode        string                 `protobuf:"bytes,8,opt,name=error_code,json=errorCode,proto3" json:"error_code,omitempty"`
+	ErrorDetails     string                 `protobuf:"bytes,9,opt,name=error_details,json=errorDetails,proto3" json:"error_details,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *DriftResponse) Reset() {
+	*x = DriftResponse{}
+	mi := &file_proto_deployment_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DriftResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DriftResponse) ProtoMessage() {}
+
+func (x *DriftResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_deployment_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DriftResponse.ProtoReflect.Descriptor instead.
+func (*DriftResponse) Descriptor() ([]byte, []int) {
+	return file_proto_deployment_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *DriftResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+func (x *DriftResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *DriftResponse) GetDriftStatus() string {
+	if x != nil {
+		return x.DriftStatus
+	}
+	return ""
+}
+
+func (x *DriftResponse) GetDrifts() []*DriftDetail {
+	if x != nil {
+		return x.Drifts
+	}
+	return nil
+}
+
+func (x *DriftResponse) GetDetectedAtUnix() int64 {
+	if x != nil {
+		return x.DetectedAtUnix
+	}
+	return 0
+}
+
+func (x *DriftResponse) GetReconciledAtUnix() int64 {
+	if x != nil {
+		return x.ReconciledAtUnix
+	}
+	return 0
+}
+
+func (x *DriftResponse) GetReconcileAction() string {
+	if x != nil {
+		return x.ReconcileAction
+	}
+	return ""
+}
+
+func (x *DriftResponse) GetErrorCode() string {
+	if x != nil {
+		return x.ErrorCode
+	}
+	return ""
+}
+
+func (x *DriftResponse) GetErrorDetails() string {
+	if x != nil {
+		return x.ErrorDetails
+	}
+	return ""
+}
+
+var File_proto_deployment_proto protoreflect.FileDescriptor
+
+const file_proto_deployment_proto_rawDesc = "" +
+	"\n" +
+	"\x16proto/deployment.proto\x12\n" +
+	"deployment\"\xdc\x03\n" +
 	"\rDeployRequest\x12#\n" +
 	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12\x1f\n" +
 	"\vcluster_arn\x18\x02 \x01(\tR\n" +
@@ -581,10 +3805,16 @@ const file_proto_deployment_proto_rawDesc = "" +
 	"\x0ftask_definition\x18\x04 \x01(\tR\x0etaskDefinition\x12\x1a\n" +
 	"\bstrategy\x18\x05 \x01(\tR\bstrategy\x12=\n" +
 	"\x06config\x18\x06 \x03(\v2%.deployment.DeployRequest.ConfigEntryR\x06config\x12)\n" +
-	"\x10require_approval\x18\a \x01(\bR\x0frequireApproval\x1a9\n" +
+	"\x10require_approval\x18\a \x01(\bR\x0frequireApproval\x12\x1d\n" +
+	"\n" +
+	"depends_on\x18\b \x03(\tR\tdependsOn\x12\x17\n" +
+	"\adry_run\x18\t \x01(\bR\x06dryRun\x12\x14\n" +
+	"\x05force\x18\n" +
+	" \x01(\bR\x05force\x12*\n" +
+	"\x11scheduled_at_unix\x18\v \x01(\x03R\x0fscheduledAtUnix\x1a9\n" +
 	"\vConfigEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xd8\x01\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xcd\x02\n" +
 	"\x0eDeployResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12#\n" +
@@ -592,40 +3822,345 @@ const file_proto_deployment_proto_rawDesc = "" +
 	"\n" +
 	"error_code\x18\x04 \x01(\tR\terrorCode\x12#\n" +
 	"\rerror_details\x18\x05 \x01(\tR\ferrorDetails\x12)\n" +
-	"\x10pending_approval\x18\x06 \x01(\bR\x0fpendingApproval\"4\n" +
+	"\x10pending_approval\x18\x06 \x01(\bR\x0fpendingApproval\x12#\n" +
+	"\rplanned_steps\x18\a \x03(\tR\fplannedSteps\x12%\n" +
+	"\x0equeue_position\x18\b \x01(\x05R\rqueuePosition\x12'\n" +
+	"\x0fexisting_status\x18\t \x01(\tR\x0eexistingStatus\"4\n" +
 	"\rStatusRequest\x12#\n" +
-	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\"\xa2\x01\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\"\x9a\x03\n" +
 	"\x0eStatusResponse\x12\x16\n" +
 	"\x06status\x18\x01 \x01(\tR\x06status\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1a\n" +
 	"\bprogress\x18\x03 \x01(\x05R\bprogress\x12\x1d\n" +
 	"\n" +
 	"error_code\x18\x04 \x01(\tR\terrorCode\x12#\n" +
-	"\rerror_details\x18\x05 \x01(\tR\ferrorDetails\"z\n" +
+	"\rerror_details\x18\x05 \x01(\tR\ferrorDetails\x12\x1f\n" +
+	"\veta_seconds\x18\x06 \x01(\x03R\n" +
+	"etaSeconds\x12\x1a\n" +
+	"\bstrategy\x18\a \x01(\tR\bstrategy\x12\x1f\n" +
+	"\vcluster_arn\x18\b \x01(\tR\n" +
+	"clusterArn\x12!\n" +
+	"\fservice_name\x18\t \x01(\tR\vserviceName\x12&\n" +
+	"\x0fstart_time_unix\x18\n" +
+	" \x01(\x03R\rstartTimeUnix\x12\"\n" +
+	"\rend_time_unix\x18\v \x01(\x03R\vendTimeUnix\x12)\n" +
+	"\x10duration_seconds\x18\f \x01(\x01R\x0fdurationSeconds\"\xd9\x01\n" +
 	"\x0fRollbackRequest\x12#\n" +
 	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12\x1f\n" +
 	"\vcluster_arn\x18\x02 \x01(\tR\n" +
 	"clusterArn\x12!\n" +
-	"\fservice_name\x18\x03 \x01(\tR\vserviceName\"\x8a\x01\n" +
+	"\fservice_name\x18\x03 \x01(\tR\vserviceName\x124\n" +
+	"\atargets\x18\x04 \x03(\v2\x1a.deployment.RollbackTargetR\atargets\x12'\n" +
+	"\x0ftask_definition\x18\x05 \x01(\tR\x0etaskDefinition\"}\n" +
+	"\x0eRollbackTarget\x12\x1f\n" +
+	"\vcluster_arn\x18\x01 \x01(\tR\n" +
+	"clusterArn\x12!\n" +
+	"\fservice_name\x18\x02 \x01(\tR\vserviceName\x12'\n" +
+	"\x0ftask_definition\x18\x03 \x01(\tR\x0etaskDefinition\"\xef\x01\n" +
 	"\x10RollbackResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
 	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1d\n" +
 	"\n" +
 	"error_code\x18\x03 \x01(\tR\terrorCode\x12#\n" +
-	"\rerror_details\x18\x04 \x01(\tR\ferrorDetails\"\x86\x01\n" +
+	"\rerror_details\x18\x04 \x01(\tR\ferrorDetails\x12:\n" +
+	"\aresults\x18\x05 \x03(\v2 .deployment.RollbackTargetResultR\aresults\x12'\n" +
+	"\x0fpartial_success\x18\x06 \x01(\bR\x0epartialSuccess\"\x8e\x01\n" +
+	"\x14RollbackTargetResult\x12\x1f\n" +
+	"\vcluster_arn\x18\x01 \x01(\tR\n" +
+	"clusterArn\x12!\n" +
+	"\fservice_name\x18\x02 \x01(\tR\vserviceName\x12\x18\n" +
+	"\asuccess\x18\x03 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x04 \x01(\tR\amessage\"4\n" +
+	"\rCancelRequest\x12#\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\"\xa0\x01\n" +
+	"\x0eCancelResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\x12#\n" +
+	"\rerror_details\x18\x05 \x01(\tR\ferrorDetails\"_\n" +
+	"\fRetryRequest\x12#\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12*\n" +
+	"\x11new_deployment_id\x18\x02 \x01(\tR\x0fnewDeploymentId\"\xf6\x01\n" +
+	"\rRetryResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12#\n" +
+	"\rdeployment_id\x18\x03 \x01(\tR\fdeploymentId\x12!\n" +
+	"\fretried_from\x18\x04 \x01(\tR\vretriedFrom\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x05 \x01(\tR\terrorCode\x12#\n" +
+	"\rerror_details\x18\x06 \x01(\tR\ferrorDetails\x12%\n" +
+	"\x0equeue_position\x18\a \x01(\x05R\rqueuePosition\"3\n" +
+	"\fPauseRequest\x12#\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\"\x9f\x01\n" +
+	"\rPauseResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\x12#\n" +
+	"\rerror_details\x18\x05 \x01(\tR\ferrorDetails\"4\n" +
+	"\rResumeRequest\x12#\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\"\xa0\x01\n" +
+	"\x0eResumeResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\x12#\n" +
+	"\rerror_details\x18\x05 \x01(\tR\ferrorDetails\"\x86\x01\n" +
 	"\x0fApprovalRequest\x12#\n" +
 	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12\x1a\n" +
 	"\bapproved\x18\x02 \x01(\bR\bapproved\x12\x1a\n" +
 	"\bapprover\x18\x03 \x01(\tR\bapprover\x12\x16\n" +
-	"\x06reason\x18\x04 \x01(\tR\x06reason\"F\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"\x8a\x01\n" +
 	"\x10ApprovalResponse\x12\x18\n" +
 	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
-	"\amessage\x18\x02 \x01(\tR\amessage2\xaf\x02\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x03 \x01(\tR\terrorCode\x12#\n" +
+	"\rerror_details\x18\x04 \x01(\tR\ferrorDetails\"\xe8\x02\n" +
+	"\fApprovalInfo\x12#\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12\x1f\n" +
+	"\vcluster_arn\x18\x02 \x01(\tR\n" +
+	"clusterArn\x12!\n" +
+	"\fservice_name\x18\x03 \x01(\tR\vserviceName\x12\x1a\n" +
+	"\bstrategy\x18\x04 \x01(\tR\bstrategy\x12*\n" +
+	"\x11requested_at_unix\x18\x05 \x01(\x03R\x0frequestedAtUnix\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1a\n" +
+	"\bapprover\x18\a \x01(\tR\bapprover\x12\x16\n" +
+	"\x06reason\x18\b \x01(\tR\x06reason\x12\x19\n" +
+	"\bgroup_id\x18\t \x01(\tR\agroupId\x12\x18\n" +
+	"\amembers\x18\n" +
+	" \x03(\tR\amembers\x12&\n" +
+	"\x0fexpires_at_unix\x18\v \x01(\x03R\rexpiresAtUnix\"<\n" +
+	"\x15ApprovalStatusRequest\x12#\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\"\xc6\x01\n" +
+	"\x16ApprovalStatusResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x124\n" +
+	"\bapproval\x18\x03 \x01(\v2\x18.deployment.ApprovalInfoR\bapproval\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\x12#\n" +
+	"\rerror_details\x18\x05 \x01(\tR\ferrorDetails\"\x19\n" +
+	"\x17PendingApprovalsRequest\"R\n" +
+	"\x18PendingApprovalsResponse\x126\n" +
+	"\tapprovals\x18\x01 \x03(\v2\x18.deployment.ApprovalInfoR\tapprovals\"\x10\n" +
+	"\x0eSummaryRequest\"\xf6\x02\n" +
+	"\x0eServiceSummary\x12\x1f\n" +
+	"\vcluster_arn\x18\x01 \x01(\tR\n" +
+	"clusterArn\x12!\n" +
+	"\fservice_name\x18\x02 \x01(\tR\vserviceName\x12,\n" +
+	"\x12last_deployment_id\x18\x03 \x01(\tR\x10lastDeploymentId\x12\x1f\n" +
+	"\vlast_status\x18\x04 \x01(\tR\n" +
+	"lastStatus\x12!\n" +
+	"\flast_message\x18\x05 \x01(\tR\vlastMessage\x125\n" +
+	"\x17in_flight_deployment_id\x18\x06 \x01(\tR\x14inFlightDeploymentId\x12'\n" +
+	"\x0fdrift_monitored\x18\a \x01(\bR\x0edriftMonitored\x12+\n" +
+	"\x11total_deployments\x18\b \x01(\x03R\x10totalDeployments\x12!\n" +
+	"\fsuccess_rate\x18\t \x01(\x01R\vsuccessRate\"I\n" +
+	"\x0fSummaryResponse\x126\n" +
+	"\bservices\x18\x01 \x03(\v2\x1a.deployment.ServiceSummaryR\bservices\"6\n" +
+	"\x0fManifestRequest\x12#\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\"\xaf\x01\n" +
+	"\x10ManifestResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12#\n" +
+	"\rmanifest_json\x18\x03 \x01(\tR\fmanifestJson\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\x12#\n" +
+	"\rerror_details\x18\x05 \x01(\tR\ferrorDetails\"\x8d\x01\n" +
+	"\x13ForceReleaseRequest\x12\x1f\n" +
+	"\vcluster_arn\x18\x01 \x01(\tR\n" +
+	"clusterArn\x12!\n" +
+	"\fservice_name\x18\x02 \x01(\tR\vserviceName\x12\x1a\n" +
+	"\boperator\x18\x03 \x01(\tR\boperator\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"\xc4\x01\n" +
+	"\x14ForceReleaseResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x124\n" +
+	"\x16released_deployment_id\x18\x03 \x01(\tR\x14releasedDeploymentId\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\x12#\n" +
+	"\rerror_details\x18\x05 \x01(\tR\ferrorDetails\"6\n" +
+	"\x0fAPICallsRequest\x12#\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\"\xa6\x01\n" +
+	"\aAPICall\x12\x18\n" +
+	"\aservice\x18\x01 \x01(\tR\aservice\x12\x1c\n" +
+	"\toperation\x18\x02 \x01(\tR\toperation\x12\x16\n" +
+	"\x06status\x18\x03 \x01(\tR\x06status\x12\x1f\n" +
+	"\vduration_ms\x18\x04 \x01(\x03R\n" +
+	"durationMs\x12*\n" +
+	"\x11timestamp_unix_ms\x18\x05 \x01(\x03R\x0ftimestampUnixMs\"\xb5\x01\n" +
+	"\x10APICallsResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12)\n" +
+	"\x05calls\x18\x03 \x03(\v2\x13.deployment.APICallR\x05calls\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x04 \x01(\tR\terrorCode\x12#\n" +
+	"\rerror_details\x18\x05 \x01(\tR\ferrorDetails\".\n" +
+	"\x10AnalyticsRequest\x12\x1a\n" +
+	"\bstrategy\x18\x01 \x01(\tR\bstrategy\"\xb2\a\n" +
+	"\x11AnalyticsResponse\x12+\n" +
+	"\x11total_deployments\x18\x01 \x01(\x03R\x10totalDeployments\x125\n" +
+	"\x16successful_deployments\x18\x02 \x01(\x03R\x15successfulDeployments\x12-\n" +
+	"\x12failed_deployments\x18\x03 \x01(\x03R\x11failedDeployments\x123\n" +
+	"\x15cancelled_deployments\x18\x04 \x01(\x03R\x14cancelledDeployments\x12!\n" +
+	"\fsuccess_rate\x18\x05 \x01(\x01R\vsuccessRate\x128\n" +
+	"\x18average_duration_seconds\x18\x06 \x01(\x01R\x16averageDurationSeconds\x12G\n" +
+	" trimmed_average_duration_seconds\x18\a \x01(\x01R\x1dtrimmedAverageDurationSeconds\x128\n" +
+	"\x18fastest_duration_seconds\x18\b \x01(\x01R\x16fastestDurationSeconds\x128\n" +
+	"\x18slowest_duration_seconds\x18\t \x01(\x01R\x16slowestDurationSeconds\x12?\n" +
+	"\x1craw_slowest_duration_seconds\x18\n" +
+	" \x01(\x01R\x19rawSlowestDurationSeconds\x12c\n" +
+	"\x12strategy_breakdown\x18\v \x03(\v24.deployment.AnalyticsResponse.StrategyBreakdownEntryR\x11strategyBreakdown\x12Z\n" +
+	"\x0ferror_breakdown\x18\f \x03(\v21.deployment.AnalyticsResponse.ErrorBreakdownEntryR\x0eerrorBreakdown\x120\n" +
+	"\x14last_deployment_unix\x18\r \x01(\x03R\x12lastDeploymentUnix\x1aD\n" +
+	"\x16StrategyBreakdownEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\x1aA\n" +
+	"\x13ErrorBreakdownEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\"\x9c\x04\n" +
+	"\x0fMetricsResponse\x12h\n" +
+	"\x15deployments_by_status\x18\x01 \x03(\v24.deployment.MetricsResponse.DeploymentsByStatusEntryR\x13deploymentsByStatus\x12+\n" +
+	"\x11deployments_total\x18\x02 \x01(\x03R\x10deploymentsTotal\x126\n" +
+	"\x17deployments_in_progress\x18\x03 \x01(\x03R\x15deploymentsInProgress\x12\"\n" +
+	"\raws_api_calls\x18\x04 \x01(\x03R\vawsApiCalls\x12$\n" +
+	"\x0eaws_api_errors\x18\x05 \x01(\x03R\fawsApiErrors\x12b\n" +
+	"\x13errors_by_component\x18\x06 \x03(\v22.deployment.MetricsResponse.ErrorsByComponentEntryR\x11errorsByComponent\x1aF\n" +
+	"\x18DeploymentsByStatusEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\x1aD\n" +
+	"\x16ErrorsByComponentEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x03R\x05value:\x028\x01\";\n" +
+	"\vListRequest\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\"\xb2\x02\n" +
+	"\x11DeploymentSummary\x12#\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x1a\n" +
+	"\bstrategy\x18\x03 \x01(\tR\bstrategy\x12&\n" +
+	"\x0fstart_time_unix\x18\x04 \x01(\x03R\rstartTimeUnix\x12\"\n" +
+	"\rend_time_unix\x18\x05 \x01(\x03R\vendTimeUnix\x12\x1a\n" +
+	"\bprogress\x18\x06 \x01(\x05R\bprogress\x12\x1f\n" +
+	"\vcluster_arn\x18\a \x01(\tR\n" +
+	"clusterArn\x12!\n" +
+	"\fservice_name\x18\b \x01(\tR\vserviceName\x12\x18\n" +
+	"\amessage\x18\t \x01(\tR\amessage\"O\n" +
+	"\fListResponse\x12?\n" +
+	"\vdeployments\x18\x01 \x03(\v2\x1d.deployment.DeploymentSummaryR\vdeployments\"\a\n" +
+	"\x05Empty\"\x8c\x01\n" +
+	"\fStrategyInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x1f\n" +
+	"\vconfig_keys\x18\x03 \x03(\tR\n" +
+	"configKeys\x12%\n" +
+	"\x0esupports_pause\x18\x04 \x01(\bR\rsupportsPause\"N\n" +
+	"\x12StrategiesResponse\x128\n" +
+	"\n" +
+	"strategies\x18\x01 \x03(\v2\x18.deployment.StrategyInfoR\n" +
+	"strategies\"\x92\x01\n" +
+	"\x12BatchDeployRequest\x12\x19\n" +
+	"\bbatch_id\x18\x01 \x01(\tR\abatchId\x12;\n" +
+	"\vdeployments\x18\x02 \x03(\v2\x19.deployment.DeployRequestR\vdeployments\x12$\n" +
+	"\x0eall_or_nothing\x18\x03 \x01(\bR\fallOrNothing\"\xcf\x01\n" +
+	"\x11BatchDeployResult\x12#\n" +
+	"\rdeployment_id\x18\x01 \x01(\tR\fdeploymentId\x12\x1f\n" +
+	"\vcluster_arn\x18\x02 \x01(\tR\n" +
+	"clusterArn\x12!\n" +
+	"\fservice_name\x18\x03 \x01(\tR\vserviceName\x12\x18\n" +
+	"\asuccess\x18\x04 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x05 \x01(\tR\amessage\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x06 \x01(\tR\terrorCode\"\xe1\x01\n" +
+	"\x13BatchDeployResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12\x19\n" +
+	"\bbatch_id\x18\x03 \x01(\tR\abatchId\x127\n" +
+	"\aresults\x18\x04 \x03(\v2\x1d.deployment.BatchDeployResultR\aresults\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x05 \x01(\tR\terrorCode\x12#\n" +
+	"\rerror_details\x18\x06 \x01(\tR\ferrorDetails\"/\n" +
+	"\x12BatchStatusRequest\x12\x19\n" +
+	"\bbatch_id\x18\x01 \x01(\tR\abatchId\"\xe8\x01\n" +
+	"\x13BatchStatusResponse\x12\x19\n" +
+	"\bbatch_id\x18\x01 \x01(\tR\abatchId\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12$\n" +
+	"\x0eall_or_nothing\x18\x04 \x01(\bR\fallOrNothing\x12?\n" +
+	"\vdeployments\x18\x05 \x03(\v2\x1d.deployment.DeploymentSummaryR\vdeployments\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x06 \x01(\tR\terrorCode\"z\n" +
+	"\vDiffRequest\x12\x1f\n" +
+	"\vcluster_arn\x18\x01 \x01(\tR\n" +
+	"clusterArn\x12!\n" +
+	"\fservice_name\x18\x02 \x01(\tR\vserviceName\x12'\n" +
+	"\x0ftask_definition\x18\x03 \x01(\tR\x0etaskDefinition\"\x93\x01\n" +
+	"\vFieldChange\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x1c\n" +
+	"\tcontainer\x18\x02 \x01(\tR\tcontainer\x12\x16\n" +
+	"\x06change\x18\x03 \x01(\tR\x06change\x12\x1b\n" +
+	"\told_value\x18\x04 \x01(\tR\boldValue\x12\x1b\n" +
+	"\tnew_value\x18\x05 \x01(\tR\bnewValue\"\xf1\x01\n" +
+	"\fDiffResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x126\n" +
+	"\x17current_task_definition\x18\x03 \x01(\tR\x15currentTaskDefinition\x121\n" +
+	"\achanges\x18\x04 \x03(\v2\x17.deployment.FieldChangeR\achanges\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\x05 \x01(\tR\terrorCode\x12#\n" +
+	"\rerror_details\x18\x06 \x01(\tR\ferrorDetails\"\xa8\x01\n" +
+	"\fDriftRequest\x12\x1f\n" +
+	"\vcluster_arn\x18\x01 \x01(\tR\n" +
+	"clusterArn\x12!\n" +
+	"\fservice_name\x18\x02 \x01(\tR\vserviceName\x128\n" +
+	"\x18expected_task_definition\x18\x03 \x01(\tR\x16expectedTaskDefinition\x12\x1a\n" +
+	"\boperator\x18\x04 \x01(\tR\boperator\"u\n" +
+	"\vDriftDetail\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x1c\n" +
+	"\tcontainer\x18\x02 \x01(\tR\tcontainer\x12\x1a\n" +
+	"\bexpected\x18\x03 \x01(\tR\bexpected\x12\x16\n" +
+	"\x06actual\x18\x04 \x01(\tR\x06actual\"\xde\x02\n" +
+	"\rDriftResponse\x12\x18\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x12!\n" +
+	"\fdrift_status\x18\x03 \x01(\tR\vdriftStatus\x12/\n" +
+	"\x06drifts\x18\x04 \x03(\v2\x17.deployment.DriftDetailR\x06drifts\x12(\n" +
+	"\x10detected_at_unix\x18\x05 \x01(\x03R\x0edetectedAtUnix\x12,\n" +
+	"\x12reconciled_at_unix\x18\x06 \x01(\x03R\x10reconciledAtUnix\x12)\n" +
+	"\x10reconcile_action\x18\a \x01(\tR\x0freconcileAction\x12\x1d\n" +
+	"\n" +
+	"error_code\x18\b \x01(\tR\terrorCode\x12#\n" +
+	"\rerror_details\x18\t \x01(\tR\ferrorDetails2\xf3\r\n" +
 	"\x11DeploymentService\x12?\n" +
 	"\x06Deploy\x12\x19.deployment.DeployRequest\x1a\x1a.deployment.DeployResponse\x12B\n" +
-	"\tGetStatus\x12\x19.deployment.StatusRequest\x1a\x1a.deployment.StatusResponse\x12E\n" +
-	"\bRollback\x12\x1b.deployment.RollbackRequest\x1a\x1c.deployment.RollbackResponse\x12N\n" +
-	"\x11ApproveDeployment\x12\x1b.deployment.ApprovalRequest\x1a\x1c.deployment.ApprovalResponseB\x16Z\x14ecs-plugin-dev/protob\x06proto3"
+	"\tGetStatus\x12\x19.deployment.StatusRequest\x1a\x1a.deployment.StatusResponse\x12G\n" +
+	"\fStreamStatus\x12\x19.deployment.StatusRequest\x1a\x1a.deployment.StatusResponse0\x01\x12E\n" +
+	"\bRollback\x12\x1b.deployment.RollbackRequest\x1a\x1c.deployment.RollbackResponse\x12?\n" +
+	"\x06Cancel\x12\x19.deployment.CancelRequest\x1a\x1a.deployment.CancelResponse\x12F\n" +
+	"\x0fRetryDeployment\x12\x18.deployment.RetryRequest\x1a\x19.deployment.RetryResponse\x12<\n" +
+	"\x05Pause\x12\x18.deployment.PauseRequest\x1a\x19.deployment.PauseResponse\x12?\n" +
+	"\x06Resume\x12\x19.deployment.ResumeRequest\x1a\x1a.deployment.ResumeResponse\x12H\n" +
+	"\vGetManifest\x12\x1b.deployment.ManifestRequest\x1a\x1c.deployment.ManifestResponse\x12H\n" +
+	"\vGetAPICalls\x12\x1b.deployment.APICallsRequest\x1a\x1c.deployment.APICallsResponse\x12Q\n" +
+	"\fForceRelease\x12\x1f.deployment.ForceReleaseRequest\x1a .deployment.ForceReleaseResponse\x12N\n" +
+	"\x11ApproveDeployment\x12\x1b.deployment.ApprovalRequest\x1a\x1c.deployment.ApprovalResponse\x12Z\n" +
+	"\x11GetApprovalStatus\x12!.deployment.ApprovalStatusRequest\x1a\".deployment.ApprovalStatusResponse\x12a\n" +
+	"\x14ListPendingApprovals\x12#.deployment.PendingApprovalsRequest\x1a$.deployment.PendingApprovalsResponse\x12L\n" +
+	"\x11SummarizeServices\x12\x1a.deployment.SummaryRequest\x1a\x1b.deployment.SummaryResponse\x12K\n" +
+	"\fGetAnalytics\x12\x1c.deployment.AnalyticsRequest\x1a\x1d.deployment.AnalyticsResponse\x12D\n" +
+	"\x0fListDeployments\x12\x17.deployment.ListRequest\x1a\x18.deployment.ListResponse\x12C\n" +
+	"\x0eListStrategies\x12\x11.deployment.Empty\x1a\x1e.deployment.StrategiesResponse\x12N\n" +
+	"\vDeployBatch\x12\x1e.deployment.BatchDeployRequest\x1a\x1f.deployment.BatchDeployResponse\x12Q\n" +
+	"\x0eGetBatchStatus\x12\x1e.deployment.BatchStatusRequest\x1a\x1f.deployment.BatchStatusResponse\x129\n" +
+	"\x04Diff\x12\x17.deployment.DiffRequest\x1a\x18.deployment.DiffResponse\x12B\n" +
+	"\vDetectDrift\x12\x18.deployment.DriftRequest\x1a\x19.deployment.DriftResponse\x12E\n" +
+	"\x0eReconcileDrift\x12\x18.deployment.DriftRequest\x1a\x19.deployment.DriftResponse\x12<\n" +
+	"\n" +
+	"GetMetrics\x12\x11.deployment.Empty\x1a\x1b.deployment.MetricsResponseB\x16Z\x14ecs-plugin-dev/protob\x06proto3"
 
 var (
 	file_proto_deployment_proto_rawDescOnce sync.Once
@@ -639,33 +4174,139 @@ func file_proto_deployment_proto_rawDescGZIP() []byte {
 	return file_proto_deployment_proto_rawDescData
 }
 
-var file_proto_deployment_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_proto_deployment_proto_msgTypes = make([]protoimpl.MessageInfo, 58)
 var file_proto_deployment_proto_goTypes = []any{
-	(*DeployRequest)(nil),    // 0: deployment.DeployRequest
-	(*DeployResponse)(nil),   // 1: deployment.DeployResponse
-	(*StatusRequest)(nil),    // 2: deployment.StatusRequest
-	(*StatusResponse)(nil),   // 3: deployment.StatusResponse
-	(*RollbackRequest)(nil),  // 4: deployment.RollbackRequest
-	(*RollbackResponse)(nil), // 5: deployment.RollbackResponse
-	(*ApprovalRequest)(nil),  // 6: deployment.ApprovalRequest
-	(*ApprovalResponse)(nil), // 7: deployment.ApprovalResponse
-	nil,                      // 8: deployment.DeployRequest.ConfigEntry
+	(*DeployRequest)(nil),            // 0: deployment.DeployRequest
+	(*DeployResponse)(nil),           // 1: deployment.DeployResponse
+	(*StatusRequest)(nil),            // 2: deployment.StatusRequest
+	(*StatusResponse)(nil),           // 3: deployment.StatusResponse
+	(*RollbackRequest)(nil),          // 4: deployment.RollbackRequest
+	(*RollbackTarget)(nil),           // 5: deployment.RollbackTarget
+	(*RollbackResponse)(nil),         // 6: deployment.RollbackResponse
+	(*RollbackTargetResult)(nil),     // 7: deployment.RollbackTargetResult
+	(*CancelRequest)(nil),            // 8: deployment.CancelRequest
+	(*CancelResponse)(nil),           // 9: deployment.CancelResponse
+	(*RetryRequest)(nil),             // 10: deployment.RetryRequest
+	(*RetryResponse)(nil),            // 11: deployment.RetryResponse
+	(*PauseRequest)(nil),             // 12: deployment.PauseRequest
+	(*PauseResponse)(nil),            // 13: deployment.PauseResponse
+	(*ResumeRequest)(nil),            // 14: deployment.ResumeRequest
+	(*ResumeResponse)(nil),           // 15: deployment.ResumeResponse
+	(*ApprovalRequest)(nil),          // 16: deployment.ApprovalRequest
+	(*ApprovalResponse)(nil),         // 17: deployment.ApprovalResponse
+	(*ApprovalInfo)(nil),             // 18: deployment.ApprovalInfo
+	(*ApprovalStatusRequest)(nil),    // 19: deployment.ApprovalStatusRequest
+	(*ApprovalStatusResponse)(nil),   // 20: deployment.ApprovalStatusResponse
+	(*PendingApprovalsRequest)(nil),  // 21: deployment.PendingApprovalsRequest
+	(*PendingApprovalsResponse)(nil), // 22: deployment.PendingApprovalsResponse
+	(*SummaryRequest)(nil),           // 23: deployment.SummaryRequest
+	(*ServiceSummary)(nil),           // 24: deployment.ServiceSummary
+	(*SummaryResponse)(nil),          // 25: deployment.SummaryResponse
+	(*ManifestRequest)(nil),          // 26: deployment.ManifestRequest
+	(*ManifestResponse)(nil),         // 27: deployment.ManifestResponse
+	(*ForceReleaseRequest)(nil),      // 28: deployment.ForceReleaseRequest
+	(*ForceReleaseResponse)(nil),     // 29: deployment.ForceReleaseResponse
+	(*APICallsRequest)(nil),          // 30: deployment.APICallsRequest
+	(*APICall)(nil),                  // 31: deployment.APICall
+	(*APICallsResponse)(nil),         // 32: deployment.APICallsResponse
+	(*AnalyticsRequest)(nil),         // 33: deployment.AnalyticsRequest
+	(*AnalyticsResponse)(nil),        // 34: deployment.AnalyticsResponse
+	(*MetricsResponse)(nil),          // 35: deployment.MetricsResponse
+	(*ListRequest)(nil),              // 36: deployment.ListRequest
+	(*DeploymentSummary)(nil),        // 37: deployment.DeploymentSummary
+	(*ListResponse)(nil),             // 38: deployment.ListResponse
+	(*Empty)(nil),                    // 39: deployment.Empty
+	(*StrategyInfo)(nil),             // 40: deployment.StrategyInfo
+	(*StrategiesResponse)(nil),       // 41: deployment.StrategiesResponse
+	(*BatchDeployRequest)(nil),       // 42: deployment.BatchDeployRequest
+	(*BatchDeployResult)(nil),        // 43: deployment.BatchDeployResult
+	(*BatchDeployResponse)(nil),      // 44: deployment.BatchDeployResponse
+	(*BatchStatusRequest)(nil),       // 45: deployment.BatchStatusRequest
+	(*BatchStatusResponse)(nil),      // 46: deployment.BatchStatusResponse
+	(*DiffRequest)(nil),              // 47: deployment.DiffRequest
+	(*FieldChange)(nil),              // 48: deployment.FieldChange
+	(*DiffResponse)(nil),             // 49: deployment.DiffResponse
+	(*DriftRequest)(nil),             // 50: deployment.DriftRequest
+	(*DriftDetail)(nil),              // 51: deployment.DriftDetail
+	(*DriftResponse)(nil),            // 52: deployment.DriftResponse
+	nil,                              // 53: deployment.DeployRequest.ConfigEntry
+	nil,                              // 54: deployment.AnalyticsResponse.StrategyBreakdownEntry
+	nil,                              // 55: deployment.AnalyticsResponse.ErrorBreakdownEntry
+	nil,                              // 56: deployment.MetricsResponse.DeploymentsByStatusEntry
+	nil,                              // 57: deployment.MetricsResponse.ErrorsByComponentEntry
 }
 var file_proto_deployment_proto_depIdxs = []int32{
-	8, // 0: deployment.DeployRequest.config:type_name -> deployment.DeployRequest.ConfigEntry
-	0, // 1: deployment.DeploymentService.Deploy:input_type -> deployment.DeployRequest
-	2, // 2: deployment.DeploymentService.GetStatus:input_type -> deployment.StatusRequest
-	4, // 3: deployment.DeploymentService.Rollback:input_type -> deployment.RollbackRequest
-	6, // 4: deployment.DeploymentService.ApproveDeployment:input_type -> deployment.ApprovalRequest
-	1, // 5: deployment.DeploymentService.Deploy:output_type -> deployment.DeployResponse
-	3, // 6: deployment.DeploymentService.GetStatus:output_type -> deployment.StatusResponse
-	5, // 7: deployment.DeploymentService.Rollback:output_type -> deployment.RollbackResponse
-	7, // 8: deployment.DeploymentService.ApproveDeployment:output_type -> deployment.ApprovalResponse
-	5, // [5:9] is the sub-list for method output_type
-	1, // [1:5] is the sub-list for method input_type
-	1, // [1:1] is the sub-list for extension type_name
-	1, // [1:1] is the sub-list for extension extendee
-	0, // [0:1] is the sub-list for field type_name
+	53, // 0: deployment.DeployRequest.config:type_name -> deployment.DeployRequest.ConfigEntry
+	5,  // 1: deployment.RollbackRequest.targets:type_name -> deployment.RollbackTarget
+	7,  // 2: deployment.RollbackResponse.results:type_name -> deployment.RollbackTargetResult
+	18, // 3: deployment.ApprovalStatusResponse.approval:type_name -> deployment.ApprovalInfo
+	18, // 4: deployment.PendingApprovalsResponse.approvals:type_name -> deployment.ApprovalInfo
+	24, // 5: deployment.SummaryResponse.services:type_name -> deployment.ServiceSummary
+	31, // 6: deployment.APICallsResponse.calls:type_name -> deployment.APICall
+	54, // 7: deployment.AnalyticsResponse.strategy_breakdown:type_name -> deployment.AnalyticsResponse.StrategyBreakdownEntry
+	55, // 8: deployment.AnalyticsResponse.error_breakdown:type_name -> deployment.AnalyticsResponse.ErrorBreakdownEntry
+	56, // 9: deployment.MetricsResponse.deployments_by_status:type_name -> deployment.MetricsResponse.DeploymentsByStatusEntry
+	57, // 10: deployment.MetricsResponse.errors_by_component:type_name -> deployment.MetricsResponse.ErrorsByComponentEntry
+	37, // 11: deployment.ListResponse.deployments:type_name -> deployment.DeploymentSummary
+	40, // 12: deployment.StrategiesResponse.strategies:type_name -> deployment.StrategyInfo
+	0,  // 13: deployment.BatchDeployRequest.deployments:type_name -> deployment.DeployRequest
+	43, // 14: deployment.BatchDeployResponse.results:type_name -> deployment.BatchDeployResult
+	37, // 15: deployment.BatchStatusResponse.deployments:type_name -> deployment.DeploymentSummary
+	48, // 16: deployment.DiffResponse.changes:type_name -> deployment.FieldChange
+	51, // 17: deployment.DriftResponse.drifts:type_name -> deployment.DriftDetail
+	0,  // 18: deployment.DeploymentService.Deploy:input_type -> deployment.DeployRequest
+	2,  // 19: deployment.DeploymentService.GetStatus:input_type -> deployment.StatusRequest
+	2,  // 20: deployment.DeploymentService.StreamStatus:input_type -> deployment.StatusRequest
+	4,  // 21: deployment.DeploymentService.Rollback:input_type -> deployment.RollbackRequest
+	8,  // 22: deployment.DeploymentService.Cancel:input_type -> deployment.CancelRequest
+	10, // 23: deployment.DeploymentService.RetryDeployment:input_type -> deployment.RetryRequest
+	12, // 24: deployment.DeploymentService.Pause:input_type -> deployment.PauseRequest
+	14, // 25: deployment.DeploymentService.Resume:input_type -> deployment.ResumeRequest
+	26, // 26: deployment.DeploymentService.GetManifest:input_type -> deployment.ManifestRequest
+	30, // 27: deployment.DeploymentService.GetAPICalls:input_type -> deployment.APICallsRequest
+	28, // 28: deployment.DeploymentService.ForceRelease:input_type -> deployment.ForceReleaseRequest
+	16, // 29: deployment.DeploymentService.ApproveDeployment:input_type -> deployment.ApprovalRequest
+	19, // 30: deployment.DeploymentService.GetApprovalStatus:input_type -> deployment.ApprovalStatusRequest
+	21, // 31: deployment.DeploymentService.ListPendingApprovals:input_type -> deployment.PendingApprovalsRequest
+	23, // 32: deployment.DeploymentService.SummarizeServices:input_type -> deployment.SummaryRequest
+	33, // 33: deployment.DeploymentService.GetAnalytics:input_type -> deployment.AnalyticsRequest
+	36, // 34: deployment.DeploymentService.ListDeployments:input_type -> deployment.ListRequest
+	39, // 35: deployment.DeploymentService.ListStrategies:input_type -> deployment.Empty
+	42, // 36: deployment.DeploymentService.DeployBatch:input_type -> deployment.BatchDeployRequest
+	45, // 37: deployment.DeploymentService.GetBatchStatus:input_type -> deployment.BatchStatusRequest
+	47, // 38: deployment.DeploymentService.Diff:input_type -> deployment.DiffRequest
+	50, // 39: deployment.DeploymentService.DetectDrift:input_type -> deployment.DriftRequest
+	50, // 40: deployment.DeploymentService.ReconcileDrift:input_type -> deployment.DriftRequest
+	39, // 41: deployment.DeploymentService.GetMetrics:input_type -> deployment.Empty
+	1,  // 42: deployment.DeploymentService.Deploy:output_type -> deployment.DeployResponse
+	3,  // 43: deployment.DeploymentService.GetStatus:output_type -> deployment.StatusResponse
+	3,  // 44: deployment.DeploymentService.StreamStatus:output_type -> deployment.StatusResponse
+	6,  // 45: deployment.DeploymentService.Rollback:output_type -> deployment.RollbackResponse
+	9,  // 46: deployment.DeploymentService.Cancel:output_type -> deployment.CancelResponse
+	11, // 47: deployment.DeploymentService.RetryDeployment:output_type -> deployment.RetryResponse
+	13, // 48: deployment.DeploymentService.Pause:output_type -> deployment.PauseResponse
+	15, // 49: deployment.DeploymentService.Resume:output_type -> deployment.ResumeResponse
+	27, // 50: deployment.DeploymentService.GetManifest:output_type -> deployment.ManifestResponse
+	32, // 51: deployment.DeploymentService.GetAPICalls:output_type -> deployment.APICallsResponse
+	29, // 52: deployment.DeploymentService.ForceRelease:output_type -> deployment.ForceReleaseResponse
+	17, // 53: deployment.DeploymentService.ApproveDeployment:output_type -> deployment.ApprovalResponse
+	20, // 54: deployment.DeploymentService.GetApprovalStatus:output_type -> deployment.ApprovalStatusResponse
+	22, // 55: deployment.DeploymentService.ListPendingApprovals:output_type -> deployment.PendingApprovalsResponse
+	25, // 56: deployment.DeploymentService.SummarizeServices:output_type -> deployment.SummaryResponse
+	34, // 57: deployment.DeploymentService.GetAnalytics:output_type -> deployment.AnalyticsResponse
+	38, // 58: deployment.DeploymentService.ListDeployments:output_type -> deployment.ListResponse
+	41, // 59: deployment.DeploymentService.ListStrategies:output_type -> deployment.StrategiesResponse
+	44, // 60: deployment.DeploymentService.DeployBatch:output_type -> deployment.BatchDeployResponse
+	46, // 61: deployment.DeploymentService.GetBatchStatus:output_type -> deployment.BatchStatusResponse
+	49, // 62: deployment.DeploymentService.Diff:output_type -> deployment.DiffResponse
+	52, // 63: deployment.DeploymentService.DetectDrift:output_type -> deployment.DriftResponse
+	52, // 64: deployment.DeploymentService.ReconcileDrift:output_type -> deployment.DriftResponse
+	35, // 65: deployment.DeploymentService.GetMetrics:output_type -> deployment.MetricsResponse
+	42, // [42:66] is the sub-list for method output_type
+	18, // [18:42] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_proto_deployment_proto_init() }
@@ -679,7 +4320,7 @@ func file_proto_deployment_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_deployment_proto_rawDesc), len(file_proto_deployment_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   58,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -21,10 +21,30 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	DeploymentService_Deploy_FullMethodName            = "/deployment.DeploymentService/Deploy"
-	DeploymentService_GetStatus_FullMethodName         = "/deployment.DeploymentService/GetStatus"
-	DeploymentService_Rollback_FullMethodName          = "/deployment.DeploymentService/Rollback"
-	DeploymentService_ApproveDeployment_FullMethodName = "/deployment.DeploymentService/ApproveDeployment"
+	DeploymentService_Deploy_FullMethodName               = "/deployment.DeploymentService/Deploy"
+	DeploymentService_GetStatus_FullMethodName            = "/deployment.DeploymentService/GetStatus"
+	DeploymentService_StreamStatus_FullMethodName         = "/deployment.DeploymentService/StreamStatus"
+	DeploymentService_Rollback_FullMethodName             = "/deployment.DeploymentService/Rollback"
+	DeploymentService_Cancel_FullMethodName               = "/deployment.DeploymentService/Cancel"
+	DeploymentService_RetryDeployment_FullMethodName      = "/deployment.DeploymentService/RetryDeployment"
+	DeploymentService_Pause_FullMethodName                = "/deployment.DeploymentService/Pause"
+	DeploymentService_Resume_FullMethodName               = "/deployment.DeploymentService/Resume"
+	DeploymentService_GetManifest_FullMethodName          = "/deployment.DeploymentService/GetManifest"
+	DeploymentService_GetAPICalls_FullMethodName          = "/deployment.DeploymentService/GetAPICalls"
+	DeploymentService_ForceRelease_FullMethodName         = "/deployment.DeploymentService/ForceRelease"
+	DeploymentService_ApproveDeployment_FullMethodName    = "/deployment.DeploymentService/ApproveDeployment"
+	DeploymentService_GetApprovalStatus_FullMethodName    = "/deployment.DeploymentService/GetApprovalStatus"
+	DeploymentService_ListPendingApprovals_FullMethodName = "/deployment.DeploymentService/ListPendingApprovals"
+	DeploymentService_SummarizeServices_FullMethodName    = "/deployment.DeploymentService/SummarizeServices"
+	DeploymentService_GetAnalytics_FullMethodName         = "/deployment.DeploymentService/GetAnalytics"
+	DeploymentService_ListDeployments_FullMethodName      = "/deployment.DeploymentService/ListDeployments"
+	DeploymentService_ListStrategies_FullMethodName       = "/deployment.DeploymentService/ListStrategies"
+	DeploymentService_DeployBatch_FullMethodName          = "/deployment.DeploymentService/DeployBatch"
+	DeploymentService_GetBatchStatus_FullMethodName       = "/deployment.DeploymentService/GetBatchStatus"
+	DeploymentService_Diff_FullMethodName                 = "/deployment.DeploymentService/Diff"
+	DeploymentService_DetectDrift_FullMethodName          = "/deployment.DeploymentService/DetectDrift"
+	DeploymentService_ReconcileDrift_FullMethodName       = "/deployment.DeploymentService/ReconcileDrift"
+	DeploymentService_GetMetrics_FullMethodName           = "/deployment.DeploymentService/GetMetrics"
 )
 
 // DeploymentServiceClient is the client API for DeploymentService service.
@@ -33,8 +53,28 @@ const (
 type DeploymentServiceClient interface {
 	Deploy(ctx context.Context, in *DeployRequest, opts ...grpc.CallOption) (*DeployResponse, error)
 	GetStatus(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error)
+	StreamStatus(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (DeploymentService_StreamStatusClient, error)
 	Rollback(ctx context.Context, in *RollbackRequest, opts ...grpc.CallOption) (*RollbackResponse, error)
+	Cancel(ctx context.Context, in *CancelRequest, opts ...grpc.CallOption) (*CancelResponse, error)
+	RetryDeployment(ctx context.Context, in *RetryRequest, opts ...grpc.CallOption) (*RetryResponse, error)
+	Pause(ctx context.Context, in *PauseRequest, opts ...grpc.CallOption) (*PauseResponse, error)
+	Resume(ctx context.Context, in *ResumeRequest, opts ...grpc.CallOption) (*ResumeResponse, error)
+	GetManifest(ctx context.Context, in *ManifestRequest, opts ...grpc.CallOption) (*ManifestResponse, error)
+	GetAPICalls(ctx context.Context, in *APICallsRequest, opts ...grpc.CallOption) (*APICallsResponse, error)
+	ForceRelease(ctx context.Context, in *ForceReleaseRequest, opts ...grpc.CallOption) (*ForceReleaseResponse, error)
 	ApproveDeployment(ctx context.Context, in *ApprovalRequest, opts ...grpc.CallOption) (*ApprovalResponse, error)
+	GetApprovalStatus(ctx context.Context, in *ApprovalStatusRequest, opts ...grpc.CallOption) (*ApprovalStatusResponse, error)
+	ListPendingApprovals(ctx context.Context, in *PendingApprovalsRequest, opts ...grpc.CallOption) (*PendingApprovalsResponse, error)
+	SummarizeServices(ctx context.Context, in *SummaryRequest, opts ...grpc.CallOption) (*SummaryResponse, error)
+	GetAnalytics(ctx context.Context, in *AnalyticsRequest, opts ...grpc.CallOption) (*AnalyticsResponse, error)
+	ListDeployments(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error)
+	ListStrategies(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StrategiesResponse, error)
+	DeployBatch(ctx context.Context, in *BatchDeployRequest, opts ...grpc.CallOption) (*BatchDeployResponse, error)
+	GetBatchStatus(ctx context.Context, in *BatchStatusRequest, opts ...grpc.CallOption) (*BatchStatusResponse, error)
+	Diff(ctx context.Context, in *DiffRequest, opts ...grpc.CallOption) (*DiffResponse, error)
+	DetectDrift(ctx context.Context, in *DriftRequest, opts ...grpc.CallOption) (*DriftResponse, error)
+	ReconcileDrift(ctx context.Context, in *DriftRequest, opts ...grpc.CallOption) (*DriftResponse, error)
+	GetMetrics(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MetricsResponse, error)
 }
 
 type deploymentServiceClient struct {
@@ -63,6 +103,38 @@ func (c *deploymentServiceClient) GetStatus(ctx context.Context, in *StatusReque
 	return out, nil
 }
 
+func (c *deploymentServiceClient) StreamStatus(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (DeploymentService_StreamStatusClient, error) {
+	stream, err := c.cc.NewStream(ctx, &DeploymentService_ServiceDesc.Streams[0], DeploymentService_StreamStatus_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &deploymentServiceStreamStatusClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type DeploymentService_StreamStatusClient interface {
+	Recv() (*StatusResponse, error)
+	grpc.ClientStream
+}
+
+type deploymentServiceStreamStatusClient struct {
+	grpc.ClientStream
+}
+
+func (x *deploymentServiceStreamStatusClient) Recv() (*StatusResponse, error) {
+	m := new(StatusResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *deploymentServiceClient) Rollback(ctx context.Context, in *RollbackRequest, opts ...grpc.CallOption) (*RollbackResponse, error) {
 	out := new(RollbackResponse)
 	err := c.cc.Invoke(ctx, DeploymentService_Rollback_FullMethodName, in, out, opts...)
@@ -72,6 +144,69 @@ func (c *deploymentServiceClient) Rollback(ctx context.Context, in *RollbackRequ
 	return out, nil
 }
 
+func (c *deploymentServiceClient) Cancel(ctx context.Context, in *CancelRequest, opts ...grpc.CallOption) (*CancelResponse, error) {
+	out := new(CancelResponse)
+	err := c.cc.Invoke(ctx, DeploymentService_Cancel_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deploymentServiceClient) RetryDeployment(ctx context.Context, in *RetryRequest, opts ...grpc.CallOption) (*RetryResponse, error) {
+	out := new(RetryResponse)
+	err := c.cc.Invoke(ctx, DeploymentService_RetryDeployment_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deploymentServiceClient) Pause(ctx context.Context, in *PauseRequest, opts ...grpc.CallOption) (*PauseResponse, error) {
+	out := new(PauseResponse)
+	err := c.cc.Invoke(ctx, DeploymentService_Pause_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deploymentServiceClient) Resume(ctx context.Context, in *ResumeRequest, opts ...grpc.CallOption) (*ResumeResponse, error) {
+	out := new(ResumeResponse)
+	err := c.cc.Invoke(ctx, DeploymentService_Resume_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deploymentServiceClient) GetManifest(ctx context.Context, in *ManifestRequest, opts ...grpc.CallOption) (*ManifestResponse, error) {
+	out := new(ManifestResponse)
+	err := c.cc.Invoke(ctx, DeploymentService_GetManifest_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deploymentServiceClient) GetAPICalls(ctx context.Context, in *APICallsRequest, opts ...grpc.CallOption) (*APICallsResponse, error) {
+	out := new(APICallsResponse)
+	err := c.cc.Invoke(ctx, DeploymentService_GetAPICalls_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deploymentServiceClient) ForceRelease(ctx context.Context, in *ForceReleaseRequest, opts ...grpc.CallOption) (*ForceReleaseResponse, error) {
+	out := new(ForceReleaseResponse)
+	err := c.cc.Invoke(ctx, DeploymentService_ForceRelease_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *deploymentServiceClient) ApproveDeployment(ctx context.Context, in *ApprovalRequest, opts ...grpc.CallOption) (*ApprovalResponse, error) {
 	out := new(ApprovalResponse)
 	err := c.cc.Invoke(ctx, DeploymentService_ApproveDeployment_FullMethodName, in, out, opts...)
@@ -81,14 +216,142 @@ func (c *deploymentServiceClient) ApproveDeployment(ctx context.Context, in *App
 	return out, nil
 }
 
+func (c *deploymentServiceClient) GetApprovalStatus(ctx context.Context, in *ApprovalStatusRequest, opts ...grpc.CallOption) (*ApprovalStatusResponse, error) {
+	out := new(ApprovalStatusResponse)
+	err := c.cc.Invoke(ctx, DeploymentService_GetApprovalStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deploymentServiceClient) ListPendingApprovals(ctx context.Context, in *PendingApprovalsRequest, opts ...grpc.CallOption) (*PendingApprovalsResponse, error) {
+	out := new(PendingApprovalsResponse)
+	err := c.cc.Invoke(ctx, DeploymentService_ListPendingApprovals_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deploymentServiceClient) SummarizeServices(ctx context.Context, in *SummaryRequest, opts ...grpc.CallOption) (*SummaryResponse, error) {
+	out := new(SummaryResponse)
+	err := c.cc.Invoke(ctx, DeploymentService_SummarizeServices_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deploymentServiceClient) GetAnalytics(ctx context.Context, in *AnalyticsRequest, opts ...grpc.CallOption) (*AnalyticsResponse, error) {
+	out := new(AnalyticsResponse)
+	err := c.cc.Invoke(ctx, DeploymentService_GetAnalytics_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deploymentServiceClient) ListDeployments(ctx context.Context, in *ListRequest, opts ...grpc.CallOption) (*ListResponse, error) {
+	out := new(ListResponse)
+	err := c.cc.Invoke(ctx, DeploymentService_ListDeployments_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deploymentServiceClient) ListStrategies(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*StrategiesResponse, error) {
+	out := new(StrategiesResponse)
+	err := c.cc.Invoke(ctx, DeploymentService_ListStrategies_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deploymentServiceClient) DeployBatch(ctx context.Context, in *BatchDeployRequest, opts ...grpc.CallOption) (*BatchDeployResponse, error) {
+	out := new(BatchDeployResponse)
+	err := c.cc.Invoke(ctx, DeploymentService_DeployBatch_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deploymentServiceClient) GetBatchStatus(ctx context.Context, in *BatchStatusRequest, opts ...grpc.CallOption) (*BatchStatusResponse, error) {
+	out := new(BatchStatusResponse)
+	err := c.cc.Invoke(ctx, DeploymentService_GetBatchStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deploymentServiceClient) Diff(ctx context.Context, in *DiffRequest, opts ...grpc.CallOption) (*DiffResponse, error) {
+	out := new(DiffResponse)
+	err := c.cc.Invoke(ctx, DeploymentService_Diff_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deploymentServiceClient) DetectDrift(ctx context.Context, in *DriftRequest, opts ...grpc.CallOption) (*DriftResponse, error) {
+	out := new(DriftResponse)
+	err := c.cc.Invoke(ctx, DeploymentService_DetectDrift_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deploymentServiceClient) ReconcileDrift(ctx context.Context, in *DriftRequest, opts ...grpc.CallOption) (*DriftResponse, error) {
+	out := new(DriftResponse)
+	err := c.cc.Invoke(ctx, DeploymentService_ReconcileDrift_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *deploymentServiceClient) GetMetrics(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MetricsResponse, error) {
+	out := new(MetricsResponse)
+	err := c.cc.Invoke(ctx, DeploymentService_GetMetrics_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DeploymentServiceServer is the server API for DeploymentService service.
 // All implementations must embed UnimplementedDeploymentServiceServer
 // for forward compatibility
 type DeploymentServiceServer interface {
 	Deploy(context.Context, *DeployRequest) (*DeployResponse, error)
 	GetStatus(context.Context, *StatusRequest) (*StatusResponse, error)
+	StreamStatus(*StatusRequest, DeploymentService_StreamStatusServer) error
 	Rollback(context.Context, *RollbackRequest) (*RollbackResponse, error)
+	Cancel(context.Context, *CancelRequest) (*CancelResponse, error)
+	RetryDeployment(context.Context, *RetryRequest) (*RetryResponse, error)
+	Pause(context.Context, *PauseRequest) (*PauseResponse, error)
+	Resume(context.Context, *ResumeRequest) (*ResumeResponse, error)
+	GetManifest(context.Context, *ManifestRequest) (*ManifestResponse, error)
+	GetAPICalls(context.Context, *APICallsRequest) (*APICallsResponse, error)
+	ForceRelease(context.Context, *ForceReleaseRequest) (*ForceReleaseResponse, error)
 	ApproveDeployment(context.Context, *ApprovalRequest) (*ApprovalResponse, error)
+	GetApprovalStatus(context.Context, *ApprovalStatusRequest) (*ApprovalStatusResponse, error)
+	ListPendingApprovals(context.Context, *PendingApprovalsRequest) (*PendingApprovalsResponse, error)
+	SummarizeServices(context.Context, *SummaryRequest) (*SummaryResponse, error)
+	GetAnalytics(context.Context, *AnalyticsRequest) (*AnalyticsResponse, error)
+	ListDeployments(context.Context, *ListRequest) (*ListResponse, error)
+	ListStrategies(context.Context, *Empty) (*StrategiesResponse, error)
+	DeployBatch(context.Context, *BatchDeployRequest) (*BatchDeployResponse, error)
+	GetBatchStatus(context.Context, *BatchStatusRequest) (*BatchStatusResponse, error)
+	Diff(context.Context, *DiffRequest) (*DiffResponse, error)
+	DetectDrift(context.Context, *DriftRequest) (*DriftResponse, error)
+	ReconcileDrift(context.Context, *DriftRequest) (*DriftResponse, error)
+	GetMetrics(context.Context, *Empty) (*MetricsResponse, error)
 	mustEmbedUnimplementedDeploymentServiceServer()
 }
 
@@ -102,12 +365,72 @@ func (UnimplementedDeploymentServiceServer) Deploy(context.Context, *DeployReque
 func (UnimplementedDeploymentServiceServer) GetStatus(context.Context, *StatusRequest) (*StatusResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetStatus not implemented")
 }
+func (UnimplementedDeploymentServiceServer) StreamStatus(*StatusRequest, DeploymentService_StreamStatusServer) error {
+	return status.Errorf(codes.Unimplemented, "method StreamStatus not implemented")
+}
 func (UnimplementedDeploymentServiceServer) Rollback(context.Context, *RollbackRequest) (*RollbackResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Rollback not implemented")
 }
+func (UnimplementedDeploymentServiceServer) Cancel(context.Context, *CancelRequest) (*CancelResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Cancel not implemented")
+}
+func (UnimplementedDeploymentServiceServer) RetryDeployment(context.Context, *RetryRequest) (*RetryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RetryDeployment not implemented")
+}
+func (UnimplementedDeploymentServiceServer) Pause(context.Context, *PauseRequest) (*PauseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Pause not implemented")
+}
+func (UnimplementedDeploymentServiceServer) Resume(context.Context, *ResumeRequest) (*ResumeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Resume not implemented")
+}
+func (UnimplementedDeploymentServiceServer) GetManifest(context.Context, *ManifestRequest) (*ManifestResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetManifest not implemented")
+}
+func (UnimplementedDeploymentServiceServer) GetAPICalls(context.Context, *APICallsRequest) (*APICallsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAPICalls not implemented")
+}
+func (UnimplementedDeploymentServiceServer) ForceRelease(context.Context, *ForceReleaseRequest) (*ForceReleaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ForceRelease not implemented")
+}
 func (UnimplementedDeploymentServiceServer) ApproveDeployment(context.Context, *ApprovalRequest) (*ApprovalResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ApproveDeployment not implemented")
 }
+func (UnimplementedDeploymentServiceServer) GetApprovalStatus(context.Context, *ApprovalStatusRequest) (*ApprovalStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetApprovalStatus not implemented")
+}
+func (UnimplementedDeploymentServiceServer) ListPendingApprovals(context.Context, *PendingApprovalsRequest) (*PendingApprovalsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPendingApprovals not implemented")
+}
+func (UnimplementedDeploymentServiceServer) SummarizeServices(context.Context, *SummaryRequest) (*SummaryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SummarizeServices not implemented")
+}
+func (UnimplementedDeploymentServiceServer) GetAnalytics(context.Context, *AnalyticsRequest) (*AnalyticsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAnalytics not implemented")
+}
+func (UnimplementedDeploymentServiceServer) ListDeployments(context.Context, *ListRequest) (*ListResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListDeployments not implemented")
+}
+func (UnimplementedDeploymentServiceServer) ListStrategies(context.Context, *Empty) (*StrategiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListStrategies not implemented")
+}
+func (UnimplementedDeploymentServiceServer) DeployBatch(context.Context, *BatchDeployRequest) (*BatchDeployResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeployBatch not implemented")
+}
+func (UnimplementedDeploymentServiceServer) GetBatchStatus(context.Context, *BatchStatusRequest) (*BatchStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBatchStatus not implemented")
+}
+func (UnimplementedDeploymentServiceServer) Diff(context.Context, *DiffRequest) (*DiffResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Diff not implemented")
+}
+func (UnimplementedDeploymentServiceServer) DetectDrift(context.Context, *DriftRequest) (*DriftResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DetectDrift not implemented")
+}
+func (UnimplementedDeploymentServiceServer) ReconcileDrift(context.Context, *DriftRequest) (*DriftResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReconcileDrift not implemented")
+}
+func (UnimplementedDeploymentServiceServer) GetMetrics(context.Context, *Empty) (*MetricsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMetrics not implemented")
+}
 func (UnimplementedDeploymentServiceServer) mustEmbedUnimplementedDeploymentServiceServer() {}
 
 // UnsafeDeploymentServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -157,6 +480,27 @@ func _DeploymentService_GetStatus_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DeploymentService_StreamStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(StatusRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DeploymentServiceServer).StreamStatus(m, &deploymentServiceStreamStatusServer{stream})
+}
+
+type DeploymentService_StreamStatusServer interface {
+	Send(*StatusResponse) error
+	grpc.ServerStream
+}
+
+type deploymentServiceStreamStatusServer struct {
+	grpc.ServerStream
+}
+
+func (x *deploymentServiceStreamStatusServer) Send(m *StatusResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _DeploymentService_Rollback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RollbackRequest)
 	if err := dec(in); err != nil {
@@ -175,6 +519,132 @@ func _DeploymentService_Rollback_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DeploymentService_Cancel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeploymentServiceServer).Cancel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeploymentService_Cancel_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeploymentServiceServer).Cancel(ctx, req.(*CancelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeploymentService_RetryDeployment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RetryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeploymentServiceServer).RetryDeployment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeploymentService_RetryDeployment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeploymentServiceServer).RetryDeployment(ctx, req.(*RetryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeploymentService_Pause_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeploymentServiceServer).Pause(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeploymentService_Pause_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeploymentServiceServer).Pause(ctx, req.(*PauseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeploymentService_Resume_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeploymentServiceServer).Resume(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeploymentService_Resume_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeploymentServiceServer).Resume(ctx, req.(*ResumeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeploymentService_GetManifest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ManifestRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeploymentServiceServer).GetManifest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeploymentService_GetManifest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeploymentServiceServer).GetManifest(ctx, req.(*ManifestRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeploymentService_GetAPICalls_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(APICallsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeploymentServiceServer).GetAPICalls(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeploymentService_GetAPICalls_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeploymentServiceServer).GetAPICalls(ctx, req.(*APICallsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeploymentService_ForceRelease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ForceReleaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeploymentServiceServer).ForceRelease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeploymentService_ForceRelease_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeploymentServiceServer).ForceRelease(ctx, req.(*ForceReleaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _DeploymentService_ApproveDeployment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ApprovalRequest)
 	if err := dec(in); err != nil {
@@ -193,6 +663,222 @@ func _DeploymentService_ApproveDeployment_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _DeploymentService_GetApprovalStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApprovalStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeploymentServiceServer).GetApprovalStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeploymentService_GetApprovalStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeploymentServiceServer).GetApprovalStatus(ctx, req.(*ApprovalStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeploymentService_ListPendingApprovals_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PendingApprovalsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeploymentServiceServer).ListPendingApprovals(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeploymentService_ListPendingApprovals_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeploymentServiceServer).ListPendingApprovals(ctx, req.(*PendingApprovalsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeploymentService_SummarizeServices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SummaryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeploymentServiceServer).SummarizeServices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeploymentService_SummarizeServices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeploymentServiceServer).SummarizeServices(ctx, req.(*SummaryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeploymentService_GetAnalytics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AnalyticsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeploymentServiceServer).GetAnalytics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeploymentService_GetAnalytics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeploymentServiceServer).GetAnalytics(ctx, req.(*AnalyticsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeploymentService_ListDeployments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeploymentServiceServer).ListDeployments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeploymentService_ListDeployments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeploymentServiceServer).ListDeployments(ctx, req.(*ListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeploymentService_ListStrategies_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeploymentServiceServer).ListStrategies(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeploymentService_ListStrategies_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeploymentServiceServer).ListStrategies(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeploymentService_DeployBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchDeployRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeploymentServiceServer).DeployBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeploymentService_DeployBatch_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeploymentServiceServer).DeployBatch(ctx, req.(*BatchDeployRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeploymentService_GetBatchStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeploymentServiceServer).GetBatchStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeploymentService_GetBatchStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeploymentServiceServer).GetBatchStatus(ctx, req.(*BatchStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeploymentService_Diff_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeploymentServiceServer).Diff(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeploymentService_Diff_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeploymentServiceServer).Diff(ctx, req.(*DiffRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeploymentService_DetectDrift_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DriftRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeploymentServiceServer).DetectDrift(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeploymentService_DetectDrift_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeploymentServiceServer).DetectDrift(ctx, req.(*DriftRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeploymentService_ReconcileDrift_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DriftRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeploymentServiceServer).ReconcileDrift(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeploymentService_ReconcileDrift_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeploymentServiceServer).ReconcileDrift(ctx, req.(*DriftRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _DeploymentService_GetMetrics_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DeploymentServiceServer).GetMetrics(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: DeploymentService_GetMetrics_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DeploymentServiceServer).GetMetrics(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // DeploymentService_ServiceDesc is the grpc.ServiceDesc for DeploymentService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -212,11 +898,93 @@ var DeploymentService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Rollback",
 			Handler:    _DeploymentService_Rollback_Handler,
 		},
+		{
+			MethodName: "Cancel",
+			Handler:    _DeploymentService_Cancel_Handler,
+		},
+		{
+			MethodName: "RetryDeployment",
+			Handler:    _DeploymentService_RetryDeployment_Handler,
+		},
+		{
+			MethodName: "Pause",
+			Handler:    _DeploymentService_Pause_Handler,
+		},
+		{
+			MethodName: "Resume",
+			Handler:    _DeploymentService_Resume_Handler,
+		},
+		{
+			MethodName: "GetManifest",
+			Handler:    _DeploymentService_GetManifest_Handler,
+		},
+		{
+			MethodName: "GetAPICalls",
+			Handler:    _DeploymentService_GetAPICalls_Handler,
+		},
+		{
+			MethodName: "ForceRelease",
+			Handler:    _DeploymentService_ForceRelease_Handler,
+		},
 		{
 			MethodName: "ApproveDeployment",
 			Handler:    _DeploymentService_ApproveDeployment_Handler,
 		},
+		{
+			MethodName: "GetApprovalStatus",
+			Handler:    _DeploymentService_GetApprovalStatus_Handler,
+		},
+		{
+			MethodName: "ListPendingApprovals",
+			Handler:    _DeploymentService_ListPendingApprovals_Handler,
+		},
+		{
+			MethodName: "SummarizeServices",
+			Handler:    _DeploymentService_SummarizeServices_Handler,
+		},
+		{
+			MethodName: "GetAnalytics",
+			Handler:    _DeploymentService_GetAnalytics_Handler,
+		},
+		{
+			MethodName: "ListDeployments",
+			Handler:    _DeploymentService_ListDeployments_Handler,
+		},
+		{
+			MethodName: "ListStrategies",
+			Handler:    _DeploymentService_ListStrategies_Handler,
+		},
+		{
+			MethodName: "DeployBatch",
+			Handler:    _DeploymentService_DeployBatch_Handler,
+		},
+		{
+			MethodName: "GetBatchStatus",
+			Handler:    _DeploymentService_GetBatchStatus_Handler,
+		},
+		{
+			MethodName: "Diff",
+			Handler:    _DeploymentService_Diff_Handler,
+		},
+		{
+			MethodName: "DetectDrift",
+			Handler:    _DeploymentService_DetectDrift_Handler,
+		},
+		{
+			MethodName: "ReconcileDrift",
+			Handler:    _DeploymentService_ReconcileDrift_Handler,
+		},
+		{
+			MethodName: "GetMetrics",
+			Handler:    _DeploymentService_GetMetrics_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "StreamStatus",
+			Handler:       _DeploymentService_StreamStatus_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/deployment.proto",
 }